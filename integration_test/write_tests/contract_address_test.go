@@ -0,0 +1,77 @@
+package write_tests
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pkg/client"
+	"github.com/kslamph/tronlib/pkg/signer"
+	"github.com/kslamph/tronlib/pkg/smartcontract"
+)
+
+// TestComputeContractAddress_Nile deploys MinimalContract and checks that the
+// address derived offline from the deployment txid matches the one the node
+// reports in the receipt.
+func TestComputeContractAddress_Nile(t *testing.T) {
+	loadEnv("../../cmd/setup_nile_testnet/test.env")
+
+	c, err := newTestNileClient()
+	if err != nil {
+		t.Fatalf("failed to create Nile client: %v", err)
+	}
+	defer c.Close()
+
+	key := os.Getenv("INTEGRATION_TEST_KEY1")
+	if key == "" {
+		t.Fatal("INTEGRATION_TEST_KEY1 not set")
+	}
+	s, err := signer.NewPrivateKeySigner(key)
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+
+	build := "../../cmd/setup_nile_testnet/test_contract/build"
+	abiJSON, err := os.ReadFile(filepath.Join(build, "MinimalContract.abi"))
+	if err != nil {
+		t.Fatalf("read ABI: %v", err)
+	}
+	bin, err := os.ReadFile(filepath.Join(build, "MinimalContract.bin"))
+	if err != nil {
+		t.Fatalf("read bytecode: %v", err)
+	}
+	bytecode, err := hex.DecodeString(strings.TrimSpace(string(bin)))
+	if err != nil {
+		t.Fatalf("decode bytecode: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	txExt, err := c.SmartContract().Deploy(ctx, s.Address(), "MinimalContract", string(abiJSON), bytecode, 0, 100, 10_000_000)
+	if err != nil {
+		t.Fatalf("Deploy: %v", err)
+	}
+	res, err := c.SignAndBroadcast(ctx, txExt, client.BroadcastOptions{FeeLimit: 2_000_000_000, WaitForReceipt: true}, s)
+	if err != nil {
+		t.Fatalf("SignAndBroadcast: %v", err)
+	}
+	receipt, err := c.Receipt(ctx, res.TxID)
+	if err != nil {
+		t.Fatalf("Receipt: %v", err)
+	}
+	if receipt.ContractAddress == nil {
+		t.Fatalf("receipt of %s has no contract address", res.TxID)
+	}
+
+	txid, _ := hex.DecodeString(res.TxID)
+	want := smartcontract.ComputeContractAddress(txid, s.Address())
+	t.Logf("deploy txid=%s owner=%s contract=%s", res.TxID, s.Address(), receipt.ContractAddress)
+	if !want.Equal(receipt.ContractAddress) {
+		t.Fatalf("ComputeContractAddress = %s, node reported %s", want, receipt.ContractAddress)
+	}
+}
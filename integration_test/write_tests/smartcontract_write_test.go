@@ -50,7 +50,7 @@ func TestSmartContract_TriggerAndSimulate_Nile(t *testing.T) {
 	simRes, err := sc.Simulate(ctx, ownerAddr, 0, "setValue", newVal)
 	assert.NoError(t, err)
 	if err == nil && simRes != nil {
-		t.Logf("simulate energy=%d success=%v", simRes.Energy, simRes.APIResult.GetResult())
+		t.Logf("simulate energy=%d success=%v", simRes.EnergyUsage, simRes.Success)
 	}
 
 	// 2) Trigger actual transaction setValue(newVal)
@@ -65,7 +65,7 @@ func TestSmartContract_TriggerAndSimulate_Nile(t *testing.T) {
 		return
 	}
 	t.Logf("setValue txid=%s success=%v msg=%s", res.TxID, res.Success, res.Message)
-	assert.Equal(t, simRes.Energy, res.EnergyUsage, "Energy usage should match simulation estimation")
+	assert.Equal(t, simRes.EnergyUsage, res.EnergyUsage, "Energy usage should match simulation estimation")
 	// 3) Verify via constant: value() or getValue()
 	// MinimalContract exposes both 'value' (public state) and 'getValue()'
 	// Try value() first; if ABI names mismatch, fallback to getValue.
@@ -0,0 +1,98 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// Account id length bounds enforced by the node.
+const (
+	minAccountIDLength = 8
+	maxAccountIDLength = 32
+)
+
+// ValidateAccountID checks id against the node's account id rules: 8 to 32
+// printable ASCII characters, without spaces.
+func ValidateAccountID(id string) error {
+	if len(id) < minAccountIDLength || len(id) > maxAccountIDLength {
+		return fmt.Errorf("%w: account id must be %d to %d characters, got %d", types.ErrInvalidParameter, minAccountIDLength, maxAccountIDLength, len(id))
+	}
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; c < 0x21 || c > 0x7e {
+			return fmt.Errorf("%w: account id contains invalid character %q at position %d", types.ErrInvalidParameter, c, i)
+		}
+	}
+	return nil
+}
+
+// GetAccountById retrieves an account by its account id, as set with
+// SetAccountId.
+//
+// An unknown id yields types.ErrNotFound.
+func (m *AccountManager) GetAccountById(ctx context.Context, id string) (*core.Account, error) {
+	if err := ValidateAccountID(id); err != nil {
+		return nil, err
+	}
+	acct, err := lowlevel.GetAccountById(m.conn, ctx, &core.Account{AccountId: []byte(id)})
+	if err != nil {
+		return nil, err
+	}
+	if len(acct.GetAddress()) == 0 {
+		return nil, fmt.Errorf("%w: account with id %q", types.ErrNotFound, id)
+	}
+	return acct, nil
+}
+
+// SetAccountId creates an unsigned transaction assigning the account id id
+// to owner. An account id can be set only once and must be unique on chain;
+// the node enforces both when the transaction is broadcast or, for a
+// node-built transaction, when it is created.
+//
+// Example:
+//
+//	txExt, err := accountMgr.SetAccountId(ctx, owner, "my-exchange-01")
+//	if err != nil {
+//	    // handle error
+//	}
+//	result, err := cli.SignAndBroadcast(ctx, txExt, client.DefaultBroadcastOptions(), signer)
+func (m *AccountManager) SetAccountId(ctx context.Context, owner *types.Address, id string) (*api.TransactionExtention, error) {
+	if owner == nil {
+		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
+	}
+	if err := ValidateAccountID(id); err != nil {
+		return nil, err
+	}
+
+	req := &core.SetAccountIdContract{
+		OwnerAddress: owner.Bytes(),
+		AccountId:    []byte(id),
+	}
+
+	if m.ref != nil {
+		return types.BuildTransaction(ctx, m.ref, core.Transaction_Contract_SetAccountIdContract, req, 0)
+	}
+
+	// The node has no v2 variant of this RPC; wrap the bare transaction so
+	// callers get the same shape as every other builder.
+	tx, err := lowlevel.SetAccountId(m.conn, ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if tx.GetRawData() == nil || len(tx.GetRawData().GetContract()) == 0 {
+		return nil, fmt.Errorf("%w: node returned an empty transaction for set account id", types.ErrTransactionFailed)
+	}
+	txid, err := types.TransactionID(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TransactionExtention{
+		Transaction: tx,
+		Txid:        txid,
+		Result:      &api.Return{Result: true, Code: api.Return_SUCCESS},
+	}, nil
+}
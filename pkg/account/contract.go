@@ -0,0 +1,67 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// maxCachedContracts bounds the per-manager IsContract cache. Past it an
+// arbitrary entry is forgotten, at worst costing one extra lookup.
+const maxCachedContracts = 10_000
+
+// IsContract reports whether a smart contract is deployed at address, i.e.
+// whether GetContract returns non-empty bytecode. Positive results are
+// memoized on the manager, so reuse it to avoid hitting the network for
+// repeated checks of the same contract. Only positive results are cached: a
+// plain address can later become a contract (for example a CREATE2 target),
+// but deployed code cannot be removed on TRON.
+//
+// Example:
+//
+//	isContract, err := accountMgr.IsContract(ctx, to)
+//	if err == nil && isContract {
+//	    // warn: the contract may not accept plain TRX transfers
+//	}
+func (m *AccountManager) IsContract(ctx context.Context, address *types.Address) (bool, error) {
+	if address == nil {
+		return false, fmt.Errorf("%w: address cannot be nil", types.ErrInvalidAddress)
+	}
+
+	key := address.String()
+	m.contractsMu.Lock()
+	_, known := m.contracts[key]
+	m.contractsMu.Unlock()
+	if known {
+		return true, nil
+	}
+
+	req := &api.BytesMessage{Value: address.Bytes()}
+	sc, err := lowlevel.Call(m.conn, ctx, "get contract", func(cl api.WalletClient, ctx context.Context) (*core.SmartContract, error) {
+		return cl.GetContract(ctx, req)
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(sc.GetBytecode()) == 0 {
+		return false, nil
+	}
+
+	m.contractsMu.Lock()
+	defer m.contractsMu.Unlock()
+	if m.contracts == nil {
+		m.contracts = make(map[string]struct{})
+	}
+	if len(m.contracts) >= maxCachedContracts {
+		for k := range m.contracts {
+			delete(m.contracts, k)
+			break
+		}
+	}
+	m.contracts[key] = struct{}{}
+	return true, nil
+}
@@ -3,6 +3,7 @@ package account
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/kslamph/tronlib/pb/api"
 	"github.com/kslamph/tronlib/pb/core"
@@ -14,6 +15,10 @@ import (
 // It is satisfied by *client.Client and any other connection pool provider.
 type ConnProvider = lowlevel.ConnProvider
 
+// RefBlockProvider supplies the reference block for transactions built
+// locally by a manager from NewManagerWithRefProvider.
+type RefBlockProvider = types.RefBlockProvider
+
 // AccountManager provides high-level account operations.
 //
 // The AccountManager allows you to query account information, retrieve balances,
@@ -21,6 +26,13 @@ type ConnProvider = lowlevel.ConnProvider
 // (typically a *client.Client) to communicate with the TRON network.
 type AccountManager struct {
 	conn lowlevel.ConnProvider
+	ref  RefBlockProvider // builds transactions locally when set
+
+	balanceConcurrency int // GetBalanceBatch fan-out; 0 means defaultBalanceConcurrency
+
+	// contracts caches addresses IsContract found to hold a contract
+	contractsMu sync.Mutex
+	contracts   map[string]struct{}
 }
 
 // NewManager creates a new account manager.
@@ -41,6 +53,27 @@ func NewManager(conn lowlevel.ConnProvider) *AccountManager {
 	return &AccountManager{conn: conn}
 }
 
+// NewManagerWithRefProvider creates an account manager that builds
+// transactions locally against refProvider instead of on a node, so the raw
+// bytes are deterministic and no network access is needed.
+//
+// The manager has no connection: query methods such as GetAccount fail with
+// lowlevel.ErrOffline. Unlike node-built transactions, locally built ones are
+// not validated against account state until broadcast.
+//
+// Example:
+//
+//	ref := types.StaticRefBlock{
+//	    RefBlockBytes: []byte{0x12, 0x34},
+//	    RefBlockHash:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+//	    Expiration:    1700000060000,
+//	}
+//	accountMgr := account.NewManagerWithRefProvider(ref)
+//	txExt, err := accountMgr.TransferTRX(ctx, from, to, 1_000_000)
+func NewManagerWithRefProvider(refProvider RefBlockProvider) *AccountManager {
+	return &AccountManager{conn: lowlevel.Offline, ref: refProvider}
+}
+
 // GetAccount retrieves account information by address.
 //
 // This method fetches detailed account information from the TRON network,
@@ -123,6 +156,78 @@ func (m *AccountManager) GetBalance(ctx context.Context, address *types.Address)
 	return account.GetBalance(), nil
 }
 
+// defaultBalanceConcurrency bounds the number of in-flight GetAccount calls
+// made by GetBalanceBatch. It matches the default maximum pool size of the
+// client.
+const defaultBalanceConcurrency = 5
+
+// WithBalanceConcurrency sets how many GetAccount calls GetBalanceBatch keeps
+// in flight at once, and returns m. Values below 1 restore the default of 5.
+// Match it to the client's pool size (see client.WithPool), and configure it
+// before sharing the manager between goroutines.
+//
+// Example:
+//
+//	cli, err := client.NewClient(endpoint, client.WithPool(5, 20))
+//	accountMgr := account.NewManager(cli).WithBalanceConcurrency(20)
+func (m *AccountManager) WithBalanceConcurrency(n int) *AccountManager {
+	m.balanceConcurrency = n
+	return m
+}
+
+// GetBalanceBatch retrieves the TRX balances (in SUN) for many addresses.
+//
+// Requests fan out across the connection provider with bounded concurrency
+// (5 by default, see WithBalanceConcurrency), so the client's pool (see
+// client.WithPool) should be sized accordingly. Results
+// are returned in input order; errs[i] is non-nil when balances[i] could not be
+// retrieved. If ctx is cancelled mid-batch, addresses not yet queried report
+// the context error.
+//
+// Example:
+//
+//	balances, errs := accountMgr.GetBalanceBatch(ctx, addrs)
+//	for i := range addrs {
+//	    if errs[i] != nil {
+//	        // handle per-address error
+//	        continue
+//	    }
+//	    fmt.Printf("%s: %d SUN\n", addrs[i], balances[i])
+//	}
+func (m *AccountManager) GetBalanceBatch(ctx context.Context, addrs []*types.Address) ([]int64, []error) {
+	balances := make([]int64, len(addrs))
+	errs := make([]error, len(addrs))
+
+	limit := m.balanceConcurrency
+	if limit < 1 {
+		limit = defaultBalanceConcurrency
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, addr *types.Address) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			balances[i], errs[i] = m.GetBalance(ctx, addr)
+		}(i, addr)
+	}
+	wg.Wait()
+
+	return balances, errs
+}
+
 // TransferTRX creates an unsigned TRX transfer transaction.
 //
 // This method creates a TRX transfer transaction from one address to another.
@@ -154,12 +259,36 @@ func (m *AccountManager) TransferTRX(ctx context.Context, from *types.Address, t
 		Amount:       amount,
 	}
 
+	if m.ref != nil {
+		return types.BuildTransaction(ctx, m.ref, core.Transaction_Contract_TransferContract, req, 0)
+	}
+
 	// Call lowlevel
 	return lowlevel.TxCall(m.conn, ctx, "create transaction2", func(cl api.WalletClient, ctx context.Context) (*api.TransactionExtention, error) {
 		return cl.CreateTransaction2(ctx, req)
 	})
 }
 
+// TransferTRXWithMemo creates an unsigned TRX transfer transaction carrying
+// memo in the transaction's data field, as exchanges use to identify deposits.
+//
+// The memo is signed together with the transfer and increases its bandwidth
+// cost; see types.SetMemo for size and fee details.
+//
+// Example:
+//
+//	txExt, err := accountMgr.TransferTRXWithMemo(ctx, from, to, 1_000_000, []byte("deposit-42"))
+func (m *AccountManager) TransferTRXWithMemo(ctx context.Context, from *types.Address, to *types.Address, amount int64, memo []byte) (*api.TransactionExtention, error) {
+	txExt, err := m.TransferTRX(ctx, from, to, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := types.SetMemo(txExt, memo); err != nil {
+		return nil, err
+	}
+	return txExt, nil
+}
+
 // validateTransferInputs validates common transfer parameters
 func (m *AccountManager) validateTransferInputs(from *types.Address, to *types.Address, amount int64) error {
 	// Validate addresses
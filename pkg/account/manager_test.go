@@ -2,13 +2,21 @@ package account_test
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
 	"github.com/kslamph/tronlib/pkg/account"
 	"github.com/kslamph/tronlib/pkg/client"
+	"github.com/kslamph/tronlib/pkg/client/clienttest"
+	"github.com/kslamph/tronlib/pkg/signer"
 	"github.com/kslamph/tronlib/pkg/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 const bufSize = 1024 * 1024
@@ -215,3 +223,483 @@ func TestAmountConversions(t *testing.T) {
 		})
 	}
 }
+
+// balanceWalletServer serves GetAccount with a balance derived from the address.
+type balanceWalletServer struct {
+	api.UnimplementedWalletServer
+	balances map[string]int64
+}
+
+func (s *balanceWalletServer) GetAccount(_ context.Context, in *core.Account) (*core.Account, error) {
+	addr := types.MustNewAddressFromBytes(in.GetAddress())
+	return &core.Account{Address: in.GetAddress(), Balance: s.balances[addr.String()]}, nil
+}
+
+func newBufconnClient(t *testing.T, impl api.WalletServer) *client.Client {
+	t.Helper()
+	return clienttest.NewClient(t, impl, client.WithTimeout(2*time.Second), client.WithPool(1, 5))
+}
+
+func TestGetBalanceBatch(t *testing.T) {
+	addrs := []*types.Address{
+		types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY"),
+		nil,
+		types.MustNewAddressFromBase58("TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH"),
+	}
+	srv := &balanceWalletServer{balances: map[string]int64{
+		"TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY": 1_000_000,
+		"TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH": 42,
+	}}
+	manager := account.NewManager(newBufconnClient(t, srv))
+
+	balances, errs := manager.GetBalanceBatch(context.Background(), addrs)
+	if len(balances) != len(addrs) || len(errs) != len(addrs) {
+		t.Fatalf("expected %d results, got %d balances and %d errors", len(addrs), len(balances), len(errs))
+	}
+	if errs[0] != nil || balances[0] != 1_000_000 {
+		t.Errorf("index 0: got balance %d err %v", balances[0], errs[0])
+	}
+	if errs[1] == nil {
+		t.Error("index 1: expected error for nil address")
+	}
+	if errs[2] != nil || balances[2] != 42 {
+		t.Errorf("index 2: got balance %d err %v", balances[2], errs[2])
+	}
+}
+
+func TestGetBalanceBatch_CancelledContext(t *testing.T) {
+	manager := account.NewManager(newBufconnClient(t, &balanceWalletServer{}))
+	addrs := []*types.Address{
+		types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY"),
+		types.MustNewAddressFromBase58("TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH"),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, errs := manager.GetBalanceBatch(ctx, addrs)
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("index %d: expected context error", i)
+		}
+	}
+}
+
+// slowBalanceServer records the peak number of concurrent GetAccount calls.
+type slowBalanceServer struct {
+	api.UnimplementedWalletServer
+	inFlight, peak atomic.Int32
+}
+
+func (s *slowBalanceServer) GetAccount(_ context.Context, in *core.Account) (*core.Account, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		p := s.peak.Load()
+		if n <= p || s.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return &core.Account{Address: in.GetAddress()}, nil
+}
+
+func TestGetBalanceBatch_Concurrency(t *testing.T) {
+	addrs := make([]*types.Address, 8)
+	for i := range addrs {
+		addrs[i] = types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY")
+	}
+	for _, tc := range []struct {
+		limit, want int32
+	}{
+		{limit: 2, want: 2},
+		{limit: 0, want: 5},
+	} {
+		srv := &slowBalanceServer{}
+		manager := account.NewManager(newBufconnClient(t, srv)).WithBalanceConcurrency(int(tc.limit))
+		_, errs := manager.GetBalanceBatch(context.Background(), addrs)
+		for i, err := range errs {
+			if err != nil {
+				t.Fatalf("limit %d: index %d: %v", tc.limit, i, err)
+			}
+		}
+		if got := srv.peak.Load(); got > tc.want {
+			t.Errorf("limit %d: peak concurrency = %d, want at most %d", tc.limit, got, tc.want)
+		}
+	}
+}
+
+// transferWalletServer echoes TransferContract requests as unsigned transactions.
+type transferWalletServer struct {
+	api.UnimplementedWalletServer
+}
+
+func (s *transferWalletServer) CreateTransaction2(_ context.Context, in *core.TransferContract) (*api.TransactionExtention, error) {
+	return &api.TransactionExtention{
+		Result:      &api.Return{Result: true},
+		Transaction: &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1}},
+		Txid:        []byte{0x01},
+	}, nil
+}
+
+func TestTransferTRXWithMemo(t *testing.T) {
+	manager := account.NewManager(newBufconnClient(t, &transferWalletServer{}))
+	from := types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY")
+	to := types.MustNewAddressFromBase58("TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH")
+
+	txExt, err := manager.TransferTRXWithMemo(context.Background(), from, to, 1_000_000, []byte("deposit-42"))
+	if err != nil {
+		t.Fatalf("TransferTRXWithMemo error: %v", err)
+	}
+	if got := string(types.GetMemo(txExt)); got != "deposit-42" {
+		t.Errorf("expected memo %q, got %q", "deposit-42", got)
+	}
+	if len(txExt.GetTxid()) != 32 {
+		t.Errorf("expected txid to be recomputed, got %x", txExt.GetTxid())
+	}
+
+	if _, err := manager.TransferTRXWithMemo(context.Background(), from, from, 1, nil); err == nil {
+		t.Error("expected validation error for same from/to")
+	}
+}
+
+// permissionWalletServer serves a fixed account for GetAccount.
+type permissionWalletServer struct {
+	api.UnimplementedWalletServer
+	account *core.Account
+}
+
+func (s *permissionWalletServer) GetAccount(_ context.Context, _ *core.Account) (*core.Account, error) {
+	return s.account, nil
+}
+
+func TestCheckMultiSigThreshold(t *testing.T) {
+	keyA, _ := signer.NewPrivateKeySigner("0x1111111111111111111111111111111111111111111111111111111111111111")
+	keyB, _ := signer.NewPrivateKeySigner("0x2222222222222222222222222222222222222222222222222222222222222222")
+	outsider, _ := signer.NewPrivateKeySigner("0x3333333333333333333333333333333333333333333333333333333333333333")
+	owner := types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY")
+
+	srv := &permissionWalletServer{account: &core.Account{
+		Address: owner.Bytes(),
+		ActivePermission: []*core.Permission{{
+			Type:      core.Permission_Active,
+			Id:        2,
+			Threshold: 3,
+			Keys: []*core.Key{
+				{Address: keyA.Address().Bytes(), Weight: 2},
+				{Address: keyB.Address().Bytes(), Weight: 1},
+			},
+		}},
+	}}
+	manager := account.NewManager(newBufconnClient(t, srv))
+	ctx := context.Background()
+
+	newTx := func(permissionID int32) *core.Transaction {
+		param, err := anypb.New(&core.TransferContract{OwnerAddress: owner.Bytes(), ToAddress: keyA.Address().Bytes(), Amount: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &core.Transaction{RawData: &core.TransactionRaw{
+			Contract: []*core.Transaction_Contract{{Type: core.Transaction_Contract_TransferContract, Parameter: param, PermissionId: permissionID}},
+		}}
+	}
+
+	tx := newTx(2)
+	_ = signer.SignTx(keyA, tx)
+	_ = signer.SignTx(outsider, tx)
+	weight, threshold, met, err := manager.CheckMultiSigThreshold(ctx, tx, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if weight != 2 || threshold != 3 || met {
+		t.Errorf("expected 2/3 unmet, got %d/%d met=%v", weight, threshold, met)
+	}
+
+	_ = signer.SignTx(keyB, tx)
+	weight, _, met, err = manager.CheckMultiSigThreshold(ctx, tx, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if weight != 3 || !met {
+		t.Errorf("expected 3/3 met, got %d met=%v", weight, met)
+	}
+
+	// Checking against a permission other than the one tx is signed under.
+	if _, _, met, err := manager.CheckMultiSigThreshold(ctx, tx, types.OwnerPermissionID); !errors.Is(err, types.ErrInvalidParameter) || met {
+		t.Errorf("expected ErrInvalidParameter for permission mismatch, got met=%v err=%v", met, err)
+	}
+
+	if _, _, _, err := manager.CheckMultiSigThreshold(ctx, newTx(5), 5); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown permission, got %v", err)
+	}
+
+	// Signatures that cannot be recovered surface the recovery error.
+	bad := newTx(2)
+	bad.Signature = [][]byte{make([]byte, 64)}
+	if _, _, _, err := manager.CheckMultiSigThreshold(ctx, bad, 2); !errors.Is(err, types.ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction for malformed signature, got %v", err)
+	}
+}
+
+type signWeightWalletServer struct {
+	api.UnimplementedWalletServer
+	resp *api.TransactionSignWeight
+}
+
+func (s *signWeightWalletServer) GetTransactionSignWeight(_ context.Context, _ *core.Transaction) (*api.TransactionSignWeight, error) {
+	return s.resp, nil
+}
+
+func TestGetSignWeight(t *testing.T) {
+	keyA := types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY")
+	perm := &core.Permission{
+		Type:      core.Permission_Active,
+		Id:        2,
+		Threshold: 3,
+		Keys:      []*core.Key{{Address: keyA.Bytes(), Weight: 2}},
+	}
+	srv := &signWeightWalletServer{resp: &api.TransactionSignWeight{
+		Permission:    perm,
+		ApprovedList:  [][]byte{keyA.Bytes()},
+		CurrentWeight: 2,
+		Result:        &api.TransactionSignWeight_Result{Code: api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION},
+	}}
+	manager := account.NewManager(newBufconnClient(t, srv))
+	ctx := context.Background()
+	tx := &core.Transaction{RawData: &core.TransactionRaw{}}
+
+	sw, err := manager.GetSignWeight(ctx, tx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sw.Enough || sw.CurrentWeight != 2 || sw.Threshold != 3 || sw.Permission.ID != 2 {
+		t.Errorf("unexpected result %+v", sw)
+	}
+	if len(sw.Approved) != 1 || !sw.Approved[0].Equal(keyA) {
+		t.Errorf("unexpected approved list %v", sw.Approved)
+	}
+
+	srv.resp.CurrentWeight = 3
+	srv.resp.Result.Code = api.TransactionSignWeight_Result_ENOUGH_PERMISSION
+	if sw, err = manager.GetSignWeight(ctx, tx); err != nil || !sw.Enough {
+		t.Errorf("expected enough weight, got %+v, %v", sw, err)
+	}
+
+	srv.resp.Result = &api.TransactionSignWeight_Result{Code: api.TransactionSignWeight_Result_PERMISSION_ERROR, Message: "key not in permission"}
+	sw, err = manager.GetSignWeight(ctx, tx)
+	if !errors.Is(err, types.ErrInvalidTransaction) || sw == nil || sw.Message != "key not in permission" {
+		t.Errorf("expected ErrInvalidTransaction with result, got %+v, %v", sw, err)
+	}
+
+	if _, err := manager.GetSignWeight(ctx, nil); !errors.Is(err, types.ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction for nil transaction, got %v", err)
+	}
+}
+
+type contractWalletServer struct {
+	api.UnimplementedWalletServer
+	contracts map[string]bool
+	calls     int32
+}
+
+func (s *contractWalletServer) GetContract(_ context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
+	atomic.AddInt32(&s.calls, 1)
+	addr := types.MustNewAddressFromBytes(in.GetValue())
+	if s.contracts[addr.String()] {
+		return &core.SmartContract{ContractAddress: in.GetValue(), Bytecode: []byte{0x60, 0x80}}, nil
+	}
+	return &core.SmartContract{}, nil
+}
+
+func TestIsContract(t *testing.T) {
+	contract := types.MustNewAddressFromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	plain := types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY")
+	srv := &contractWalletServer{contracts: map[string]bool{contract.String(): true}}
+	manager := account.NewManager(newBufconnClient(t, srv))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		ok, err := manager.IsContract(ctx, contract)
+		if err != nil || !ok {
+			t.Fatalf("IsContract(contract) = %v, %v", ok, err)
+		}
+	}
+	if calls := atomic.LoadInt32(&srv.calls); calls != 1 {
+		t.Errorf("expected contract result to be cached, got %d calls", calls)
+	}
+
+	for i := 0; i < 2; i++ {
+		ok, err := manager.IsContract(ctx, plain)
+		if err != nil || ok {
+			t.Fatalf("IsContract(plain) = %v, %v", ok, err)
+		}
+	}
+	if calls := atomic.LoadInt32(&srv.calls); calls != 3 {
+		t.Errorf("expected negative results not to be cached, got %d calls", calls)
+	}
+
+	if _, err := manager.IsContract(ctx, nil); !errors.Is(err, types.ErrInvalidAddress) {
+		t.Errorf("expected ErrInvalidAddress, got %v", err)
+	}
+}
+
+func TestNewManagerWithRefProvider(t *testing.T) {
+	ref := types.StaticRefBlock{
+		RefBlockBytes: []byte{0x12, 0x34},
+		RefBlockHash:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Expiration:    1700000060000,
+		Timestamp:     1700000000000,
+	}
+	manager := account.NewManagerWithRefProvider(ref)
+	from, _ := types.NewAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	to, _ := types.NewAddress("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+
+	first, err := manager.TransferTRX(context.Background(), from, to, 1_000_000)
+	if err != nil {
+		t.Fatalf("TransferTRX: %v", err)
+	}
+	second, err := manager.TransferTRX(context.Background(), from, to, 1_000_000)
+	if err != nil {
+		t.Fatalf("TransferTRX: %v", err)
+	}
+	if string(first.GetTxid()) != string(second.GetTxid()) {
+		t.Fatalf("expected deterministic txid")
+	}
+	raw := first.GetTransaction().GetRawData()
+	if string(raw.GetRefBlockBytes()) != "\x12\x34" || raw.GetExpiration() != ref.Expiration {
+		t.Fatalf("reference block not applied: %v", raw)
+	}
+	var transfer core.TransferContract
+	if err := raw.GetContract()[0].GetParameter().UnmarshalTo(&transfer); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if transfer.GetAmount() != 1_000_000 {
+		t.Fatalf("unexpected amount %d", transfer.GetAmount())
+	}
+
+	if _, err := manager.GetAccount(context.Background(), from); err == nil {
+		t.Fatalf("expected offline manager to fail queries")
+	}
+}
+
+// accountIDWalletServer serves one account by id and builds SetAccountId
+// transactions.
+type accountIDWalletServer struct {
+	api.UnimplementedWalletServer
+	id      string
+	account *core.Account
+}
+
+func (s *accountIDWalletServer) GetAccountById(_ context.Context, in *core.Account) (*core.Account, error) {
+	if string(in.GetAccountId()) != s.id {
+		return &core.Account{}, nil
+	}
+	return s.account, nil
+}
+
+func (s *accountIDWalletServer) SetAccountId(_ context.Context, in *core.SetAccountIdContract) (*core.Transaction, error) {
+	param, err := anypb.New(in)
+	if err != nil {
+		return nil, err
+	}
+	return &core.Transaction{RawData: &core.TransactionRaw{
+		Timestamp: 1,
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_SetAccountIdContract,
+			Parameter: param,
+		}},
+	}}, nil
+}
+
+func TestAccountID(t *testing.T) {
+	owner := types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY")
+	manager := account.NewManager(newBufconnClient(t, &accountIDWalletServer{
+		id:      "exchange-01",
+		account: &core.Account{Address: owner.Bytes(), AccountId: []byte("exchange-01")},
+	}))
+	ctx := context.Background()
+
+	acct, err := manager.GetAccountById(ctx, "exchange-01")
+	if err != nil {
+		t.Fatalf("GetAccountById error: %v", err)
+	}
+	if types.MustNewAddressFromBytes(acct.GetAddress()).String() != owner.String() {
+		t.Errorf("unexpected account %x", acct.GetAddress())
+	}
+	if _, err := manager.GetAccountById(ctx, "unknown-id"); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	txExt, err := manager.SetAccountId(ctx, owner, "exchange-01")
+	if err != nil {
+		t.Fatalf("SetAccountId error: %v", err)
+	}
+	if len(txExt.GetTxid()) != 32 || !txExt.GetResult().GetResult() {
+		t.Errorf("expected wrapped transaction with txid, got %v", txExt)
+	}
+	var contract core.SetAccountIdContract
+	if err := txExt.GetTransaction().GetRawData().GetContract()[0].GetParameter().UnmarshalTo(&contract); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if string(contract.GetAccountId()) != "exchange-01" {
+		t.Errorf("unexpected account id %q", contract.GetAccountId())
+	}
+
+	for _, bad := range []string{"short", "this-account-id-is-way-too-long-1", "has space", "non-ascii-é"} {
+		if _, err := manager.SetAccountId(ctx, owner, bad); !errors.Is(err, types.ErrInvalidParameter) {
+			t.Errorf("SetAccountId(%q): expected ErrInvalidParameter, got %v", bad, err)
+		}
+	}
+	if _, err := manager.SetAccountId(ctx, nil, "exchange-01"); !errors.Is(err, types.ErrInvalidAddress) {
+		t.Errorf("expected ErrInvalidAddress, got %v", err)
+	}
+}
+
+func TestGetStakeInfo(t *testing.T) {
+	addr := types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY")
+	expired := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	pending := time.Now().Add(14 * 24 * time.Hour).Truncate(time.Millisecond)
+	manager := account.NewManager(newBufconnClient(t, &permissionWalletServer{account: &core.Account{
+		Address: addr.Bytes(),
+		FrozenV2: []*core.Account_FreezeV2{
+			{Type: core.ResourceCode_BANDWIDTH, Amount: 100},
+			{Type: core.ResourceCode_ENERGY, Amount: 200},
+			{Type: core.ResourceCode_TRON_POWER, Amount: 300},
+		},
+		UnfrozenV2: []*core.Account_UnFreezeV2{
+			{Type: core.ResourceCode_ENERGY, UnfreezeAmount: 40, UnfreezeExpireTime: expired.UnixMilli()},
+			{Type: core.ResourceCode_BANDWIDTH, UnfreezeAmount: 50, UnfreezeExpireTime: pending.UnixMilli()},
+		},
+		DelegatedFrozenV2BalanceForBandwidth:         10,
+		AcquiredDelegatedFrozenV2BalanceForBandwidth: 1,
+		Frozen: []*core.Account_Frozen{{FrozenBalance: 5}},
+		AccountResource: &core.Account_AccountResource{
+			DelegatedFrozenV2BalanceForEnergy:         20,
+			AcquiredDelegatedFrozenV2BalanceForEnergy: 2,
+			FrozenBalanceForEnergy:                    &core.Account_Frozen{FrozenBalance: 7},
+		},
+	}}))
+
+	info, err := manager.GetStakeInfo(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("GetStakeInfo error: %v", err)
+	}
+	if info.StakedForBandwidth != 100 || info.StakedForEnergy != 200 || info.StakedForTronPower != 300 {
+		t.Errorf("unexpected staked amounts: %+v", info)
+	}
+	if info.DelegatedOutBandwidth != 10 || info.DelegatedOutEnergy != 20 || info.DelegatedInBandwidth != 1 || info.DelegatedInEnergy != 2 {
+		t.Errorf("unexpected delegated amounts: %+v", info)
+	}
+	if info.LegacyStakedForBandwidth != 5 || info.LegacyStakedForEnergy != 7 {
+		t.Errorf("unexpected legacy amounts: %+v", info)
+	}
+	if want := int64(100 + 200 + 300 + 10 + 20 + 5 + 7); info.TotalStaked != want {
+		t.Errorf("TotalStaked = %d, want %d", info.TotalStaked, want)
+	}
+	if len(info.Unfreezing) != 2 || !info.Unfreezing[1].ExpireTime.Equal(pending) {
+		t.Fatalf("unexpected unfreezing: %+v", info.Unfreezing)
+	}
+	if info.TotalUnfreezing() != 90 || info.Withdrawable(time.Now()) != 40 {
+		t.Errorf("TotalUnfreezing = %d, Withdrawable = %d", info.TotalUnfreezing(), info.Withdrawable(time.Now()))
+	}
+}
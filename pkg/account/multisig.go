@@ -0,0 +1,189 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// CheckMultiSigThreshold reports whether the signatures collected on tx carry
+// enough weight to satisfy the given permission of the transaction owner.
+// permissionID must match the permission ID tx is signed under; otherwise an
+// error wrapping types.ErrInvalidParameter is returned, since the node would
+// check the signatures against a different permission.
+//
+// The owner account is fetched to resolve the permission, each signature is
+// recovered to its signer address, and the weights of distinct signers listed
+// in the permission are summed. Signers not in the permission contribute
+// nothing. Use this before broadcasting to avoid paying bandwidth for an
+// under-signed transaction. Signatures are recovered with
+// types.RecoverSigners, and its error is returned if any cannot be recovered.
+//
+// Accounts that never updated their permissions have an implicit owner
+// permission (ID 0) with threshold 1 and the account itself as sole key.
+//
+// Example:
+//
+//	weight, threshold, met, err := accountMgr.CheckMultiSigThreshold(ctx, tx, 2)
+//	if err != nil {
+//	    // handle error
+//	}
+//	if !met {
+//	    fmt.Printf("need %d more weight\n", threshold-weight)
+//	}
+func (m *AccountManager) CheckMultiSigThreshold(ctx context.Context, tx *core.Transaction, permissionID int32) (currentWeight, threshold int64, met bool, err error) {
+	summary, err := types.DecodeTransaction(tx)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if summary.From == nil {
+		return 0, 0, false, fmt.Errorf("%w: transaction has no owner address", types.ErrInvalidTransaction)
+	}
+	if summary.PermissionID != permissionID {
+		return 0, 0, false, fmt.Errorf("%w: transaction is signed under permission %d, not %d", types.ErrInvalidParameter, summary.PermissionID, permissionID)
+	}
+
+	acct, err := m.GetAccount(ctx, summary.From)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	perm, err := findPermission(acct, summary.From, permissionID)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	signers, err := types.RecoverSigners(tx)
+	if err != nil {
+		return 0, perm.GetThreshold(), false, err
+	}
+
+	weights := make(map[string]int64, len(perm.GetKeys()))
+	for _, key := range perm.GetKeys() {
+		addr, err := types.NewAddressFromBytes(key.GetAddress())
+		if err != nil {
+			continue
+		}
+		weights[addr.String()] = key.GetWeight()
+	}
+
+	counted := make(map[string]bool, len(signers))
+	for _, signer := range signers {
+		addr := signer.String()
+		if counted[addr] {
+			continue
+		}
+		counted[addr] = true
+		currentWeight += weights[addr]
+	}
+
+	threshold = perm.GetThreshold()
+	return currentWeight, threshold, currentWeight >= threshold, nil
+}
+
+// SignWeightResult is the node's assessment of the signatures collected on a
+// transaction, as returned by GetSignWeight.
+type SignWeightResult struct {
+	// CurrentWeight is the summed weight of the distinct approving keys.
+	CurrentWeight int64
+	// Threshold is the weight the permission requires.
+	Threshold int64
+	// Permission is the permission the transaction is checked against, as
+	// selected by its contract's permission ID; nil if the node could not
+	// resolve it.
+	Permission *types.PermissionSummary
+	// Approved lists the addresses whose signatures the node accepted.
+	Approved []*types.Address
+	// Enough reports whether the transaction is ready to broadcast.
+	Enough bool
+	// Code and Message are the node's verdict; Code is NOT_ENOUGH_PERMISSION
+	// while signatures are still missing.
+	Code    api.TransactionSignWeight_ResultResponseCode
+	Message string
+}
+
+// GetSignWeight asks the node how much signature weight tx carries against
+// the permission it names, using the GetTransactionSignWeight RPC. Unlike
+// CheckMultiSigThreshold, which recomputes weights locally, this is the
+// node's authoritative view and uses the same rules as broadcast validation.
+//
+// A transaction that is merely under-signed is not an error: Enough is false
+// and Code is NOT_ENOUGH_PERMISSION. Malformed signatures, keys outside the
+// permission and other node-side failures return the result together with an
+// error wrapping types.ErrInvalidTransaction.
+//
+// Example:
+//
+//	sw, err := accountMgr.GetSignWeight(ctx, tx)
+//	if err != nil {
+//	    // handle error
+//	}
+//	if !sw.Enough {
+//	    fmt.Printf("%d of %d weight, approved by %v\n", sw.CurrentWeight, sw.Threshold, sw.Approved)
+//	}
+func (m *AccountManager) GetSignWeight(ctx context.Context, tx *core.Transaction) (*SignWeightResult, error) {
+	if tx == nil || tx.GetRawData() == nil {
+		return nil, fmt.Errorf("%w: transaction raw data cannot be nil", types.ErrInvalidTransaction)
+	}
+	resp, err := lowlevel.GetTransactionSignWeight(m.conn, ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SignWeightResult{
+		CurrentWeight: resp.GetCurrentWeight(),
+		Code:          resp.GetResult().GetCode(),
+		Message:       string(resp.GetResult().GetMessage()),
+	}
+	if p := resp.GetPermission(); p != nil {
+		summary := types.NewPermissionSummary(p)
+		res.Permission = &summary
+		res.Threshold = p.GetThreshold()
+	}
+	for _, a := range resp.GetApprovedList() {
+		addr, err := types.NewAddressFromBytes(a)
+		if err != nil {
+			continue
+		}
+		res.Approved = append(res.Approved, addr)
+	}
+	res.Enough = res.Code == api.TransactionSignWeight_Result_ENOUGH_PERMISSION
+
+	switch res.Code {
+	case api.TransactionSignWeight_Result_ENOUGH_PERMISSION, api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION:
+		return res, nil
+	default:
+		return res, fmt.Errorf("%w: sign weight check failed: %s: %s", types.ErrInvalidTransaction, res.Code, res.Message)
+	}
+}
+
+// findPermission returns the permission with the given ID, falling back to
+// the implicit owner permission for accounts without explicit permissions.
+func findPermission(acct *core.Account, owner *types.Address, permissionID int32) (*core.Permission, error) {
+	switch permissionID {
+	case types.OwnerPermissionID:
+		if p := acct.GetOwnerPermission(); p != nil {
+			return p, nil
+		}
+		return &core.Permission{
+			Type:      core.Permission_Owner,
+			Id:        types.OwnerPermissionID,
+			Threshold: 1,
+			Keys:      []*core.Key{{Address: owner.Bytes(), Weight: 1}},
+		}, nil
+	case types.WitnessPermissionID:
+		if p := acct.GetWitnessPermission(); p != nil {
+			return p, nil
+		}
+	default:
+		for _, p := range acct.GetActivePermission() {
+			if p.GetId() == permissionID {
+				return p, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: permission %d not found on account %s", types.ErrNotFound, permissionID, owner)
+}
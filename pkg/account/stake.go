@@ -0,0 +1,124 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// StakeInfo is a typed breakdown of an account's staked TRX. All amounts
+// are in SUN.
+//
+// Under Stake 2.0, TRX delegated to another account is moved out of the
+// owner's FrozenV2 balance, so the Staked* fields exclude it and
+// DelegatedOut* report it separately. TotalStaked adds both back, together
+// with any remaining Stake 1.0 (legacy) balances.
+type StakeInfo struct {
+	// Stake 2.0 balances held by the account itself, by resource type.
+	StakedForBandwidth int64
+	StakedForEnergy    int64
+	StakedForTronPower int64
+
+	// DelegatedOut* is TRX this account staked and delegated to others.
+	DelegatedOutBandwidth int64
+	DelegatedOutEnergy    int64
+
+	// DelegatedIn* is TRX staked by other accounts on this account's behalf.
+	// It provides resources but is not owned, so TotalStaked excludes it.
+	DelegatedInBandwidth int64
+	DelegatedInEnergy    int64
+
+	// Legacy* are Stake 1.0 balances, including those delegated out.
+	LegacyStakedForBandwidth int64
+	LegacyStakedForEnergy    int64
+
+	// Unfreezing lists pending Stake 2.0 unstakes in node order.
+	Unfreezing []UnfreezingStake
+
+	TotalStaked int64
+}
+
+// UnfreezingStake is a pending Stake 2.0 unstake, withdrawable with
+// WithdrawExpireUnfreeze once ExpireTime has passed.
+type UnfreezingStake struct {
+	Resource   core.ResourceCode
+	Amount     int64
+	ExpireTime time.Time
+}
+
+// TotalUnfreezing returns the sum of all pending unstakes.
+func (s *StakeInfo) TotalUnfreezing() int64 {
+	var total int64
+	for _, u := range s.Unfreezing {
+		total += u.Amount
+	}
+	return total
+}
+
+// Withdrawable returns the sum of pending unstakes that have expired at now.
+func (s *StakeInfo) Withdrawable(now time.Time) int64 {
+	var total int64
+	for _, u := range s.Unfreezing {
+		if !u.ExpireTime.After(now) {
+			total += u.Amount
+		}
+	}
+	return total
+}
+
+// NewStakeInfo aggregates the staking fields of acct into a StakeInfo.
+func NewStakeInfo(acct *core.Account) *StakeInfo {
+	s := &StakeInfo{
+		DelegatedOutBandwidth:    acct.GetDelegatedFrozenV2BalanceForBandwidth(),
+		DelegatedOutEnergy:       acct.GetAccountResource().GetDelegatedFrozenV2BalanceForEnergy(),
+		DelegatedInBandwidth:     acct.GetAcquiredDelegatedFrozenV2BalanceForBandwidth(),
+		DelegatedInEnergy:        acct.GetAccountResource().GetAcquiredDelegatedFrozenV2BalanceForEnergy(),
+		LegacyStakedForBandwidth: acct.GetDelegatedFrozenBalanceForBandwidth(),
+		LegacyStakedForEnergy: acct.GetAccountResource().GetFrozenBalanceForEnergy().GetFrozenBalance() +
+			acct.GetAccountResource().GetDelegatedFrozenBalanceForEnergy(),
+	}
+	for _, f := range acct.GetFrozenV2() {
+		switch f.GetType() {
+		case core.ResourceCode_BANDWIDTH:
+			s.StakedForBandwidth += f.GetAmount()
+		case core.ResourceCode_ENERGY:
+			s.StakedForEnergy += f.GetAmount()
+		case core.ResourceCode_TRON_POWER:
+			s.StakedForTronPower += f.GetAmount()
+		}
+	}
+	for _, f := range acct.GetFrozen() {
+		s.LegacyStakedForBandwidth += f.GetFrozenBalance()
+	}
+	for _, u := range acct.GetUnfrozenV2() {
+		s.Unfreezing = append(s.Unfreezing, UnfreezingStake{
+			Resource:   u.GetType(),
+			Amount:     u.GetUnfreezeAmount(),
+			ExpireTime: time.UnixMilli(u.GetUnfreezeExpireTime()),
+		})
+	}
+	s.TotalStaked = s.StakedForBandwidth + s.StakedForEnergy + s.StakedForTronPower +
+		s.DelegatedOutBandwidth + s.DelegatedOutEnergy +
+		s.LegacyStakedForBandwidth + s.LegacyStakedForEnergy
+	return s
+}
+
+// GetStakeInfo fetches the account and returns its staking breakdown.
+//
+// Example:
+//
+//	info, err := accountMgr.GetStakeInfo(ctx, addr)
+//	if err != nil {
+//	    // handle error
+//	}
+//	fmt.Printf("staked for energy: %d SUN, withdrawable: %d SUN\n",
+//	    info.StakedForEnergy, info.Withdrawable(time.Now()))
+func (m *AccountManager) GetStakeInfo(ctx context.Context, address *types.Address) (*StakeInfo, error) {
+	acct, err := m.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return NewStakeInfo(acct), nil
+}
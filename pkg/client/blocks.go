@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// maxLatestBlocks is the largest count GetBlockByLatestNum2 accepts.
+const maxLatestBlocks = 100
+
+// GetLatestBlocks returns the n most recent blocks, oldest first, with their
+// transactions. n must be between 1 and 100.
+//
+// Example:
+//
+//	blocks, err := cli.GetLatestBlocks(ctx, 10)
+//	for _, b := range blocks {
+//	    fmt.Println(b.GetBlockHeader().GetRawData().GetNumber(), len(b.GetTransactions()))
+//	}
+func (c *Client) GetLatestBlocks(ctx context.Context, n int64) ([]*api.BlockExtention, error) {
+	if n <= 0 || n > maxLatestBlocks {
+		return nil, fmt.Errorf("%w: block count must be between 1 and %d, got %d", types.ErrInvalidParameter, maxLatestBlocks, n)
+	}
+	list, err := lowlevel.Call(c, ctx, "get block by latest num2", func(cl api.WalletClient, ctx context.Context) (*api.BlockListExtention, error) {
+		return cl.GetBlockByLatestNum2(ctx, &api.NumberMessage{Num: n})
+	})
+	if err != nil {
+		return nil, err
+	}
+	blocks := list.GetBlock()
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].GetBlockHeader().GetRawData().GetNumber() < blocks[j].GetBlockHeader().GetRawData().GetNumber()
+	})
+	return blocks, nil
+}
+
+// GetBlockByHash returns the block with the given 32-byte hash (block ID) in
+// hex, with optional 0x prefix, including its transactions. It returns
+// types.ErrNotFound if the node does not know the block.
+func (c *Client) GetBlockByHash(ctx context.Context, hash string) (*api.BlockExtention, error) {
+	hash = strings.TrimPrefix(strings.TrimPrefix(hash, "0x"), "0X")
+	if id, err := hex.DecodeString(hash); err != nil || len(id) != 32 {
+		return nil, fmt.Errorf("%w: invalid block hash %q", types.ErrInvalidParameter, hash)
+	}
+	block, err := lowlevel.GetBlock(c, ctx, &api.BlockReq{IdOrNum: strings.ToLower(hash), Detail: true})
+	if err != nil {
+		return nil, err
+	}
+	if block.GetBlockHeader() == nil {
+		return nil, fmt.Errorf("%w: block %s", types.ErrNotFound, hash)
+	}
+	return block, nil
+}
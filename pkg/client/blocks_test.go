@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+func blockAt(num int64) *api.BlockExtention {
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: num}}}
+}
+
+func TestGetLatestBlocks(t *testing.T) {
+	fake := &testWalletServer{
+		GetBlockByLatestNumHandler: func(ctx context.Context, in *api.NumberMessage) (*api.BlockListExtention, error) {
+			list := &api.BlockListExtention{}
+			for i := int64(0); i < in.GetNum(); i++ {
+				list.Block = append(list.Block, blockAt(1000-i))
+			}
+			return list, nil
+		},
+	}
+	lis, _, cleanup := newBufconnServer(t, fake)
+	defer cleanup()
+	c, closeClient := newTestClientWithBufConn(t, lis, time.Second)
+	defer closeClient()
+
+	blocks, err := c.GetLatestBlocks(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
+	}
+	for i, want := range []int64{998, 999, 1000} {
+		if got := blocks[i].GetBlockHeader().GetRawData().GetNumber(); got != want {
+			t.Fatalf("block %d: got %d, want %d", i, got, want)
+		}
+	}
+
+	for _, n := range []int64{0, 101} {
+		if _, err := c.GetLatestBlocks(context.Background(), n); !errors.Is(err, types.ErrInvalidParameter) {
+			t.Fatalf("n=%d: expected ErrInvalidParameter, got %v", n, err)
+		}
+	}
+}
+
+func TestGetBlockByHash(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	fake := &testWalletServer{
+		GetBlockHandler: func(ctx context.Context, in *api.BlockReq) (*api.BlockExtention, error) {
+			if in.GetIdOrNum() != hash || !in.GetDetail() {
+				return &api.BlockExtention{}, nil
+			}
+			return blockAt(42), nil
+		},
+	}
+	lis, _, cleanup := newBufconnServer(t, fake)
+	defer cleanup()
+	c, closeClient := newTestClientWithBufConn(t, lis, time.Second)
+	defer closeClient()
+
+	block, err := c.GetBlockByHash(context.Background(), "0x"+strings.ToUpper(hash))
+	if err != nil || block.GetBlockHeader().GetRawData().GetNumber() != 42 {
+		t.Fatalf("GetBlockByHash = %v, %v", block, err)
+	}
+	if _, err := c.GetBlockByHash(context.Background(), strings.Repeat("cd", 32)); !errors.Is(err, types.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := c.GetBlockByHash(context.Background(), "1234"); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithCircuitBreaker stops sending calls to a node that keeps failing.
+//
+// After failureThreshold consecutive calls fail with a node-side error
+// (Unavailable, DeadlineExceeded, ResourceExhausted or Internal), the breaker
+// opens and every call fails immediately with ErrCircuitOpen for cooldown.
+// The next call after that is let through as a probe while others are still
+// rejected: if it succeeds the breaker closes, otherwise it opens for another
+// cooldown. Application errors such as NotFound or InvalidArgument, and calls
+// cancelled by the caller, neither trip nor reset the breaker.
+//
+// The breaker covers the client's node and is shared with WithOptions views.
+// To fail over, keep one client per node and move to the next one when a
+// call returns ErrCircuitOpen.
+//
+// Example:
+//
+//	cli, err := client.NewClient(endpoint, client.WithCircuitBreaker(5, 30*time.Second))
+//	...
+//	_, err = cli.Account().GetAccount(ctx, addr)
+//	if errors.Is(err, client.ErrCircuitOpen) {
+//	    // use a backup node
+//	}
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) Option {
+	return func(co *clientOptions) {
+		co.breakerThreshold = failureThreshold
+		co.breakerCooldown = cooldown
+	}
+}
+
+// breakerState is the state of a circuitBreaker.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker tracks consecutive node failures for one client.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a call may proceed, moving an open breaker whose
+// cooldown has passed to half-open and admitting the caller as its probe.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false // a probe is already in flight
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of an admitted call.
+func (b *circuitBreaker) record(err error) {
+	failed, counted := classifyBreakerError(err)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !counted {
+		if b.state == breakerHalfOpen {
+			// Inconclusive probe; let the next call probe again.
+			b.state = breakerOpen
+			b.openedAt = b.now().Add(-b.cooldown)
+		}
+		return
+	}
+	if !failed {
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// classifyBreakerError reports whether err is a node failure, and whether the
+// outcome says anything about node health at all.
+func classifyBreakerError(err error) (failed, counted bool) {
+	if err == nil {
+		return false, true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal:
+		return true, true
+	case codes.Canceled:
+		return false, false
+	default:
+		// The node answered, so it is up.
+		return false, true
+	}
+}
+
+// breakerInterceptor short-circuits calls while b is open and feeds it the
+// outcome of every other call.
+func breakerInterceptor(b *circuitBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return ErrCircuitOpen
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker_StateTransitions(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	b.record(unavailable)
+	if !b.allow() {
+		t.Fatalf("breaker opened before reaching the threshold")
+	}
+	b.record(status.Error(codes.NotFound, "missing")) // the node answered
+	b.record(unavailable)
+	if !b.allow() {
+		t.Fatalf("application error should reset the failure count")
+	}
+	b.record(unavailable)
+	if b.allow() {
+		t.Fatalf("expected breaker to open after 2 consecutive failures")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatalf("expected a probe after the cooldown")
+	}
+	if b.allow() {
+		t.Fatalf("only one probe may be in flight")
+	}
+	b.record(unavailable)
+	if b.allow() {
+		t.Fatalf("failed probe should reopen the breaker")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatalf("expected a second probe")
+	}
+	b.record(nil)
+	if !b.allow() || !b.allow() {
+		t.Fatalf("successful probe should close the breaker")
+	}
+}
+
+func TestNewClient_WithCircuitBreaker(t *testing.T) {
+	var calls, healthy int32
+	fake := &testWalletServer{
+		GetNodeInfoHandler: func(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+			atomic.AddInt32(&calls, 1)
+			if atomic.LoadInt32(&healthy) == 0 {
+				return nil, status.Error(codes.Unavailable, "overloaded")
+			}
+			return &core.NodeInfo{}, nil
+		},
+	}
+	lis, _, stop := newBufconnServer(t, fake)
+	defer stop()
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+
+	c, err := NewClientWithDialer("passthrough:///bufnet", dialer, WithTimeout(time.Second), WithCircuitBreaker(3, 50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClientWithDialer error: %v", err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Network().GetNodeInfo(ctx); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: expected node error, got %v", i, err)
+		}
+	}
+	if _, err := c.Network().GetNodeInfo(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("open breaker should not reach the node, got %d calls", got)
+	}
+
+	atomic.StoreInt32(&healthy, 1)
+	time.Sleep(60 * time.Millisecond)
+	if _, err := c.Network().GetNodeInfo(ctx); err != nil {
+		t.Fatalf("probe after cooldown failed: %v", err)
+	}
+	if _, err := c.Network().GetNodeInfo(ctx); err != nil {
+		t.Fatalf("expected closed breaker, got %v", err)
+	}
+}
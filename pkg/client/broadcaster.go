@@ -5,12 +5,14 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/kslamph/tronlib/pb/api"
 	"github.com/kslamph/tronlib/pb/core"
 	"github.com/kslamph/tronlib/pkg/client/lowlevel"
 	"github.com/kslamph/tronlib/pkg/signer"
+	"github.com/kslamph/tronlib/pkg/types"
 	"github.com/kslamph/tronlib/pkg/utils"
 	"google.golang.org/protobuf/proto"
 )
@@ -22,24 +24,76 @@ import (
 // These options control how transactions are signed, broadcast, and confirmed.
 // Use DefaultBroadcastOptions() to get sensible defaults, then modify as needed.
 type BroadcastOptions struct {
-	FeeLimit       int64         // Fee limit for the transaction
+	// FeeLimit is the fee limit, in SUN, set before signing. When zero, the
+	// fee limit already on the transaction is kept, such as the default
+	// stamped by smartcontract.Instance and trc20.TRC20Manager; a transaction
+	// without one gets 150 TRX. A non-zero value always replaces it.
+	FeeLimit       int64
 	PermissionID   int32         // Permission ID for the transaction
 	WaitForReceipt bool          // Wait for transaction receipt
 	WaitTimeout    time.Duration // Timeout for waiting for receipt
 	PollInterval   time.Duration // Polling interval when waiting for receipt
+
+	// RetryOnBusy is the number of times to retry the broadcast when the node
+	// answers SERVER_BUSY, with exponential backoff between attempts. Zero
+	// disables retries. Retrying is safe because the txid does not change.
+	RetryOnBusy int
+	// TreatDuplicateAsSuccess reports a DUP_TRANSACTION_ERROR as success, since
+	// it means the transaction was already accepted (typically by an earlier
+	// attempt), and continues to wait for the receipt if requested.
+	TreatDuplicateAsSuccess bool
+	// SkipIfExists checks IsTransactionKnown before broadcasting and, when
+	// the node already has the transaction, skips the broadcast and reports
+	// success with code DUP_TRANSACTION_ERROR, waiting for the receipt if
+	// requested. Use it when a job may resend a transaction that landed.
+	SkipIfExists bool
+
+	// AutoFeeLimit sizes FeeLimit for TriggerSmartContract transactions from
+	// a simulation: simulated energy times the current energy price, plus
+	// FeeLimitMargin. It replaces FeeLimit and only applies when signers are
+	// passed to SignAndBroadcast. A simulation that reverts aborts the
+	// broadcast with types.ErrContractExecutionFailed.
+	AutoFeeLimit bool
+	// FeeLimitMargin is the safety margin added by AutoFeeLimit as a fraction
+	// of the simulated cost (0.2 = 20%). Zero uses the default of 20%.
+	FeeLimitMargin float64
+
+	// Expiration, when positive, resets the transaction to expire this long
+	// after signing instead of the node's default (about one minute after it
+	// was built). Like FeeLimit it only applies when signers are passed to
+	// SignAndBroadcast, and it is applied before the expiration check, so it
+	// also revives an expired unsigned transaction. It must not exceed 24
+	// hours. To set it where the transaction is built, use
+	// types.SetExpiration; see Client.RefreshTransaction for transactions
+	// whose reference block is too old.
+	Expiration time.Duration
 }
 
+// defaultFeeLimit is the fee limit, in SUN, applied when neither
+// BroadcastOptions.FeeLimit nor the transaction sets one (150 TRX).
+const defaultFeeLimit = 150_000_000
+
+// defaultFeeLimitMargin is the AutoFeeLimit safety margin used when
+// FeeLimitMargin is zero.
+const defaultFeeLimitMargin = 0.2
+
+// maxFeeLimit is the largest fee limit the network accepts (15,000 TRX).
+const maxFeeLimit = 15_000_000_000
+
+// busyRetryBackoff is the delay before the first SERVER_BUSY retry; it doubles
+// on each subsequent attempt.
+const busyRetryBackoff = 500 * time.Millisecond
+
 // DefaultBroadcastOptions returns sane defaults for broadcasting transactions.
 //
 // The default options are:
-//   - FeeLimit: 150,000,000 SUN (0.15 TRX)
+//   - FeeLimit: 0 (keep the transaction's fee limit, else 150 TRX)
 //   - PermissionID: 0 (owner permission)
 //   - WaitForReceipt: true (wait for transaction confirmation)
 //   - WaitTimeout: 15 seconds
 //   - PollInterval: 3 seconds
 func DefaultBroadcastOptions() BroadcastOptions {
 	return BroadcastOptions{
-		FeeLimit:       150_000_000,
 		PermissionID:   0,
 		WaitForReceipt: true,
 		WaitTimeout:    15 * time.Second, // seconds
@@ -48,15 +102,12 @@ func DefaultBroadcastOptions() BroadcastOptions {
 }
 
 // BroadcastResult summarizes the outcome of a simulation or a broadcasted
-// transaction, including TRON return status, and for smart contract transactions,
-// resource usage and logs.
+// transaction, including TRON return status, resource usage and logs.
 //
 // This struct contains the results of either a Simulate or SignAndBroadcast operation.
-// For smart contract transactions (CreateSmartContract and TriggerSmartContract),
-// when WaitForReceipt is true in SignAndBroadcast, additional fields like EnergyUsage
-// and Logs will be populated with data from the transaction receipt. For other
-// transaction types (like TRX transfers), only the basic success/failure information
-// will be available.
+// When WaitForReceipt is true in SignAndBroadcast, the receipt fields (NetUsage,
+// the fees and, for smart contract transactions, EnergyUsage and Logs) are
+// populated from the transaction receipt for every transaction type.
 type BroadcastResult struct {
 	TxID    string                 `json:"txID"`
 	Success bool                   `json:"success"`
@@ -67,16 +118,51 @@ type BroadcastResult struct {
 	// Populated for smart contract transactions when WaitForReceipt is true
 	ConstantReturn [][]byte //test if nil before use
 
-	// Fields primarily populated by simulation (TriggerConstantContract) or
-	// for smart contract transactions when WaitForReceipt is true
+	// Fields populated by simulation (TriggerConstantContract) or from the
+	// receipt when WaitForReceipt is true; EnergyUsage and Logs stay empty
+	// for transactions that run no contract code, such as TRX transfers
 	EnergyUsage int64                       `json:"energyUsed,omitempty"`
 	NetUsage    int64                       `json:"netUsage,omitempty"`
 	Logs        []*core.TransactionInfo_Log `json:"logs,omitempty"`
+	// EnergyPenalty is the part of EnergyUsage charged as the dynamic energy
+	// penalty for heavily used contracts.
+	EnergyPenalty int64 `json:"energyPenalty,omitempty"`
+	// InternalCalls lists the sub-calls and value transfers made during
+	// execution, in order.
+	InternalCalls []InternalCall `json:"internalCalls,omitempty"`
+
+	// Costs in SUN taken from the transaction receipt; populated only when
+	// SignAndBroadcast waited for the receipt.
+	NetFee    int64 `json:"netFee,omitempty"`    // TRX burned for bandwidth
+	EnergyFee int64 `json:"energyFee,omitempty"` // TRX burned for energy
+	TRXBurned int64 `json:"trxBurned,omitempty"` // total fee charged, including NetFee and EnergyFee
+
+	// FeeLimit is the fee limit SignAndBroadcast applied before signing,
+	// including one computed by AutoFeeLimit; zero if it was left unchanged.
+	FeeLimit int64 `json:"feeLimit,omitempty"`
+
+	// EstimatedEnergyFee is the simulated energy usage priced at the current
+	// energy fee, in SUN; WouldExceedFeeLimit reports whether it is above the
+	// fee limit given to SimulateWithFeeLimit. Both are set only by
+	// SimulateWithFeeLimit.
+	EstimatedEnergyFee  int64 `json:"estimatedEnergyFee,omitempty"`
+	WouldExceedFeeLimit bool  `json:"wouldExceedFeeLimit,omitempty"`
 	// DebugExt   *api.TransactionExtention   `json:"debugExt,omitempty"`
 }
 
+// SimulateOptions configures Simulate.
+type SimulateOptions struct {
+	// Timeout bounds the simulation in place of the client's default
+	// timeout (see WithTimeout), for heavy contract calls that need longer
+	// than ordinary requests. It applies on top of ctx, so an earlier ctx
+	// deadline still wins. Zero keeps the default behaviour: ctx's deadline
+	// if it has one, otherwise the client timeout.
+	Timeout time.Duration
+}
+
 // Simulate performs a read-only execution of a single-contract transaction and
-// returns a BroadcastResult with constant return data, energy usage, and logs.
+// returns a BroadcastResult with constant return data, energy usage, logs, and
+// the energy penalty and internal calls reported by the node.
 //
 // This method allows you to test a transaction without actually broadcasting it
 // to the network. It's useful for estimating energy usage and checking if a
@@ -85,9 +171,14 @@ type BroadcastResult struct {
 // Supported input types are *api.TransactionExtention and *core.Transaction.
 // The transaction must contain exactly one contract and must not be expired.
 //
+// A deadline on ctx always takes precedence over the client's default
+// timeout; alternatively pass SimulateOptions with a Timeout.
+//
 // Example:
 //
 //	sim, err := cli.Simulate(ctx, txExt)
+//	// or, for a slow contract with a short client-wide timeout:
+//	sim, err = cli.Simulate(ctx, txExt, client.SimulateOptions{Timeout: 30 * time.Second})
 //	if err != nil {
 //	    // handle error
 //	}
@@ -95,7 +186,14 @@ type BroadcastResult struct {
 //	    // transaction would fail
 //	}
 //	fmt.Printf("Energy usage: %d\n", sim.EnergyUsage)
-func (c *Client) Simulate(ctx context.Context, anytx any) (*BroadcastResult, error) {
+func (c *Client) Simulate(ctx context.Context, anytx any, opts ...SimulateOptions) (*BroadcastResult, error) {
+	var opt SimulateOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Timeout < 0 {
+		return nil, fmt.Errorf("%w: simulate timeout cannot be negative", types.ErrInvalidParameter)
+	}
 	if anytx == nil {
 		return nil, fmt.Errorf("transaction cannot be nil")
 	}
@@ -130,12 +228,18 @@ func (c *Client) Simulate(ctx context.Context, anytx any) (*BroadcastResult, err
 		return nil, fmt.Errorf("failed to decode transaction: %v", err)
 	}
 
+	if opt.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opt.Timeout)
+		defer cancel()
+	}
+
 	// Perform constant call (simulation)
 	ext, err := lowlevel.Call(c, ctx, "trigger constant contract", func(cl api.WalletClient, ctx context.Context) (*api.TransactionExtention, error) {
 		return cl.TriggerConstantContract(ctx, decodedTx)
 	})
 	if err != nil {
-		return nil, err
+		return nil, newNodeError("trigger constant contract", err)
 	}
 
 	br := &BroadcastResult{}
@@ -155,11 +259,47 @@ func (c *Client) Simulate(ctx context.Context, anytx any) (*BroadcastResult, err
 		br.ConstantReturn = ext.GetConstantResult()
 		br.EnergyUsage = ext.GetEnergyUsed()
 		br.Logs = ext.GetLogs()
+		br.EnergyPenalty = ext.GetEnergyPenalty()
+		br.InternalCalls = newInternalCalls(ext.GetInternalTransactions())
 	}
 
 	return br, nil
 }
 
+// SimulateWithFeeLimit simulates anytx like Simulate and checks whether the
+// simulated energy usage, priced at the network's current energy fee, fits
+// within feeLimit (in SUN). A transaction that succeeds in simulation can
+// still fail with OUT_OF_ENERGY on chain when WouldExceedFeeLimit is true.
+//
+// The check prices all energy at the burn rate and ignores energy the owner
+// has staked, so it is conservative for accounts with their own energy.
+//
+// Example:
+//
+//	sim, err := cli.SimulateWithFeeLimit(ctx, txExt, 30_000_000)
+//	if err != nil {
+//	    // handle error
+//	}
+//	if sim.WouldExceedFeeLimit {
+//	    fmt.Printf("needs %d SUN of fee limit\n", sim.EstimatedEnergyFee)
+//	}
+func (c *Client) SimulateWithFeeLimit(ctx context.Context, anytx any, feeLimit int64) (*BroadcastResult, error) {
+	if feeLimit <= 0 {
+		return nil, fmt.Errorf("%w: fee limit must be positive, got %d", types.ErrInvalidParameter, feeLimit)
+	}
+	sim, err := c.Simulate(ctx, anytx)
+	if err != nil {
+		return nil, err
+	}
+	energyFee, err := c.Network().GetEnergyFee(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get energy fee: %w", err)
+	}
+	sim.EstimatedEnergyFee = sim.EnergyUsage * energyFee
+	sim.WouldExceedFeeLimit = sim.EstimatedEnergyFee > feeLimit
+	return sim, nil
+}
+
 // SignAndBroadcast signs a single-contract transaction using the provided
 // signers (if any), applies BroadcastOptions, broadcasts it to the network,
 // and optionally waits for receipt. It returns a BroadcastResult with txid,
@@ -170,13 +310,16 @@ func (c *Client) Simulate(ctx context.Context, anytx any) (*BroadcastResult, err
 // It handles signing, broadcasting, and (optionally) waiting for the transaction
 // to be confirmed.
 //
-// For smart contract transactions (CreateSmartContract and TriggerSmartContract),
-// when WaitForReceipt is enabled, it will retrieve execution results including
-// energy usage, logs, and contract return values. For other transaction types
-// (like TRX transfers), it will only indicate success/failure status.
+// When WaitForReceipt is enabled, it retrieves the receipt for any transaction
+// type: bandwidth usage and fees for all of them, plus energy usage, logs and
+// contract return values for smart contract transactions.
 //
 // Supported input types are *api.TransactionExtention and *core.Transaction.
 //
+// If the node rejects the broadcast or the RPC itself fails, the returned error
+// is a *NodeError carrying the TRON return code and gRPC status code; the
+// BroadcastResult is still returned with the txid and return code populated.
+//
 // Example:
 //
 //	opts := client.DefaultBroadcastOptions()
@@ -195,19 +338,7 @@ func (c *Client) Simulate(ctx context.Context, anytx any) (*BroadcastResult, err
 //	    }
 //	}
 func (c *Client) SignAndBroadcast(ctx context.Context, anytx any, opt BroadcastOptions, signers ...signer.Signer) (*BroadcastResult, error) {
-	// Apply defaults for zero-values without breaking explicit non-zero caller values.
-	def := DefaultBroadcastOptions()
-	if opt.FeeLimit == 0 {
-		opt.FeeLimit = def.FeeLimit
-	}
-	// PermissionID: default is 0; honor explicit 0 provided by caller.
-	// WaitForReceipt: honor explicit false (no defaulting needed here).
-	if opt.WaitTimeout == 0 {
-		opt.WaitTimeout = def.WaitTimeout
-	}
-	if opt.PollInterval == 0 {
-		opt.PollInterval = def.PollInterval
-	}
+	opt = opt.withDefaults()
 	// Trigger the smart contract with the given parameters
 	if anytx == nil {
 		return nil, fmt.Errorf("transaction cannot be nil")
@@ -223,24 +354,37 @@ func (c *Client) SignAndBroadcast(ctx context.Context, anytx any, opt BroadcastO
 	default:
 		return nil, fmt.Errorf("unsupported transaction type: %T", anytx)
 	}
-	// Validate transaction
-	if coretx == nil {
-		return nil, fmt.Errorf("transaction cannot be nil")
-	}
-	if coretx.GetRawData() == nil {
-		return nil, fmt.Errorf("transaction raw data cannot be nil")
+	if opt.Expiration < 0 || opt.Expiration > maxExpirationWindow {
+		return nil, fmt.Errorf("%w: expiration must be between 0 and %v, got %v", types.ErrInvalidParameter, maxExpirationWindow, opt.Expiration)
 	}
-	if len(coretx.GetRawData().GetContract()) != 1 {
-		return nil, fmt.Errorf("transaction must have exactly one contract, got %d", len(coretx.GetRawData().GetContract()))
+	// Reset the expiration before validating, so a transaction that expired
+	// while waiting to be signed can still be signed with a fresh one.
+	if len(signers) > 0 && opt.Expiration > 0 && coretx.GetRawData() != nil {
+		if err := types.SetExpiration(anytx, opt.Expiration); err != nil {
+			return nil, err
+		}
 	}
-	if coretx.GetRawData().GetExpiration() < time.Now().UnixMilli() {
-		return nil, fmt.Errorf("transaction expiration must be in the future")
+	if err := validateForBroadcast(coretx); err != nil {
+		return nil, err
 	}
 
 	if len(signers) > 0 {
 		if opt.PermissionID != 0 {
 			coretx.RawData.GetContract()[0].PermissionId = opt.PermissionID
 		}
+		if opt.AutoFeeLimit && coretx.GetRawData().GetContract()[0].GetType() == core.Transaction_Contract_TriggerSmartContract {
+			feeLimit, err := c.autoFeeLimit(ctx, coretx, opt.FeeLimitMargin)
+			if err != nil {
+				return nil, err
+			}
+			opt.FeeLimit = feeLimit
+		}
+		if opt.FeeLimit == 0 {
+			opt.FeeLimit = coretx.GetRawData().GetFeeLimit()
+		}
+		if opt.FeeLimit == 0 {
+			opt.FeeLimit = defaultFeeLimit
+		}
 		coretx.RawData.FeeLimit = opt.FeeLimit
 		for _, s := range signers {
 			if err := signer.SignTx(s, coretx); err != nil {
@@ -249,26 +393,131 @@ func (c *Client) SignAndBroadcast(ctx context.Context, anytx any, opt BroadcastO
 		}
 	}
 
+	result, err := c.submit(ctx, coretx, opt)
+	if result != nil && len(signers) > 0 {
+		result.FeeLimit = opt.FeeLimit
+	}
+	return result, err
+}
+
+// autoFeeLimit simulates coretx and returns its energy cost at the current
+// energy price plus margin, capped at maxFeeLimit.
+func (c *Client) autoFeeLimit(ctx context.Context, coretx *core.Transaction, margin float64) (int64, error) {
+	sim, err := c.Simulate(ctx, coretx)
+	if err != nil {
+		return 0, fmt.Errorf("auto fee limit: %w", err)
+	}
+	if !sim.Success {
+		return 0, fmt.Errorf("%w: auto fee limit simulation failed: %s", types.ErrContractExecutionFailed, sim.Message)
+	}
+	energyFee, err := c.Network().GetEnergyFee(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("auto fee limit: %w", err)
+	}
+	if margin <= 0 {
+		margin = defaultFeeLimitMargin
+	}
+	feeLimit := int64(math.Ceil(float64(sim.EnergyUsage*energyFee) * (1 + margin)))
+	if feeLimit > maxFeeLimit {
+		feeLimit = maxFeeLimit
+	}
+	return feeLimit, nil
+}
+
+// BroadcastSigned broadcasts a transaction that is already fully signed, for
+// example a multi-signature transaction collected offline, and optionally
+// waits for its receipt.
+//
+// Unlike SignAndBroadcast it never modifies the transaction: FeeLimit and
+// PermissionID in opt are ignored because changing the raw data would
+// invalidate the existing signatures. The transaction must carry at least one
+// signature. Errors are reported as for SignAndBroadcast.
+//
+// Example:
+//
+//	// tx signed by each required key with signer.SignTx
+//	result, err := cli.BroadcastSigned(ctx, tx, client.DefaultBroadcastOptions())
+func (c *Client) BroadcastSigned(ctx context.Context, tx *core.Transaction, opt BroadcastOptions) (*BroadcastResult, error) {
+	opt = opt.withDefaults()
+	if err := validateForBroadcast(tx); err != nil {
+		return nil, err
+	}
+	if len(tx.GetSignature()) == 0 {
+		return nil, fmt.Errorf("%w: transaction has no signatures", types.ErrInvalidTransaction)
+	}
+	return c.submit(ctx, tx, opt)
+}
+
+// withDefaults applies defaults for zero-values without breaking explicit
+// non-zero caller values.
+func (opt BroadcastOptions) withDefaults() BroadcastOptions {
+	def := DefaultBroadcastOptions()
+	// FeeLimit: zero is resolved against the transaction in SignAndBroadcast.
+	// PermissionID: default is 0; honor explicit 0 provided by caller.
+	// WaitForReceipt: honor explicit false (no defaulting needed here).
+	if opt.WaitTimeout == 0 {
+		opt.WaitTimeout = def.WaitTimeout
+	}
+	if opt.PollInterval == 0 {
+		opt.PollInterval = def.PollInterval
+	}
+	return opt
+}
+
+// validateForBroadcast checks that coretx is a single-contract transaction
+// that has not expired.
+func validateForBroadcast(coretx *core.Transaction) error {
+	if coretx == nil {
+		return fmt.Errorf("transaction cannot be nil")
+	}
+	if coretx.GetRawData() == nil {
+		return fmt.Errorf("transaction raw data cannot be nil")
+	}
+	if len(coretx.GetRawData().GetContract()) != 1 {
+		return fmt.Errorf("transaction must have exactly one contract, got %d", len(coretx.GetRawData().GetContract()))
+	}
+	if coretx.GetRawData().GetExpiration() < time.Now().UnixMilli() {
+		return fmt.Errorf("transaction expiration must be in the future")
+	}
+	return nil
+}
+
+// submit broadcasts a signed transaction and waits for the receipt when
+// opt.WaitForReceipt is set.
+func (c *Client) submit(ctx context.Context, coretx *core.Transaction, opt BroadcastOptions) (*BroadcastResult, error) {
 	txid := utils.GetTransactionID(coretx)
 
 	result := &BroadcastResult{TxID: hex.EncodeToString(txid)}
 
-	ret, err := lowlevel.Call(c, ctx, "broadcast transaction", func(cl api.WalletClient, ctx context.Context) (*api.Return, error) {
-		return cl.BroadcastTransaction(ctx, coretx)
-	})
-	if err != nil {
-		return result, fmt.Errorf("failed to broadcast transaction: %w", err)
+	known := false
+	if opt.SkipIfExists {
+		var err error
+		if known, _, err = c.IsTransactionKnown(ctx, result.TxID); err != nil {
+			return result, fmt.Errorf("check existing transaction: %w", err)
+		}
+	}
+	if known {
+		result.Success = true
+		result.Code = api.Return_DUP_TRANSACTION_ERROR
+		result.Message = "transaction already known to the node"
+	} else {
+		ret, err := c.broadcast(ctx, coretx, opt.RetryOnBusy)
+		if err != nil {
+			return result, newNodeError("broadcast transaction", err)
+		}
+		result.Success = ret.GetResult()
+		result.Code = ret.GetCode()
+		result.Message = string(ret.GetMessage())
+		if opt.TreatDuplicateAsSuccess && ret.GetCode() == api.Return_DUP_TRANSACTION_ERROR {
+			result.Success = true
+		} else if err := newReturnError("broadcast transaction", ret); err != nil {
+			return result, err
+		}
 	}
-	result.Success = ret.GetResult()
-	result.Code = ret.GetCode()
-	result.Message = string(ret.GetMessage())
-
-	// Check if this is a smart contract transaction (only applicable to CreateSmartContract and TriggerSmartContract)
-	contractType := coretx.GetRawData().GetContract()[0].GetType()
-	isSmartContractTx := contractType == core.Transaction_Contract_CreateSmartContract ||
-		contractType == core.Transaction_Contract_TriggerSmartContract
 
-	if !opt.WaitForReceipt || !isSmartContractTx {
+	// Every transaction type has a receipt; for transfers its bandwidth
+	// usage and fees are the whole cost.
+	if !opt.WaitForReceipt {
 		return result, nil
 	}
 
@@ -284,10 +533,35 @@ func (c *Client) SignAndBroadcast(ctx context.Context, anytx any, opt BroadcastO
 	result.EnergyUsage = txInfo.GetReceipt().GetEnergyUsageTotal()
 	result.NetUsage = txInfo.GetReceipt().GetNetUsage()
 	result.Logs = txInfo.GetLog()
+	result.EnergyPenalty = txInfo.GetReceipt().GetEnergyPenaltyTotal()
+	result.InternalCalls = newInternalCalls(txInfo.GetInternalTransactions())
+	result.NetFee = txInfo.GetReceipt().GetNetFee()
+	result.EnergyFee = txInfo.GetReceipt().GetEnergyFee()
+	result.TRXBurned = txInfo.GetFee()
 
 	return result, nil
 }
 
+// broadcast sends the transaction, retrying up to retries times with
+// exponential backoff while the node answers SERVER_BUSY.
+func (c *Client) broadcast(ctx context.Context, coretx *core.Transaction, retries int) (*api.Return, error) {
+	backoff := busyRetryBackoff
+	for attempt := 0; ; attempt++ {
+		ret, err := lowlevel.Call(c, ctx, "broadcast transaction", func(cl api.WalletClient, ctx context.Context) (*api.Return, error) {
+			return cl.BroadcastTransaction(ctx, coretx)
+		})
+		if err != nil || ret.GetCode() != api.Return_SERVER_BUSY || attempt >= retries {
+			return ret, err
+		}
+		select {
+		case <-ctx.Done():
+			return ret, nil
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
 func (c *Client) waitForTransactionInfo(ctx context.Context, txid []byte, waitTimeout time.Duration, pollInterval time.Duration) *core.TransactionInfo {
 	timeout := waitTimeout
 	ctx, cancel := context.WithTimeout(ctx, timeout)
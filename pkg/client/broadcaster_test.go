@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/kslamph/tronlib/pb/api"
 	"github.com/kslamph/tronlib/pb/core"
 	"github.com/kslamph/tronlib/pkg/signer"
+	"github.com/kslamph/tronlib/pkg/types"
 	"github.com/kslamph/tronlib/pkg/utils"
 )
 
@@ -105,6 +107,94 @@ func TestSignAndBroadcast_WithSignerPermissionAndFee(t *testing.T) {
 	}
 }
 
+func TestSignAndBroadcast_FeeLimitPrecedence(t *testing.T) {
+	fakeSigner, _ := signer.NewPrivateKeySigner("1cba74a2cbc5008272e0250b1b36f9e8527510665107e19451032839d6c4e887")
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	cases := []struct {
+		name        string
+		stamped     int64
+		optFee      int64
+		wantApplied int64
+	}{
+		{"keeps stamped", 30_000_000, 0, 30_000_000},
+		{"option wins", 30_000_000, 5_000_000, 5_000_000},
+		{"fallback", 0, 0, defaultFeeLimit},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Second))
+			tx.RawData.FeeLimit = tc.stamped
+			opts := DefaultBroadcastOptions()
+			opts.WaitForReceipt = false
+			opts.FeeLimit = tc.optFee
+			res, err := c.SignAndBroadcast(context.Background(), tx, opts, fakeSigner)
+			if err != nil {
+				t.Fatalf("SignAndBroadcast error: %v", err)
+			}
+			if got := tx.GetRawData().GetFeeLimit(); got != tc.wantApplied || res.FeeLimit != tc.wantApplied {
+				t.Fatalf("fee limit = %d (reported %d), want %d", got, res.FeeLimit, tc.wantApplied)
+			}
+		})
+	}
+}
+
+func TestSignAndBroadcast_Expiration(t *testing.T) {
+	fakeSigner, _ := signer.NewPrivateKeySigner("1cba74a2cbc5008272e0250b1b36f9e8527510665107e19451032839d6c4e887")
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Second))
+	before := time.Now()
+	res, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{Expiration: time.Hour}, fakeSigner)
+	if err != nil {
+		t.Fatalf("SignAndBroadcast error: %v", err)
+	}
+	exp := types.GetExpiration(tx)
+	if exp.Before(before.Add(time.Hour).Truncate(time.Millisecond)) || exp.After(time.Now().Add(time.Hour)) {
+		t.Fatalf("expiration not applied, got %v", exp)
+	}
+	if want := hex.EncodeToString(utils.GetTransactionID(tx)); res.TxID != want {
+		t.Fatalf("txid not recomputed: got %s want %s", res.TxID, want)
+	}
+
+	_, err = c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{Expiration: 25 * time.Hour}, fakeSigner)
+	if !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for expiration over 24h, got %v", err)
+	}
+
+	// An already expired transaction is revived by the override rather than
+	// rejected by the expiration check.
+	expired := buildTriggerSmartContractTx(time.Now())
+	expired.RawData.Expiration = time.Now().Add(-time.Minute).UnixMilli()
+	if _, err := c.SignAndBroadcast(context.Background(), expired, BroadcastOptions{}, fakeSigner); err == nil {
+		t.Fatalf("expected expired transaction to be rejected without an override, got %v", err)
+	}
+	if _, err := c.SignAndBroadcast(context.Background(), expired, BroadcastOptions{Expiration: time.Minute}, fakeSigner); err != nil {
+		t.Fatalf("expected expiration override to revive expired transaction: %v", err)
+	}
+	if types.IsExpired(expired) {
+		t.Fatalf("expiration not reset on expired transaction")
+	}
+}
+
 func TestSignAndBroadcast_WaitForReceipt_Success(t *testing.T) {
 	var polls int32
 	var txidSeen []byte
@@ -153,6 +243,42 @@ func TestSignAndBroadcast_WaitForReceipt_Success(t *testing.T) {
 	}
 }
 
+func TestSignAndBroadcast_WaitForReceipt_Transfer(t *testing.T) {
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+		},
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			return &core.TransactionInfo{
+				Id:      in.GetValue(),
+				Fee:     100_000,
+				Receipt: &core.ResourceReceipt{NetUsage: 267, NetFee: 100_000},
+			}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		Contract:   []*core.Transaction_Contract{{Type: core.Transaction_Contract_TransferContract}},
+		Expiration: time.Now().Add(time.Minute).UnixMilli(),
+	}}
+	res, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{
+		WaitForReceipt: true,
+		WaitTimeout:    time.Second,
+		PollInterval:   10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("SignAndBroadcast error: %v", err)
+	}
+	if !res.Success || res.NetUsage != 267 || res.NetFee != 100_000 || res.TRXBurned != 100_000 {
+		t.Fatalf("expected receipt costs for a TRX transfer, got %+v", res)
+	}
+}
+
 func TestSignAndBroadcast_WaitForReceipt_Timeout(t *testing.T) {
 	srv := &testWalletServer{
 		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
@@ -236,3 +362,367 @@ func TestSimulate_ValidationErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestSignAndBroadcast_RetryOnBusy(t *testing.T) {
+	var calls int32
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return &api.Return{Result: false, Code: api.Return_SERVER_BUSY}, nil
+			}
+			return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 2*time.Second)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(5 * time.Second))
+	res, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{RetryOnBusy: 2})
+	if err != nil {
+		t.Fatalf("SignAndBroadcast error: %v", err)
+	}
+	if !res.Success || atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected success after one retry, got success=%v calls=%d", res.Success, calls)
+	}
+
+	// Without retries the busy answer is surfaced
+	atomic.StoreInt32(&calls, 0)
+	if _, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{}); err == nil {
+		t.Fatalf("expected SERVER_BUSY error without retries")
+	}
+}
+
+func TestSignAndBroadcast_TreatDuplicateAsSuccess(t *testing.T) {
+	var polled int32
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			return &api.Return{Result: false, Code: api.Return_DUP_TRANSACTION_ERROR, Message: []byte("dup transaction")}, nil
+		},
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			atomic.AddInt32(&polled, 1)
+			return &core.TransactionInfo{Id: in.GetValue(), Result: core.TransactionInfo_SUCESS}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(5 * time.Second))
+	if _, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{WaitForReceipt: false}); err == nil {
+		t.Fatalf("expected duplicate error when not opted in")
+	}
+
+	opts := BroadcastOptions{
+		WaitForReceipt:          true,
+		WaitTimeout:             2 * time.Second,
+		PollInterval:            20 * time.Millisecond,
+		TreatDuplicateAsSuccess: true,
+	}
+	res, err := c.SignAndBroadcast(context.Background(), tx, opts)
+	if err != nil {
+		t.Fatalf("SignAndBroadcast error: %v", err)
+	}
+	if !res.Success || res.Code != api.Return_DUP_TRANSACTION_ERROR {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if atomic.LoadInt32(&polled) == 0 {
+		t.Fatalf("expected receipt polling after duplicate")
+	}
+}
+
+func TestSignAndBroadcast_WaitForReceipt_Costs(t *testing.T) {
+	srv := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			return &core.TransactionInfo{
+				Id:     in.GetValue(),
+				Fee:    3_145_000,
+				Result: core.TransactionInfo_SUCESS,
+				Receipt: &core.ResourceReceipt{
+					EnergyUsageTotal: 29_631,
+					EnergyFee:        2_800_000,
+					NetUsage:         0,
+					NetFee:           345_000,
+				},
+			}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(5 * time.Second))
+	opts := BroadcastOptions{WaitForReceipt: true, WaitTimeout: 2 * time.Second, PollInterval: 20 * time.Millisecond}
+	res, err := c.SignAndBroadcast(context.Background(), tx, opts)
+	if err != nil {
+		t.Fatalf("SignAndBroadcast error: %v", err)
+	}
+	if res.EnergyUsage != 29_631 || res.EnergyFee != 2_800_000 || res.NetFee != 345_000 || res.TRXBurned != 3_145_000 {
+		t.Fatalf("unexpected costs: %+v", res)
+	}
+}
+
+func TestBroadcastSigned(t *testing.T) {
+	var received *core.Transaction
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			received = in
+			return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Second))
+
+	// Unsigned transactions are rejected before broadcasting
+	if _, err := c.BroadcastSigned(context.Background(), tx, BroadcastOptions{}); !errors.Is(err, types.ErrInvalidTransaction) {
+		t.Fatalf("expected ErrInvalidTransaction, got %v", err)
+	}
+	if received != nil {
+		t.Fatalf("unsigned transaction was broadcast")
+	}
+
+	fakeSigner, _ := signer.NewPrivateKeySigner("1cba74a2cbc5008272e0250b1b36f9e8527510665107e19451032839d6c4e887")
+	tx.RawData.GetContract()[0].PermissionId = 2
+	if err := signer.SignTx(fakeSigner, tx); err != nil {
+		t.Fatalf("SignTx: %v", err)
+	}
+	wantTxID := hex.EncodeToString(utils.GetTransactionID(tx))
+
+	// FeeLimit and PermissionID must not be applied to a signed transaction
+	res, err := c.BroadcastSigned(context.Background(), tx, BroadcastOptions{FeeLimit: 1, PermissionID: 3})
+	if err != nil {
+		t.Fatalf("BroadcastSigned error: %v", err)
+	}
+	if !res.Success || res.TxID != wantTxID {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if got := received.GetRawData().GetContract()[0].GetPermissionId(); got != 2 {
+		t.Fatalf("permission id modified, got %d", got)
+	}
+	if len(received.GetSignature()) != 1 {
+		t.Fatalf("expected exactly the original signature, got %d", len(received.GetSignature()))
+	}
+}
+
+func TestSignAndBroadcast_AutoFeeLimit(t *testing.T) {
+	fakeSigner, _ := signer.NewPrivateKeySigner("1cba74a2cbc5008272e0250b1b36f9e8527510665107e19451032839d6c4e887")
+	srv := &testWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{
+				EnergyUsed: 10_000,
+				Result:     &api.Return{Result: true, Code: api.Return_SUCCESS},
+			}, nil
+		},
+		GetChainParametersHandler: func(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+			return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+				{Key: "getEnergyFee", Value: 420},
+			}}, nil
+		},
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Second))
+
+	res, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{
+		FeeLimit:       1,
+		AutoFeeLimit:   true,
+		FeeLimitMargin: 0.5,
+	}, fakeSigner)
+	if err != nil {
+		t.Fatalf("SignAndBroadcast error: %v", err)
+	}
+	const want = 10_000 * 420 * 3 / 2
+	if res.FeeLimit != want {
+		t.Fatalf("result fee limit: got %d want %d", res.FeeLimit, want)
+	}
+	if got := tx.GetRawData().GetFeeLimit(); got != want {
+		t.Fatalf("tx fee limit: got %d want %d", got, want)
+	}
+}
+
+func TestSignAndBroadcast_AutoFeeLimitReverted(t *testing.T) {
+	fakeSigner, _ := signer.NewPrivateKeySigner("1cba74a2cbc5008272e0250b1b36f9e8527510665107e19451032839d6c4e887")
+	var broadcasts int32
+	srv := &testWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{
+				Result: &api.Return{Result: false, Code: api.Return_CONTRACT_EXE_ERROR, Message: []byte("REVERT")},
+			}, nil
+		},
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			atomic.AddInt32(&broadcasts, 1)
+			return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Second))
+
+	_, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{AutoFeeLimit: true}, fakeSigner)
+	if !errors.Is(err, types.ErrContractExecutionFailed) {
+		t.Fatalf("expected ErrContractExecutionFailed, got %v", err)
+	}
+	if n := atomic.LoadInt32(&broadcasts); n != 0 {
+		t.Fatalf("expected no broadcast, got %d", n)
+	}
+}
+
+func TestSimulate_InternalCalls(t *testing.T) {
+	caller, _ := types.NewAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	to, _ := types.NewAddress("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	srv := &testWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{
+				EnergyUsed:    50_000,
+				EnergyPenalty: 20_000,
+				Result:        &api.Return{Result: true},
+				InternalTransactions: []*core.InternalTransaction{{
+					Hash:              []byte{0xab, 0xcd},
+					CallerAddress:     caller.Bytes(),
+					TransferToAddress: to.Bytes(),
+					Note:              []byte("call"),
+					CallValueInfo:     []*core.InternalTransaction_CallValueInfo{{CallValue: 1_000_000}},
+				}},
+			}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	res, err := c.Simulate(context.Background(), buildTriggerSmartContractTx(time.Now().Add(2*time.Second)))
+	if err != nil {
+		t.Fatalf("Simulate error: %v", err)
+	}
+	if res.EnergyPenalty != 20_000 {
+		t.Fatalf("unexpected energy penalty %d", res.EnergyPenalty)
+	}
+	if len(res.InternalCalls) != 1 {
+		t.Fatalf("expected 1 internal call, got %d", len(res.InternalCalls))
+	}
+	call := res.InternalCalls[0]
+	if call.Hash != "abcd" || call.Note != "call" || !call.Caller.Equal(caller) || !call.To.Equal(to) {
+		t.Fatalf("unexpected internal call %+v", call)
+	}
+	if len(call.Values) != 1 || call.Values[0].Amount != 1_000_000 || call.Values[0].TokenID != "" {
+		t.Fatalf("unexpected call values %+v", call.Values)
+	}
+}
+
+func TestSimulateWithFeeLimit(t *testing.T) {
+	var paramCalls atomic.Int32
+	srv := &testWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{
+				EnergyUsed: 10_000,
+				Result:     &api.Return{Result: true, Code: api.Return_SUCCESS},
+			}, nil
+		},
+		GetChainParametersHandler: func(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+			paramCalls.Add(1)
+			return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+				{Key: "getEnergyFee", Value: 420},
+			}}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Second))
+
+	res, err := c.SimulateWithFeeLimit(context.Background(), tx, 4_200_000)
+	if err != nil {
+		t.Fatalf("SimulateWithFeeLimit error: %v", err)
+	}
+	if !res.Success || res.EstimatedEnergyFee != 4_200_000 || res.WouldExceedFeeLimit {
+		t.Fatalf("unexpected result at exact limit: %+v", res)
+	}
+
+	res, err = c.SimulateWithFeeLimit(context.Background(), tx, 4_199_999)
+	if err != nil {
+		t.Fatalf("SimulateWithFeeLimit error: %v", err)
+	}
+	if !res.Success || !res.WouldExceedFeeLimit {
+		t.Fatalf("expected fee limit to be exceeded: %+v", res)
+	}
+	if n := paramCalls.Load(); n != 1 {
+		t.Fatalf("expected chain parameters to be fetched once across calls, got %d", n)
+	}
+	if c.Network() != c.Network() {
+		t.Fatal("expected Network to return the same manager")
+	}
+
+	if _, err := c.SimulateWithFeeLimit(context.Background(), tx, 0); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestSimulate_Timeout(t *testing.T) {
+	srv := &testWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			select {
+			case <-time.After(200 * time.Millisecond):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			return &api.TransactionExtention{Result: &api.Return{Result: true, Code: api.Return_SUCCESS}}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	// The client-wide timeout is shorter than the simulation.
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 50*time.Millisecond)
+	t.Cleanup(cleanupClient)
+	tx := buildTriggerSmartContractTx(time.Now().Add(5 * time.Second))
+
+	if _, err := c.Simulate(context.Background(), tx); err == nil {
+		t.Fatal("expected the client timeout to cut the simulation short")
+	}
+
+	if res, err := c.Simulate(context.Background(), tx, SimulateOptions{Timeout: 2 * time.Second}); err != nil || !res.Success {
+		t.Fatalf("Simulate with Timeout: res=%+v err=%v", res, err)
+	}
+
+	// A context deadline wins over the client default.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if res, err := c.Simulate(ctx, tx); err != nil || !res.Success {
+		t.Fatalf("Simulate with ctx deadline: res=%+v err=%v", res, err)
+	}
+
+	// An earlier context deadline still wins over Timeout.
+	short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	if _, err := c.Simulate(short, tx, SimulateOptions{Timeout: 2 * time.Second}); err == nil {
+		t.Fatal("expected the ctx deadline to cut the simulation short")
+	}
+
+	if _, err := c.Simulate(context.Background(), tx, SimulateOptions{Timeout: -1}); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+}
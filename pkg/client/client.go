@@ -2,16 +2,20 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 
+	"github.com/kslamph/tronlib/pkg/network"
 	"github.com/kslamph/tronlib/pkg/types"
 )
 
@@ -20,6 +24,9 @@ var (
 	ErrConnectionFailed = types.NewTronError(1001, "connection to node failed", nil)
 	ErrClientClosed     = types.NewTronError(1002, "client is closed", nil)
 	ErrContextCancelled = types.NewTronError(1003, "context cancelled", nil)
+	// ErrCircuitOpen is returned without contacting the node while the
+	// circuit breaker (see WithCircuitBreaker) is open.
+	ErrCircuitOpen = types.NewTronError(1006, "circuit breaker open", nil)
 )
 
 // Functional options for Client
@@ -29,6 +36,61 @@ type clientOptions struct {
 	timeout         time.Duration
 	initConnections int
 	maxConnections  int
+	maxRecvMsgSize  int
+	maxSendMsgSize  int
+	keepalive       *keepalive.ClientParameters
+	idleTimeout     time.Duration
+	tlsConfig       *tls.Config
+	metrics         MetricsFunc
+
+	breakerThreshold int
+	breakerCooldown  time.Duration
+}
+
+// DefaultMaxRecvMsgSize is the default maximum size in bytes of a single gRPC
+// response. gRPC itself defaults to 4MB, which full TRON blocks with many
+// transactions regularly exceed.
+const DefaultMaxRecvMsgSize = 32 * 1024 * 1024
+
+// newClientOptions returns clientOptions populated with the defaults.
+func newClientOptions() *clientOptions {
+	return &clientOptions{
+		timeout:         30 * time.Second,
+		initConnections: 1,
+		maxConnections:  5,
+		maxRecvMsgSize:  DefaultMaxRecvMsgSize,
+	}
+}
+
+// dialOptions returns the gRPC dial options derived from the client options,
+// excluding transport credentials. endpoint is reported with metrics.
+func (co *clientOptions) dialOptions(endpoint string) []grpc.DialOption {
+	var callOpts []grpc.CallOption
+	if co.maxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(co.maxRecvMsgSize))
+	}
+	if co.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(co.maxSendMsgSize))
+	}
+	var dialOpts []grpc.DialOption
+	if len(callOpts) > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if co.keepalive != nil {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(*co.keepalive))
+	}
+	// Metrics run outermost so short-circuited calls are reported too.
+	var interceptors []grpc.UnaryClientInterceptor
+	if co.metrics != nil {
+		interceptors = append(interceptors, metricsInterceptor(endpoint, co.metrics))
+	}
+	if co.breakerThreshold > 0 {
+		interceptors = append(interceptors, breakerInterceptor(newCircuitBreaker(co.breakerThreshold, co.breakerCooldown)))
+	}
+	if len(interceptors) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(interceptors...))
+	}
+	return dialOpts
 }
 
 // WithTimeout sets the default timeout for client operations when the context has no deadline.
@@ -51,6 +113,61 @@ func WithPool(initConnections, maxConnections int) Option {
 	}
 }
 
+// WithMaxRecvMsgSize sets the maximum size in bytes of a single message the
+// client will accept from the node (default: DefaultMaxRecvMsgSize, 32MB).
+//
+// Large blocks and contract payloads can exceed gRPC's own 4MB default. Note
+// that gRPC buffers each message fully in memory, so this is also an upper
+// bound on the memory a single in-flight response may use; raise it only as
+// far as needed when many calls run concurrently.
+func WithMaxRecvMsgSize(bytes int) Option {
+	return func(co *clientOptions) { co.maxRecvMsgSize = bytes }
+}
+
+// WithMaxSendMsgSize sets the maximum size in bytes of a single message the
+// client will send to the node. When unset, gRPC's default is used.
+func WithMaxSendMsgSize(bytes int) Option {
+	return func(co *clientOptions) { co.maxSendMsgSize = bytes }
+}
+
+// WithKeepalive enables gRPC keepalive pings on pooled connections.
+//
+// The client pings the node after pingTime without activity and closes the
+// connection if no ack arrives within timeout. When permitWithoutStream is
+// true, pings are sent even with no active RPCs, which keeps idle connections
+// alive behind NAT gateways and load balancers. Note that many public nodes
+// reject pings more frequent than every few minutes with GOAWAY.
+func WithKeepalive(pingTime, timeout time.Duration, permitWithoutStream bool) Option {
+	return func(co *clientOptions) {
+		co.keepalive = &keepalive.ClientParameters{
+			Time:                pingTime,
+			Timeout:             timeout,
+			PermitWithoutStream: permitWithoutStream,
+		}
+	}
+}
+
+// WithIdleTimeout closes pooled connections that have not been used for d.
+//
+// Idle connections are reaped in the background and are also re-dialed on
+// checkout if they expired in the meantime, so callers never receive a
+// connection that sat unused past the timeout. Zero (the default) disables
+// reaping.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(co *clientOptions) { co.idleTimeout = d }
+}
+
+// WithTLSConfig sets the TLS configuration used for grpcs:// endpoints.
+//
+// The config is used verbatim, which allows custom root CAs, client
+// certificates for mutual TLS, or InsecureSkipVerify for private nodes with
+// self-signed certificates. Without this option grpcs:// endpoints verify the
+// node against the system roots. NewClient rejects this option for grpc://
+// endpoints.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(co *clientOptions) { co.tlsConfig = cfg }
+}
+
 // Client manages connection to a single Tron node with connection pooling.
 //
 // The Client maintains a pool of gRPC connections to improve performance for
@@ -64,6 +181,15 @@ type Client struct {
 	timeout     time.Duration
 	nodeAddress string
 	closed      int32
+
+	// owner is the client that owns pool when this client is a view created
+	// by WithOptions; nil for clients that own their pool.
+	owner *Client
+
+	// network is created on first use by Network so that its chain
+	// parameter cache is shared by every caller of this client.
+	networkOnce sync.Once
+	network     *network.NetworkManager
 }
 
 // NewClient creates a new client to a TRON node using endpoint like grpc://host:port or grpcs://host:port
@@ -74,6 +200,11 @@ type Client struct {
 // Options can be used to configure:
 //   - Connection timeout with WithTimeout()
 //   - Connection pool size with WithPool()
+//   - Message size limits with WithMaxRecvMsgSize() and WithMaxSendMsgSize()
+//   - Connection liveness with WithKeepalive() and WithIdleTimeout()
+//   - TLS settings for grpcs:// endpoints with WithTLSConfig()
+//   - Per-call latency and error reporting with WithMetrics()
+//   - Backing off a failing node with WithCircuitBreaker()
 //
 // Example:
 //
@@ -106,11 +237,7 @@ func NewClient(endpoint string, opts ...Option) (*Client, error) {
 	}
 
 	// Apply options with defaults
-	co := &clientOptions{
-		timeout:         30 * time.Second,
-		initConnections: 1,
-		maxConnections:  5,
-	}
+	co := newClientOptions()
 	for _, opt := range opts {
 		if opt != nil {
 			opt(co)
@@ -123,12 +250,20 @@ func NewClient(endpoint string, opts ...Option) (*Client, error) {
 		co.initConnections = 1
 	}
 
-	factory := func(ctx context.Context) (*grpc.ClientConn, error) {
-		// Dial using credentials based on scheme
-		if scheme == "grpcs" {
-			return grpc.NewClient(hostPort, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	// Dial using credentials based on scheme
+	creds := insecure.NewCredentials()
+	if scheme == "grpcs" {
+		if co.tlsConfig != nil {
+			creds = credentials.NewTLS(co.tlsConfig)
+		} else {
+			creds = credentials.NewClientTLSFromCert(nil, "")
 		}
-		return grpc.NewClient(hostPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else if co.tlsConfig != nil {
+		return nil, fmt.Errorf("TLS config requires a grpcs:// node address")
+	}
+	dialOpts := append(co.dialOptions(endpoint), grpc.WithTransportCredentials(creds))
+	factory := func(ctx context.Context) (*grpc.ClientConn, error) {
+		return grpc.NewClient(hostPort, dialOpts...)
 	}
 
 	// Use the same timeout for connection pool
@@ -136,6 +271,7 @@ func NewClient(endpoint string, opts ...Option) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	pool.startReaper(co.idleTimeout)
 
 	return &Client{
 		pool:        pool,
@@ -153,7 +289,7 @@ func NewClient(endpoint string, opts ...Option) (*Client, error) {
 // Returns ErrClientClosed if the client has been closed, or ErrConnectionFailed
 // if no connection is available.
 func (c *Client) GetConnection(ctx context.Context) (*grpc.ClientConn, error) {
-	if atomic.LoadInt32(&c.closed) == 1 {
+	if c.isClosed() {
 		return nil, ErrClientClosed
 	}
 
@@ -184,27 +320,67 @@ func (c *Client) GetConnection(ctx context.Context) (*grpc.ClientConn, error) {
 // This method should always be called after GetConnection to return the
 // connection to the pool for reuse. It is safe to call on a closed client.
 func (c *Client) ReturnConnection(conn *grpc.ClientConn) {
-	if atomic.LoadInt32(&c.closed) == 1 {
+	if c.pool == nil {
 		return
 	}
-	if c.pool != nil {
-		c.pool.put(conn)
+	// A closed view still shares a live pool, so only the pool owner's state
+	// decides whether the connection is reused.
+	owner := c
+	if c.owner != nil {
+		owner = c.owner
 	}
+	if owner.isClosed() {
+		// Calls that finish while Shutdown is draining still count down.
+		c.pool.discard(conn)
+		return
+	}
+	c.pool.put(conn)
 }
 
 // Close closes the client and all connections in the pool.
 //
 // This method should be called when the client is no longer needed to free
-// up resources. It is safe to call multiple times.
+// up resources. It is safe to call multiple times. Closing a view returned by
+// WithOptions only marks the view closed; the shared pool stays open.
 func (c *Client) Close() {
 	if !atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
 		return // Already closed
 	}
-	if c.pool != nil {
+	if c.owner == nil && c.pool != nil {
 		c.pool.close()
 	}
 }
 
+// Shutdown gracefully closes the client. New calls fail with ErrClientClosed
+// immediately, while calls already holding a connection are allowed to
+// finish. Once they have, or when ctx is done, all connections are closed.
+//
+// If ctx ends first, the remaining calls are cut off and an error wrapping
+// ctx.Err() is returned. Shutdown on a view returned by WithOptions behaves
+// like Close. It is safe to call Shutdown and Close multiple times.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := cli.Shutdown(ctx); err != nil {
+//	    log.Printf("forced shutdown: %v", err)
+//	}
+func (c *Client) Shutdown(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
+		return nil // Already closed
+	}
+	if c.owner != nil || c.pool == nil {
+		return nil
+	}
+	remaining := c.pool.waitIdle(ctx)
+	c.pool.close()
+	if remaining > 0 {
+		return fmt.Errorf("shutdown interrupted with %d calls in flight: %w", remaining, ctx.Err())
+	}
+	return nil
+}
+
 // GetTimeout returns the client's configured timeout.
 //
 // This timeout is applied to operations when the context doesn't have a deadline.
@@ -224,5 +400,45 @@ func (c *Client) GetNodeAddress() string {
 // Returns true if the client is still open and can be used for operations,
 // false if it has been closed.
 func (c *Client) IsConnected() bool {
-	return atomic.LoadInt32(&c.closed) == 0
+	return !c.isClosed()
+}
+
+// isClosed reports whether the client, or the client owning its pool, has
+// been closed.
+func (c *Client) isClosed() bool {
+	if atomic.LoadInt32(&c.closed) == 1 {
+		return true
+	}
+	return c.owner != nil && c.owner.isClosed()
+}
+
+// WithOptions returns a lightweight view of the client that shares its
+// connection pool but applies the given per-call options.
+//
+// Only per-call settings take effect (currently WithTimeout); options that
+// affect dialing or pool sizing are ignored because the pool is shared.
+// Closing the view does not close the pool, and the view stops working once
+// the original client is closed.
+//
+// Example:
+//
+//	slow := cli.WithOptions(client.WithTimeout(2 * time.Minute))
+//	block, err := slow.Network().GetBlockByNumber(ctx, num)
+func (c *Client) WithOptions(opts ...Option) *Client {
+	co := &clientOptions{timeout: c.timeout}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(co)
+		}
+	}
+	owner := c
+	if c.owner != nil {
+		owner = c.owner
+	}
+	return &Client{
+		pool:        c.pool,
+		timeout:     co.timeout,
+		nodeAddress: c.nodeAddress,
+		owner:       owner,
+	}
 }
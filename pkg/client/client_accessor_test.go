@@ -124,6 +124,20 @@ func TestClient_Voting(t *testing.T) {
 	}
 }
 
+func TestClient_Exchange(t *testing.T) {
+	srv := &testWalletServer{}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	defer cleanupSrv()
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	defer cleanupClient()
+
+	mgr := c.Exchange()
+	if mgr == nil {
+		t.Fatal("expected non-nil ExchangeManager")
+	}
+}
+
 func TestClient_TRC20(t *testing.T) {
 	srv := &testWalletServer{}
 	lis, _, cleanupSrv := newBufconnServer(t, srv)
@@ -1,6 +1,9 @@
 package client
 
-import "testing"
+import (
+	"crypto/tls"
+	"testing"
+)
 
 func TestNewClient_InvalidEndpoint(t *testing.T) {
 	if _, err := NewClient(""); err == nil {
@@ -16,3 +19,15 @@ func TestNewClient_InvalidEndpoint(t *testing.T) {
 		t.Fatalf("expected error for missing host:port")
 	}
 }
+
+func TestNewClient_TLSConfig(t *testing.T) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if _, err := NewClient("grpc://127.0.0.1:50051", WithTLSConfig(cfg)); err == nil {
+		t.Fatalf("expected error for TLS config on grpc:// endpoint")
+	}
+	c, err := NewClient("grpcs://127.0.0.1:50051", WithTLSConfig(cfg))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Close()
+}
@@ -0,0 +1,318 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+)
+
+func TestNewClient_MaxRecvMsgSize(t *testing.T) {
+	// 5MB response, above gRPC's 4MB default receive limit
+	payload := make([]byte, 5*1024*1024)
+	fake := &testWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{
+				Result:         &api.Return{Result: true},
+				ConstantResult: [][]byte{payload},
+			}, nil
+		},
+	}
+	lis, _, stop := newBufconnServer(t, fake)
+	defer stop()
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+
+	call := func(opts ...Option) error {
+		req := &core.TriggerSmartContract{Data: make([]byte, 64)}
+		c, err := NewClientWithDialer("passthrough:///bufnet", dialer, append([]Option{WithTimeout(5 * time.Second)}, opts...)...)
+		if err != nil {
+			t.Fatalf("NewClientWithDialer error: %v", err)
+		}
+		defer c.Close()
+		ctx := context.Background()
+		conn, err := c.GetConnection(ctx)
+		if err != nil {
+			t.Fatalf("GetConnection error: %v", err)
+		}
+		defer c.ReturnConnection(conn)
+		_, err = api.NewWalletClient(conn).TriggerConstantContract(ctx, req)
+		return err
+	}
+
+	if err := call(); err != nil {
+		t.Fatalf("default limit should accept 5MB response: %v", err)
+	}
+	if err := call(WithMaxRecvMsgSize(1024 * 1024)); err == nil {
+		t.Fatalf("expected error with 1MB receive limit")
+	}
+	if err := call(WithMaxSendMsgSize(16)); err == nil {
+		t.Fatalf("expected error with 16 byte send limit")
+	}
+}
+
+func TestNewClient_IdleTimeoutReapsConnections(t *testing.T) {
+	lis, _, stop := newBufconnServer(t, &testWalletServer{})
+	defer stop()
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+
+	c, err := NewClientWithDialer("passthrough:///bufnet", dialer,
+		WithIdleTimeout(30*time.Millisecond),
+		WithKeepalive(time.Minute, 10*time.Second, true))
+	if err != nil {
+		t.Fatalf("NewClientWithDialer error: %v", err)
+	}
+	defer c.Close()
+
+	conn, err := c.GetConnection(context.Background())
+	if err != nil {
+		t.Fatalf("GetConnection error: %v", err)
+	}
+	c.ReturnConnection(conn)
+	if got := len(c.pool.conns); got != 1 {
+		t.Fatalf("expected 1 pooled connection, got %d", got)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(c.pool.conns) > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := len(c.pool.conns); got != 0 {
+		t.Fatalf("expected idle connection to be reaped, %d remain", got)
+	}
+	if _, err := api.NewWalletClient(conn).GetNowBlock2(context.Background(), &api.EmptyMessage{}); err == nil {
+		t.Fatalf("expected reaped connection to be closed")
+	}
+}
+
+func TestClient_WithOptionsSharesPool(t *testing.T) {
+	lis, _, stop := newBufconnServer(t, &testWalletServer{})
+	defer stop()
+	c, cleanup := newTestClientWithBufConn(t, lis, time.Second)
+	defer cleanup()
+
+	view := c.WithOptions(WithTimeout(time.Minute), WithPool(3, 7))
+	if view.GetTimeout() != time.Minute {
+		t.Fatalf("expected view timeout 1m, got %v", view.GetTimeout())
+	}
+	if c.GetTimeout() != time.Second {
+		t.Fatalf("original timeout changed: %v", c.GetTimeout())
+	}
+	if view.pool != c.pool {
+		t.Fatalf("expected view to share the pool")
+	}
+
+	// Closing the view leaves the original usable
+	view.Close()
+	if view.IsConnected() {
+		t.Fatalf("expected closed view")
+	}
+	if _, err := view.GetConnection(context.Background()); err != ErrClientClosed {
+		t.Fatalf("expected ErrClientClosed from closed view, got %v", err)
+	}
+	conn, err := c.GetConnection(context.Background())
+	if err != nil {
+		t.Fatalf("original client unusable after view close: %v", err)
+	}
+	c.ReturnConnection(conn)
+
+	// Closing the original invalidates remaining views
+	other := c.WithOptions()
+	c.Close()
+	if other.IsConnected() {
+		t.Fatalf("expected view to report closed after owner close")
+	}
+	if _, err := other.GetConnection(context.Background()); err != ErrClientClosed {
+		t.Fatalf("expected ErrClientClosed after owner close, got %v", err)
+	}
+}
+
+func TestClient_ClosedViewReturnsConnectionsToPool(t *testing.T) {
+	lis, _, stop := newBufconnServer(t, &testWalletServer{})
+	defer stop()
+	c, cleanup := newTestClientWithBufConn(t, lis, time.Second)
+	defer cleanup()
+
+	view := c.WithOptions()
+	conn, err := view.GetConnection(context.Background())
+	if err != nil {
+		t.Fatalf("GetConnection: %v", err)
+	}
+	// Any reply, even Unimplemented, leaves the connection ready.
+	_, _ = api.NewWalletClient(conn).GetNowBlock2(context.Background(), &api.EmptyMessage{})
+	view.Close()
+	view.ReturnConnection(conn)
+
+	if conn.GetState() == connectivity.Shutdown {
+		t.Fatalf("closed view closed a connection of the shared pool")
+	}
+	// The parent keeps working and reuses the returned connection.
+	for i := 0; i < 3; i++ {
+		pooled, err := c.GetConnection(context.Background())
+		if err != nil {
+			t.Fatalf("parent GetConnection: %v", err)
+		}
+		if pooled != conn {
+			t.Fatalf("expected the parent to reuse the returned connection")
+		}
+		if _, err := api.NewWalletClient(pooled).GetNowBlock2(context.Background(), &api.EmptyMessage{}); status.Code(err) != codes.Unimplemented {
+			t.Fatalf("parent call failed: %v", err)
+		}
+		c.ReturnConnection(pooled)
+	}
+}
+
+func TestClient_ShutdownWaitsForInFlightCalls(t *testing.T) {
+	lis, _, stop := newBufconnServer(t, &testWalletServer{})
+	defer stop()
+	c, cleanup := newTestClientWithBufConn(t, lis, time.Second)
+	defer cleanup()
+
+	conn, err := c.GetConnection(context.Background())
+	if err != nil {
+		t.Fatalf("GetConnection: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.Shutdown(context.Background()) }()
+
+	// New checkouts are refused while draining
+	deadline := time.Now().Add(time.Second)
+	for c.IsConnected() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := c.GetConnection(context.Background()); err != ErrClientClosed {
+		t.Fatalf("expected ErrClientClosed while draining, got %v", err)
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned before in-flight call finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	c.ReturnConnection(conn)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Shutdown: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown did not return after in-flight call finished")
+	}
+}
+
+func TestClient_ShutdownHonorsContext(t *testing.T) {
+	lis, _, stop := newBufconnServer(t, &testWalletServer{})
+	defer stop()
+	c, cleanup := newTestClientWithBufConn(t, lis, time.Second)
+	defer cleanup()
+
+	conn, err := c.GetConnection(context.Background())
+	if err != nil {
+		t.Fatalf("GetConnection: %v", err)
+	}
+	defer c.ReturnConnection(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("second Shutdown: %v", err)
+	}
+}
+
+func TestClient_ShutdownTimeoutWithRPCInFlight(t *testing.T) {
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	lis, _, stop := newBufconnServer(t, &testWalletServer{
+		GetNodeInfoHandler: func(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+			started <- struct{}{}
+			<-release
+			return &core.NodeInfo{}, nil
+		},
+	})
+	defer stop()
+	c, cleanup := newTestClientWithBufConn(t, lis, 5*time.Second)
+	defer cleanup()
+
+	// One call through the client and one through a view, which returns its
+	// connection to the shared pool rather than discarding it.
+	calls := make(chan error, 2)
+	for _, cli := range []*Client{c, c.WithOptions()} {
+		go func(cli *Client) {
+			_, err := cli.Network().GetNodeInfo(context.Background())
+			calls <- err
+		}(cli)
+	}
+	<-started
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+
+	// The calls finish after the pool is closed and must not panic.
+	close(release)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatal("in-flight call did not finish")
+		}
+	}
+	if _, err := c.pool.get(context.Background()); err != ErrClientClosed {
+		t.Fatalf("expected ErrClientClosed from closed pool, got %v", err)
+	}
+}
+
+func TestNewClient_WithMetrics(t *testing.T) {
+	fake := &testWalletServer{
+		GetNodeInfoHandler: func(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+			return &core.NodeInfo{}, nil
+		},
+	}
+	lis, _, stop := newBufconnServer(t, fake)
+	defer stop()
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+
+	var samples []RPCInfo
+	c, err := NewClientWithDialer("passthrough:///bufnet", dialer, WithTimeout(time.Second), WithMetrics(func(info RPCInfo) {
+		if info.Latency <= 0 {
+			t.Errorf("expected positive latency for %s", info.Method)
+		}
+		samples = append(samples, info)
+	}))
+	if err != nil {
+		t.Fatalf("NewClientWithDialer error: %v", err)
+	}
+	defer c.Close()
+
+	if _, err := c.Network().GetNodeInfo(context.Background()); err != nil {
+		t.Fatalf("GetNodeInfo error: %v", err)
+	}
+	if _, err := c.Network().ListNodes(context.Background()); err == nil {
+		t.Fatalf("expected ListNodes to be unimplemented")
+	}
+
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(samples))
+	}
+	if samples[0].Method != "/protocol.Wallet/GetNodeInfo" || samples[0].Err != nil || samples[0].Endpoint != "passthrough:///bufnet" {
+		t.Errorf("unexpected first sample %+v", samples[0])
+	}
+	if samples[1].Method != "/protocol.Wallet/ListNodes" || samples[1].Err == nil {
+		t.Errorf("unexpected second sample %+v", samples[1])
+	}
+}
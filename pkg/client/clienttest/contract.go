@@ -0,0 +1,99 @@
+//go:build !release
+
+package clienttest
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	eabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+)
+
+// Selector returns the 4-byte method selector for a Solidity signature such
+// as "transfer(address,uint256)".
+func Selector(sig string) []byte {
+	return crypto.Keccak256([]byte(sig))[:4]
+}
+
+// Pack ABI-encodes vs as values of the Solidity types typs, failing t on
+// error. Use it to build TriggerConstantContract results and event data.
+//
+// Example:
+//
+//	out := clienttest.Pack(t, []string{"uint256"}, big.NewInt(5))
+func Pack(t testing.TB, typs []string, vs ...interface{}) []byte {
+	t.Helper()
+	args := make(eabi.Arguments, len(typs))
+	for i, typ := range typs {
+		ty, err := eabi.NewType(typ, "", nil)
+		if err != nil {
+			t.Fatalf("clienttest: invalid ABI type %q: %v", typ, err)
+		}
+		args[i] = eabi.Argument{Type: ty}
+	}
+	out, err := args.Pack(vs...)
+	if err != nil {
+		t.Fatalf("clienttest: failed to pack %v: %v", typs, err)
+	}
+	return out
+}
+
+// TriggerRecorder is an api.WalletServer whose TriggerContract records the
+// call data and returns a successful, unsigned transaction. Embed it instead
+// of api.UnimplementedWalletServer to test contract write methods, and
+// override the other RPCs the test needs.
+type TriggerRecorder struct {
+	api.UnimplementedWalletServer
+
+	mu   sync.Mutex
+	data []byte
+}
+
+func (r *TriggerRecorder) TriggerContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	r.mu.Lock()
+	r.data = in.GetData()
+	r.mu.Unlock()
+	return &api.TransactionExtention{
+		Result:      &api.Return{Result: true},
+		Transaction: &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1}},
+		Txid:        make([]byte, 32),
+	}, nil
+}
+
+// LastData returns the call data of the most recent TriggerContract, or nil
+// if there was none.
+func (r *TriggerRecorder) LastData() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.data
+}
+
+// WriteCase is a table entry for RunWriteCases: Call builds a transaction
+// through the code under test, which must invoke the method Sig.
+type WriteCase struct {
+	Name string
+	Call func() (*api.TransactionExtention, error)
+	Sig  string
+}
+
+// RunWriteCases runs each case as a subtest and checks that it succeeded and
+// that the last call recorded by rec used the selector of the case's Sig.
+func RunWriteCases(t *testing.T, rec *TriggerRecorder, cases []WriteCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := tc.Call(); err != nil {
+				t.Fatalf("%s: %v", tc.Name, err)
+			}
+			if data := rec.LastData(); len(data) < 4 || !bytes.Equal(data[:4], Selector(tc.Sig)) {
+				t.Fatalf("called selector %x, want %s", data, tc.Sig)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+//go:build !release
+
+// Package clienttest provides an in-memory TRON node for testing code built
+// on *client.Client and the managers, without a live node or testnet.
+//
+// MockClient runs a gRPC Wallet service over an in-memory listener. Set the
+// XxxFunc fields to program responses; RPCs without a handler fail with
+// codes.Unimplemented. The Client field is a real *client.Client connected to
+// the mock, so managers (which accept any lowlevel.ConnProvider) and helpers
+// such as SignAndBroadcast run unmodified.
+//
+// Like client.NewClientWithDialer, which it is built on, the package is
+// excluded from builds with the release tag.
+//
+// Example:
+//
+//	mock := clienttest.NewMockClient(t)
+//	mock.GetAccountFunc = func(ctx context.Context, in *core.Account) (*core.Account, error) {
+//	    return &core.Account{Address: in.Address, Balance: 5_000_000}, nil
+//	}
+//	balance, err := mock.Client.Account().GetBalance(ctx, addr)
+package clienttest
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client"
+)
+
+// bufSize is the in-memory listener buffer size.
+const bufSize = 1024 * 1024
+
+// DefaultTimeout is the Client timeout used by NewMockClient.
+const DefaultTimeout = 5 * time.Second
+
+// MockClient is a programmable in-memory Wallet service with a connected
+// *client.Client. Handlers are read on every call, so they may be replaced
+// between calls but not concurrently with them.
+type MockClient struct {
+	api.UnimplementedWalletServer
+
+	// Client is connected to the mock and closed by the test cleanup.
+	Client *client.Client
+
+	GetAccountFunc                   func(ctx context.Context, in *core.Account) (*core.Account, error)
+	GetAccountResourceFunc           func(ctx context.Context, in *core.Account) (*api.AccountResourceMessage, error)
+	CreateTransaction2Func           func(ctx context.Context, in *core.TransferContract) (*api.TransactionExtention, error)
+	TriggerContractFunc              func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error)
+	TriggerConstantContractFunc      func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error)
+	EstimateEnergyFunc               func(ctx context.Context, in *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error)
+	GetContractFunc                  func(ctx context.Context, in *api.BytesMessage) (*core.SmartContract, error)
+	BroadcastTransactionFunc         func(ctx context.Context, in *core.Transaction) (*api.Return, error)
+	GetTransactionByIdFunc           func(ctx context.Context, in *api.BytesMessage) (*core.Transaction, error)
+	GetTransactionInfoByIdFunc       func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error)
+	GetTransactionInfoByBlockNumFunc func(ctx context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error)
+	GetNowBlock2Func                 func(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error)
+	GetBlockByNum2Func               func(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error)
+	GetChainParametersFunc           func(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error)
+	GetNodeInfoFunc                  func(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error)
+}
+
+// NewMockClient starts a MockClient and registers its shutdown with
+// t.Cleanup. The connected Client uses DefaultTimeout; client options given
+// in opts are applied after it.
+func NewMockClient(t testing.TB, opts ...client.Option) *MockClient {
+	t.Helper()
+
+	m := &MockClient{}
+	m.Client = NewClient(t, m, opts...)
+	return m
+}
+
+// NewClient serves srv over an in-memory listener and returns a
+// *client.Client connected to it. Use it instead of MockClient when a test
+// needs RPCs MockClient does not cover or keeps state across calls in its own
+// api.WalletServer. The client and server are shut down by t.Cleanup. The
+// client uses DefaultTimeout and a pool of one to two connections; client
+// options given in opts are applied after them.
+func NewClient(t testing.TB, srv api.WalletServer, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	lis := bufconn.Listen(bufSize)
+	gs := grpc.NewServer()
+	api.RegisterWalletServer(gs, srv)
+	go func() {
+		_ = gs.Serve(lis)
+	}()
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}
+	opts = append([]client.Option{client.WithTimeout(DefaultTimeout), client.WithPool(1, 2)}, opts...)
+	c, err := client.NewClientWithDialer("passthrough:///clienttest", dialer, opts...)
+	if err != nil {
+		gs.Stop()
+		t.Fatalf("clienttest: failed to create client: %v", err)
+	}
+
+	t.Cleanup(func() {
+		c.Close()
+		gs.Stop()
+		_ = lis.Close()
+	})
+	return c
+}
+
+func (m *MockClient) GetAccount(ctx context.Context, in *core.Account) (*core.Account, error) {
+	if m.GetAccountFunc != nil {
+		return m.GetAccountFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetAccount(ctx, in)
+}
+
+func (m *MockClient) GetAccountResource(ctx context.Context, in *core.Account) (*api.AccountResourceMessage, error) {
+	if m.GetAccountResourceFunc != nil {
+		return m.GetAccountResourceFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetAccountResource(ctx, in)
+}
+
+func (m *MockClient) CreateTransaction2(ctx context.Context, in *core.TransferContract) (*api.TransactionExtention, error) {
+	if m.CreateTransaction2Func != nil {
+		return m.CreateTransaction2Func(ctx, in)
+	}
+	return m.UnimplementedWalletServer.CreateTransaction2(ctx, in)
+}
+
+func (m *MockClient) TriggerContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	if m.TriggerContractFunc != nil {
+		return m.TriggerContractFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.TriggerContract(ctx, in)
+}
+
+func (m *MockClient) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	if m.TriggerConstantContractFunc != nil {
+		return m.TriggerConstantContractFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.TriggerConstantContract(ctx, in)
+}
+
+func (m *MockClient) EstimateEnergy(ctx context.Context, in *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+	if m.EstimateEnergyFunc != nil {
+		return m.EstimateEnergyFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.EstimateEnergy(ctx, in)
+}
+
+func (m *MockClient) GetContract(ctx context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
+	if m.GetContractFunc != nil {
+		return m.GetContractFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetContract(ctx, in)
+}
+
+func (m *MockClient) BroadcastTransaction(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+	if m.BroadcastTransactionFunc != nil {
+		return m.BroadcastTransactionFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.BroadcastTransaction(ctx, in)
+}
+
+func (m *MockClient) GetTransactionById(ctx context.Context, in *api.BytesMessage) (*core.Transaction, error) {
+	if m.GetTransactionByIdFunc != nil {
+		return m.GetTransactionByIdFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetTransactionById(ctx, in)
+}
+
+func (m *MockClient) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+	if m.GetTransactionInfoByIdFunc != nil {
+		return m.GetTransactionInfoByIdFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetTransactionInfoById(ctx, in)
+}
+
+func (m *MockClient) GetTransactionInfoByBlockNum(ctx context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error) {
+	if m.GetTransactionInfoByBlockNumFunc != nil {
+		return m.GetTransactionInfoByBlockNumFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetTransactionInfoByBlockNum(ctx, in)
+}
+
+func (m *MockClient) GetNowBlock2(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+	if m.GetNowBlock2Func != nil {
+		return m.GetNowBlock2Func(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetNowBlock2(ctx, in)
+}
+
+func (m *MockClient) GetBlockByNum2(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+	if m.GetBlockByNum2Func != nil {
+		return m.GetBlockByNum2Func(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetBlockByNum2(ctx, in)
+}
+
+func (m *MockClient) GetChainParameters(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+	if m.GetChainParametersFunc != nil {
+		return m.GetChainParametersFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetChainParameters(ctx, in)
+}
+
+func (m *MockClient) GetNodeInfo(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+	if m.GetNodeInfoFunc != nil {
+		return m.GetNodeInfoFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetNodeInfo(ctx, in)
+}
@@ -0,0 +1,97 @@
+//go:build !release
+
+package clienttest_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/account"
+	"github.com/kslamph/tronlib/pkg/client"
+	"github.com/kslamph/tronlib/pkg/client/clienttest"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+func TestMockClient(t *testing.T) {
+	mock := clienttest.NewMockClient(t)
+	addr, _ := types.NewAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	to, _ := types.NewAddress("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	ctx := context.Background()
+
+	mock.GetAccountFunc = func(ctx context.Context, in *core.Account) (*core.Account, error) {
+		return &core.Account{Address: in.Address, Balance: 5_000_000}, nil
+	}
+	balance, err := account.NewManager(mock.Client).GetBalance(ctx, addr)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance != 5_000_000 {
+		t.Fatalf("unexpected balance %d", balance)
+	}
+
+	// Unprogrammed RPCs report Unimplemented.
+	_, err = mock.Client.Account().GetAccountResource(ctx, addr)
+	if err == nil {
+		t.Fatalf("expected error for unprogrammed RPC")
+	}
+
+	mock.CreateTransaction2Func = func(ctx context.Context, in *core.TransferContract) (*api.TransactionExtention, error) {
+		tx := &core.Transaction{RawData: &core.TransactionRaw{
+			Expiration: time.Now().Add(time.Minute).UnixMilli(),
+			Contract:   []*core.Transaction_Contract{{Type: core.Transaction_Contract_TransferContract}},
+		}}
+		return &api.TransactionExtention{Transaction: tx, Result: &api.Return{Result: true}}, nil
+	}
+	var broadcasts int
+	mock.BroadcastTransactionFunc = func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+		broadcasts++
+		return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+	}
+	txExt, err := mock.Client.Account().TransferTRX(ctx, addr, to, 1)
+	if err != nil {
+		t.Fatalf("TransferTRX: %v", err)
+	}
+	res, err := mock.Client.SignAndBroadcast(ctx, txExt, client.BroadcastOptions{})
+	if err != nil || !res.Success {
+		t.Fatalf("SignAndBroadcast: %v %+v", err, res)
+	}
+	if broadcasts != 1 {
+		t.Fatalf("expected 1 broadcast, got %d", broadcasts)
+	}
+
+	mock.GetAccountFunc = func(ctx context.Context, in *core.Account) (*core.Account, error) {
+		return nil, status.Error(codes.Unavailable, "node down")
+	}
+	if _, err := mock.Client.Account().GetAccount(ctx, addr); err == nil {
+		t.Fatalf("expected programmed error")
+	}
+}
+
+func TestTriggerRecorder(t *testing.T) {
+	rec := &clienttest.TriggerRecorder{}
+	c := clienttest.NewClient(t, rec)
+	owner, _ := types.NewAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	token, _ := types.NewAddress("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	instance, err := c.ContractInstance(token, `[{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"type":"bool"}]}]`)
+	if err != nil {
+		t.Fatalf("ContractInstance: %v", err)
+	}
+
+	clienttest.RunWriteCases(t, rec, []clienttest.WriteCase{
+		{Name: "Approve", Call: func() (*api.TransactionExtention, error) {
+			return instance.Invoke(context.Background(), owner, 0, "approve", owner, big.NewInt(1))
+		}, Sig: "approve(address,uint256)"},
+	})
+	want := clienttest.Pack(t, []string{"uint256"}, big.NewInt(1))
+	if data := rec.LastData(); !bytes.HasSuffix(data, want) {
+		t.Fatalf("unexpected call data %x", data)
+	}
+}
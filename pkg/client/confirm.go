@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/network"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// ErrTransactionReverted is returned by WaitForConfirmations when the
+// transaction was included in a block but failed to execute.
+var ErrTransactionReverted = types.NewTronError(1005, "transaction reverted", nil)
+
+// confirmationBlockTime is the block interval used to size the default
+// WaitForConfirmations timeout.
+const confirmationBlockTime = 3 * time.Second
+
+// WaitForConfirmations waits until the transaction's block is at least
+// confirmations blocks below the node's solidified block height and returns
+// the receipt. With confirmations 0 it returns as soon as the block is
+// solidified (irreversible).
+//
+// The solidified height is read from the connected node's NodeInfo. Once
+// the block is deep enough the receipt is fetched again from the node's
+// solidity service and returned only if it is in the same block; a
+// transaction that was reorged out is looked up again, so polling continues
+// until it is confirmed in its final block. While the transaction is not yet
+// indexed polling continues. If it executed but failed, the receipt is
+// returned together with an error wrapping ErrTransactionReverted.
+//
+// opts is interpreted as for WaitForReceipt, except that a zero Timeout
+// defaults to the receipt timeout plus three seconds per confirmation. On
+// timeout ErrReceiptTimeout is returned, with the receipt if it was found.
+//
+// Example:
+//
+//	receipt, err := cli.WaitForConfirmations(ctx, txid, 20, client.WaitOptions{})
+//	if errors.Is(err, client.ErrTransactionReverted) {
+//	    // do not credit
+//	}
+func (c *Client) WaitForConfirmations(ctx context.Context, txid string, confirmations int64, opts WaitOptions) (*Receipt, error) {
+	id, err := hex.DecodeString(strings.TrimPrefix(txid, "0x"))
+	if err != nil || len(id) != 32 {
+		return nil, fmt.Errorf("%w: invalid transaction id %q", types.ErrInvalidParameter, txid)
+	}
+	if confirmations < 0 {
+		return nil, fmt.Errorf("%w: confirmations must be non-negative", types.ErrInvalidParameter)
+	}
+
+	def := DefaultWaitOptions()
+	if opts.Timeout <= 0 {
+		opts.Timeout = def.Timeout + time.Duration(confirmations)*confirmationBlockTime
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = def.PollInterval
+	}
+	if opts.MaxPollInterval <= 0 {
+		opts.MaxPollInterval = def.MaxPollInterval
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	var receipt *Receipt
+	interval := opts.PollInterval
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return receipt, ctx.Err()
+			}
+			return receipt, fmt.Errorf("%w: %s not confirmed %d blocks deep after %v", ErrReceiptTimeout, txid, confirmations, opts.Timeout)
+		case <-timer.C:
+		}
+
+		if receipt == nil {
+			if info, err := c.transactionInfo(waitCtx, txid, false); err == nil {
+				receipt = NewReceipt(info)
+				if !receipt.Success {
+					return receipt, fmt.Errorf("%w: %s: %s", ErrTransactionReverted, txid, receipt.RevertReason)
+				}
+			}
+		}
+
+		if receipt != nil {
+			info, err := lowlevel.GetNodeInfo(c, waitCtx, &api.EmptyMessage{})
+			if err == nil {
+				if st, err := network.NewNodeStatus(info); err == nil && st.SolidityBlockHeight-receipt.BlockNumber >= confirmations {
+					// Deep enough: confirm against the solidified chain, which
+					// no longer holds the transaction if it was reorged out.
+					solid, err := c.transactionInfo(waitCtx, txid, true)
+					switch {
+					case err == nil && solid.GetBlockNumber() == receipt.BlockNumber:
+						return NewReceipt(solid), nil
+					case err == nil || errors.Is(err, types.ErrNotFound):
+						// Reorged out or into another block; look it up again.
+						receipt = nil
+					}
+				}
+			}
+		}
+
+		timer.Reset(interval)
+		interval *= 2
+		if interval > opts.MaxPollInterval {
+			interval = opts.MaxPollInterval
+		}
+	}
+}
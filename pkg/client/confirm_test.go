@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+)
+
+// solidityInfoServer serves GetTransactionInfoById on the solidity service.
+type solidityInfoServer struct {
+	api.UnimplementedWalletSolidityServer
+	handler func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error)
+}
+
+func (s *solidityInfoServer) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+	return s.handler(ctx, in)
+}
+
+// newConfirmServer serves wallet and, on the same listener, a solidity
+// service answering transaction info lookups with solid.
+func newConfirmServer(t *testing.T, wallet *testWalletServer, solid func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error)) (*bufconn.Listener, func()) {
+	t.Helper()
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	api.RegisterWalletServer(srv, wallet)
+	api.RegisterWalletSolidityServer(srv, &solidityInfoServer{handler: solid})
+	go func() { _ = srv.Serve(lis) }()
+	return lis, func() {
+		_ = lis.Close()
+		srv.Stop()
+	}
+}
+
+func TestWaitForConfirmations(t *testing.T) {
+	var solidity int64 = 95
+	var infoCalls int32
+	fake := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			if atomic.AddInt32(&infoCalls, 1) < 2 {
+				return &core.TransactionInfo{}, nil
+			}
+			return &core.TransactionInfo{Id: in.GetValue(), BlockNumber: 100, Result: core.TransactionInfo_SUCESS}, nil
+		},
+		GetNodeInfoHandler: func(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+			h := atomic.AddInt64(&solidity, 2)
+			return &core.NodeInfo{Block: fmt.Sprintf("Num:%d,ID:aa", h+19), SolidityBlock: fmt.Sprintf("Num:%d,ID:bb", h)}, nil
+		},
+	}
+	lis, cleanup := newConfirmServer(t, fake, func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+		return &core.TransactionInfo{Id: in.GetValue(), BlockNumber: 100, Result: core.TransactionInfo_SUCESS}, nil
+	})
+	defer cleanup()
+	c, closeClient := newTestClientWithBufConn(t, lis, time.Second)
+	defer closeClient()
+
+	receipt, err := c.WaitForConfirmations(context.Background(), testTxID, 5, WaitOptions{
+		Timeout:         2 * time.Second,
+		PollInterval:    5 * time.Millisecond,
+		MaxPollInterval: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if receipt.BlockNumber != 100 || !receipt.Success {
+		t.Fatalf("unexpected receipt: %+v", receipt)
+	}
+	if h := atomic.LoadInt64(&solidity); h < 105 {
+		t.Fatalf("returned before 5 confirmations, solidity height %d", h)
+	}
+
+	// Not deep enough before the timeout
+	atomic.StoreInt64(&solidity, 0)
+	receipt, err = c.WaitForConfirmations(context.Background(), testTxID, 1000, WaitOptions{
+		Timeout:      50 * time.Millisecond,
+		PollInterval: 5 * time.Millisecond,
+	})
+	if !errors.Is(err, ErrReceiptTimeout) || receipt == nil {
+		t.Fatalf("expected ErrReceiptTimeout with receipt, got %v, %v", receipt, err)
+	}
+}
+
+func TestWaitForConfirmations_Reorg(t *testing.T) {
+	// The full node first reports the transaction in block 100, which is
+	// then reorged away; it lands in block 102 instead.
+	var reorged int32
+	fake := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			block := int64(100)
+			if atomic.LoadInt32(&reorged) == 1 {
+				block = 102
+			}
+			return &core.TransactionInfo{Id: in.GetValue(), BlockNumber: block, Result: core.TransactionInfo_SUCESS}, nil
+		},
+		GetNodeInfoHandler: func(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+			return &core.NodeInfo{Block: "Num:130,ID:aa", SolidityBlock: "Num:110,ID:bb"}, nil
+		},
+	}
+	var solidCalls int32
+	lis, cleanup := newConfirmServer(t, fake, func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+		if atomic.AddInt32(&solidCalls, 1) == 1 {
+			// Not on the solidified chain: the block it was in is gone.
+			atomic.StoreInt32(&reorged, 1)
+			return &core.TransactionInfo{}, nil
+		}
+		return &core.TransactionInfo{Id: in.GetValue(), BlockNumber: 102, Result: core.TransactionInfo_SUCESS}, nil
+	})
+	defer cleanup()
+	c, closeClient := newTestClientWithBufConn(t, lis, time.Second)
+	defer closeClient()
+
+	receipt, err := c.WaitForConfirmations(context.Background(), testTxID, 5, WaitOptions{
+		Timeout:         2 * time.Second,
+		PollInterval:    5 * time.Millisecond,
+		MaxPollInterval: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if receipt.BlockNumber != 102 {
+		t.Fatalf("expected the receipt from the block after the reorg, got block %d", receipt.BlockNumber)
+	}
+	if n := atomic.LoadInt32(&solidCalls); n != 2 {
+		t.Fatalf("expected 2 solidity lookups, got %d", n)
+	}
+}
+
+func TestWaitForConfirmations_Reverted(t *testing.T) {
+	fake := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			return &core.TransactionInfo{
+				Id:             in.GetValue(),
+				BlockNumber:    100,
+				Result:         core.TransactionInfo_FAILED,
+				ContractResult: [][]byte{revertData(t, "paused")},
+			}, nil
+		},
+	}
+	lis, _, cleanup := newBufconnServer(t, fake)
+	defer cleanup()
+	c, closeClient := newTestClientWithBufConn(t, lis, time.Second)
+	defer closeClient()
+
+	receipt, err := c.WaitForConfirmations(context.Background(), testTxID, 19, WaitOptions{Timeout: time.Second})
+	if !errors.Is(err, ErrTransactionReverted) {
+		t.Fatalf("expected ErrTransactionReverted, got %v", err)
+	}
+	if receipt == nil || receipt.RevertReason != "paused" {
+		t.Fatalf("expected reverted receipt, got %+v", receipt)
+	}
+
+	if _, err := c.WaitForConfirmations(context.Background(), testTxID, -1, WaitOptions{}); err == nil {
+		t.Fatal("expected error for negative confirmations")
+	}
+}
@@ -5,19 +5,38 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 )
 
+// pooledConn is an idle connection held by the pool together with the time it
+// was returned.
+type pooledConn struct {
+	conn      *grpc.ClientConn
+	idleSince time.Time
+}
+
 // connPool manages a pool of gRPC client connections.
 // For testing purposes, GetFunc can be overridden to mock connection behavior.
 type connPool struct {
 	mu          sync.Mutex
-	conns       chan *grpc.ClientConn
+	conns       chan pooledConn
 	factory     func(ctx context.Context) (*grpc.ClientConn, error)
 	initialSize int
 
+	// idleTimeout, when positive, is how long a connection may sit unused in
+	// the pool before it is closed by the reaper.
+	idleTimeout time.Duration
+	done        chan struct{}
+
+	// active counts connections checked out by get and not yet returned;
+	// drained is closed when active drops to zero while waitIdle is waiting.
+	activeMu sync.Mutex
+	active   int
+	drained  chan struct{}
+
 	// For testing only: A function to override the Get method's behavior.
 	getFunc func(ctx context.Context) (*grpc.ClientConn, error)
 }
@@ -29,9 +48,10 @@ func newConnPool(factory func(ctx context.Context) (*grpc.ClientConn, error), in
 	}
 
 	p := &connPool{
-		conns:       make(chan *grpc.ClientConn, capacity),
+		conns:       make(chan pooledConn, capacity),
 		factory:     factory,
 		initialSize: initialSize,
+		done:        make(chan struct{}),
 	}
 
 	// Don't create initial connections - let them be created on demand
@@ -43,70 +63,212 @@ func newConnPool(factory func(ctx context.Context) (*grpc.ClientConn, error), in
 // get retrieves a connection from the pool. If no connection is available,
 // it will try to create a new one if the pool has not reached its capacity.
 func (p *connPool) get(ctx context.Context) (*grpc.ClientConn, error) {
+	conn, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.activeMu.Lock()
+	p.active++
+	p.activeMu.Unlock()
+	return conn, nil
+}
+
+// acquire takes a pooled connection or dials a new one.
+func (p *connPool) acquire(ctx context.Context) (*grpc.ClientConn, error) {
 	// If a mock GetFunc is provided, use it
 	if p.getFunc != nil {
 		return p.getFunc(ctx)
 	}
 
 	select {
-	case conn := <-p.conns:
-		// Check connection health before returning
-		if conn.GetState() != connectivity.Ready {
-			// Connection is not ready, close it and create a new one
-			_ = conn.Close()
-			conn, err := p.factory(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("connection failed: %v", err)
-			}
-			return conn, nil
+	case <-p.done:
+		return nil, ErrClientClosed
+	default:
+	}
+
+	select {
+	case pc, ok := <-p.conns:
+		if !ok {
+			return nil, ErrClientClosed
 		}
-		return conn, nil
+		return p.checkout(ctx, pc)
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
-		p.mu.Lock()
+	}
+
+	p.mu.Lock()
+	if len(p.conns) < cap(p.conns) {
 		defer p.mu.Unlock()
+		conn, err := p.factory(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("connection failed: %v", err)
+		}
+		return conn, nil
+	}
+	// Wait for a connection to be returned to the pool. p.mu is released
+	// first, since put needs it to hand the connection back.
+	p.mu.Unlock()
+	select {
+	case pc, ok := <-p.conns:
+		if !ok {
+			return nil, ErrClientClosed
+		}
+		return p.checkout(ctx, pc)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
 
-		if len(p.conns) < cap(p.conns) {
-			conn, err := p.factory(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("connection failed: %v", err)
+// checkout validates a connection taken from the pool. Connections that are
+// not ready or have been idle longer than idleTimeout are closed and replaced
+// with a freshly dialed one.
+func (p *connPool) checkout(ctx context.Context, pc pooledConn) (*grpc.ClientConn, error) {
+	if pc.conn.GetState() == connectivity.Ready && !p.expired(pc, time.Now()) {
+		return pc.conn, nil
+	}
+	// Connection is not usable, close it and create a new one
+	_ = pc.conn.Close()
+	conn, err := p.factory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connection failed: %v", err)
+	}
+	return conn, nil
+}
+
+// expired reports whether pc has been idle longer than the pool's idleTimeout.
+func (p *connPool) expired(pc pooledConn, now time.Time) bool {
+	return p.idleTimeout > 0 && now.Sub(pc.idleSince) > p.idleTimeout
+}
+
+// startReaper starts a background goroutine that periodically closes
+// connections idle for longer than idleTimeout. It stops when the pool is
+// closed.
+func (p *connPool) startReaper(idleTimeout time.Duration) {
+	if idleTimeout <= 0 {
+		return
+	}
+	p.idleTimeout = idleTimeout
+	interval := idleTimeout / 2
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.reap(time.Now())
+			case <-p.done:
+				return
 			}
-			return conn, nil
 		}
-		// Wait for a connection to be returned to the pool
+	}()
+}
+
+// reap closes idle connections that have expired and keeps the rest pooled.
+func (p *connPool) reap(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return
+	default:
+	}
+
+	for n := len(p.conns); n > 0; n-- {
+		var pc pooledConn
 		select {
-		case conn := <-p.conns:
-			// Check connection health before returning
-			if conn.GetState() != connectivity.Ready {
-				// Connection is not ready, close it and create a new one
-				_ = conn.Close()
-				conn, err := p.factory(ctx)
-				if err != nil {
-					return nil, fmt.Errorf("connection failed: %v", err)
-				}
-				return conn, nil
-			}
-			return conn, nil
-		case <-ctx.Done():
-			return nil, ctx.Err()
+		case pc = <-p.conns:
+		default:
+			return
+		}
+		if p.expired(pc, now) {
+			_ = pc.conn.Close()
+			continue
 		}
+		select {
+		case p.conns <- pc:
+		default:
+			_ = pc.conn.Close()
+		}
+	}
+}
+
+// release marks a connection obtained from get as no longer in use.
+func (p *connPool) release() {
+	p.activeMu.Lock()
+	defer p.activeMu.Unlock()
+	if p.active > 0 {
+		p.active--
+	}
+	if p.active == 0 && p.drained != nil {
+		close(p.drained)
+		p.drained = nil
+	}
+}
+
+// waitIdle blocks until every connection handed out by get has been
+// returned, or ctx is done. It returns the number of connections still in use.
+func (p *connPool) waitIdle(ctx context.Context) int {
+	p.activeMu.Lock()
+	if p.active == 0 {
+		p.activeMu.Unlock()
+		return 0
+	}
+	if p.drained == nil {
+		p.drained = make(chan struct{})
+	}
+	drained := p.drained
+	p.activeMu.Unlock()
+
+	select {
+	case <-drained:
+		return 0
+	case <-ctx.Done():
+		p.activeMu.Lock()
+		defer p.activeMu.Unlock()
+		return p.active
+	}
+}
+
+// discard releases a connection without returning it to the pool.
+func (p *connPool) discard(conn *grpc.ClientConn) {
+	if conn == nil {
+		return
 	}
+	p.release()
+	_ = conn.Close()
 }
 
-// put returns a connection to the pool.
+// put returns a connection to the pool. Once the pool is closed, for
+// example by a Shutdown whose drain timed out while calls were in flight, the
+// connection is closed instead.
 func (p *connPool) put(conn *grpc.ClientConn) {
 	if conn == nil {
 		return
 	}
+	p.release()
 	if p.conns == nil {
 		// No backing channel (tests override get); close to avoid leaks.
 		_ = conn.Close()
 		return
 	}
 
+	// p.mu orders the send against close, which closes p.conns under it.
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	select {
-	case p.conns <- conn:
+	case <-p.done:
+		_ = conn.Close()
+		return
+	default:
+	}
+
+	select {
+	case p.conns <- pooledConn{conn: conn, idleSince: time.Now()}:
 	default:
 		// Pool is full, close the connection and ignore close error
 		_ = conn.Close()
@@ -122,9 +284,15 @@ func (p *connPool) close() {
 		// Nothing to close in test/fake pools.
 		return
 	}
+	select {
+	case <-p.done:
+		return // already closed
+	default:
+	}
 
+	close(p.done)
 	close(p.conns)
-	for conn := range p.conns {
-		_ = conn.Close()
+	for pc := range p.conns {
+		_ = pc.conn.Close()
 	}
 }
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"encoding/hex"
+
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// InternalCall is a sub-call or value transfer made by a contract during
+// execution, as reported in the node's internal transactions. The node does
+// not attribute energy to individual internal calls.
+type InternalCall struct {
+	Hash string `json:"hash"`
+	// Caller is the contract that made the call.
+	Caller *types.Address `json:"caller,omitempty"`
+	// To is the callee or transfer recipient.
+	To *types.Address `json:"to,omitempty"`
+	// Note is the call kind recorded by the node, e.g. "call", "create" or
+	// "suicide".
+	Note string `json:"note,omitempty"`
+	// Values are the TRX (empty TokenID) and TRC10 amounts sent with the call.
+	Values   []InternalCallValue `json:"values,omitempty"`
+	Rejected bool                `json:"rejected,omitempty"`
+}
+
+// InternalCallValue is an amount sent with an InternalCall.
+type InternalCallValue struct {
+	Amount  int64  `json:"amount"`
+	TokenID string `json:"tokenId,omitempty"` // empty for TRX (SUN)
+}
+
+// newInternalCalls converts the node's internal transactions.
+func newInternalCalls(txs []*core.InternalTransaction) []InternalCall {
+	if len(txs) == 0 {
+		return nil
+	}
+	calls := make([]InternalCall, len(txs))
+	for i, tx := range txs {
+		call := InternalCall{
+			Hash:     hex.EncodeToString(tx.GetHash()),
+			Note:     string(tx.GetNote()),
+			Rejected: tx.GetRejected(),
+		}
+		if addr, err := types.NewAddressFromBytes(tx.GetCallerAddress()); err == nil {
+			call.Caller = addr
+		}
+		if addr, err := types.NewAddressFromBytes(tx.GetTransferToAddress()); err == nil {
+			call.To = addr
+		}
+		for _, v := range tx.GetCallValueInfo() {
+			call.Values = append(call.Values, InternalCallValue{Amount: v.GetCallValue(), TokenID: v.GetTokenId()})
+		}
+		calls[i] = call
+	}
+	return calls
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+)
+
+// IsTransactionKnown reports whether the node already has the transaction:
+// confirmed is true when it was included in a block (its TransactionInfo is
+// indexed), known is true when it is either confirmed or waiting in the
+// node's pending pool. Use it before re-sending a transaction that may
+// already have landed; confirmed does not mean solidified, see
+// WaitForConfirmations for that.
+//
+// Example:
+//
+//	known, confirmed, err := cli.IsTransactionKnown(ctx, job.TxID)
+//	if err != nil {
+//	    // handle error
+//	}
+//	if !known {
+//	    // safe to broadcast again
+//	}
+func (c *Client) IsTransactionKnown(ctx context.Context, txid string) (known bool, confirmed bool, err error) {
+	info, err := c.transactionInfo(ctx, txid, false)
+	if err == nil && info != nil {
+		return true, true, nil
+	}
+	if !errors.Is(err, types.ErrNotFound) {
+		return false, false, err
+	}
+
+	id, _ := hex.DecodeString(strings.TrimPrefix(txid, "0x"))
+	req := &api.BytesMessage{Value: id}
+	tx, err := lowlevel.Call(c, ctx, "get transaction from pending", func(cl api.WalletClient, ctx context.Context) (*core.Transaction, error) {
+		return cl.GetTransactionFromPending(ctx, req)
+	})
+	if err != nil {
+		return false, false, fmt.Errorf("failed to query pending pool: %w", err)
+	}
+	if tx.GetRawData() != nil && bytes.Equal(utils.GetTransactionID(tx), id) {
+		return true, false, nil
+	}
+	return false, false, nil
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+)
+
+func TestIsTransactionKnown(t *testing.T) {
+	confirmedTx := buildTriggerSmartContractTx(time.Now().Add(time.Minute))
+	pendingTx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Minute))
+	confirmedID := utils.GetTransactionID(confirmedTx)
+	pendingID := utils.GetTransactionID(pendingTx)
+
+	srv := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			if bytes.Equal(in.GetValue(), confirmedID) {
+				return &core.TransactionInfo{Id: confirmedID}, nil
+			}
+			return &core.TransactionInfo{}, nil
+		},
+		GetTxFromPendingHandler: func(ctx context.Context, in *api.BytesMessage) (*core.Transaction, error) {
+			if bytes.Equal(in.GetValue(), pendingID) {
+				return pendingTx, nil
+			}
+			return &core.Transaction{}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+	ctx := context.Background()
+
+	cases := []struct {
+		name             string
+		txid             string
+		known, confirmed bool
+	}{
+		{"confirmed", hex.EncodeToString(confirmedID), true, true},
+		{"pending", "0x" + hex.EncodeToString(pendingID), true, false},
+		{"unknown", hex.EncodeToString(make([]byte, 32)), false, false},
+	}
+	for _, tc := range cases {
+		known, confirmed, err := c.IsTransactionKnown(ctx, tc.txid)
+		if err != nil || known != tc.known || confirmed != tc.confirmed {
+			t.Fatalf("%s: got known=%v confirmed=%v err=%v", tc.name, known, confirmed, err)
+		}
+	}
+
+	if _, _, err := c.IsTransactionKnown(ctx, "abc"); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestSignAndBroadcast_SkipIfExists(t *testing.T) {
+	var broadcasts int32
+	var pending atomic.Pointer[core.Transaction]
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			atomic.AddInt32(&broadcasts, 1)
+			return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+		},
+		GetTxFromPendingHandler: func(ctx context.Context, in *api.BytesMessage) (*core.Transaction, error) {
+			if tx := pending.Load(); tx != nil {
+				return tx, nil
+			}
+			return &core.Transaction{}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(time.Minute))
+	opts := BroadcastOptions{SkipIfExists: true}
+
+	res, err := c.SignAndBroadcast(context.Background(), tx, opts)
+	if err != nil || !res.Success || res.Code != api.Return_SUCCESS || atomic.LoadInt32(&broadcasts) != 1 {
+		t.Fatalf("first send: res=%+v err=%v broadcasts=%d", res, err, broadcasts)
+	}
+
+	// Make the node report the transaction as pending; the resend is skipped.
+	pending.Store(tx)
+	res, err = c.SignAndBroadcast(context.Background(), tx, opts)
+	if err != nil || !res.Success || res.Code != api.Return_DUP_TRANSACTION_ERROR {
+		t.Fatalf("resend: res=%+v err=%v", res, err)
+	}
+	if n := atomic.LoadInt32(&broadcasts); n != 1 {
+		t.Fatalf("expected the resend to be skipped, got %d broadcasts", n)
+	}
+}
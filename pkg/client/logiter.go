@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// LogIterator walks the event logs of a block range one block at a time, so
+// only a single block's logs are held in memory. Create it with
+// FilterLogsIter. A LogIterator is not safe for concurrent use.
+type LogIterator struct {
+	c   *Client
+	ctx context.Context
+	f   *logFilter
+
+	next   int64 // next block to fetch
+	to     int64
+	buf    []DecodedLog
+	cursor int64
+	err    error
+}
+
+// FilterLogsIter returns an iterator over the event logs matching q, in block
+// and log order. Unlike FilterLogs it has no range limit and fetches each
+// block only when the previous one's logs are consumed. ToBlock zero means
+// the head block at the time of the call.
+//
+// Cursor reports the last block whose logs were all returned; an indexer
+// that stores it can resume after a crash with FromBlock = Cursor()+1,
+// seeing at most the logs of one block twice.
+//
+// Example:
+//
+//	it, err := cli.FilterLogsIter(ctx, client.LogQuery{
+//	    FromBlock:         checkpoint + 1,
+//	    ToBlock:           70_000_000,
+//	    ContractAddresses: []*types.Address{usdt},
+//	})
+//	if err != nil {
+//	    // handle error
+//	}
+//	for lg, ok := it.Next(); ok; lg, ok = it.Next() {
+//	    index(lg)
+//	    checkpoint = it.Cursor()
+//	}
+//	if err := it.Err(); err != nil {
+//	    // resume later from checkpoint + 1
+//	}
+func (c *Client) FilterLogsIter(ctx context.Context, q LogQuery) (*LogIterator, error) {
+	f, err := newLogFilter(q)
+	if err != nil {
+		return nil, err
+	}
+	if q.ToBlock == 0 {
+		head, err := lowlevel.GetNowBlock2(c, ctx, &api.EmptyMessage{})
+		if err != nil {
+			return nil, err
+		}
+		q.ToBlock = head.GetBlockHeader().GetRawData().GetNumber()
+	}
+	if q.FromBlock < 0 || q.ToBlock < q.FromBlock {
+		return nil, fmt.Errorf("%w: invalid block range %d-%d", types.ErrInvalidParameter, q.FromBlock, q.ToBlock)
+	}
+	return &LogIterator{c: c, ctx: ctx, f: f, next: q.FromBlock, to: q.ToBlock, cursor: q.FromBlock - 1}, nil
+}
+
+// Next returns the next matching log. It returns false when the range is
+// exhausted or a block could not be fetched; check Err to tell them apart.
+func (it *LogIterator) Next() (DecodedLog, bool) {
+	for len(it.buf) == 0 {
+		if it.err != nil || it.next > it.to {
+			return DecodedLog{}, false
+		}
+		infos, err := it.c.blockTransactionInfos(it.ctx, it.next)
+		if err != nil {
+			it.err = err
+			return DecodedLog{}, false
+		}
+		it.buf = it.f.collect(infos)
+		if len(it.buf) == 0 {
+			it.cursor = it.next
+		}
+		it.next++
+	}
+
+	lg := it.buf[0]
+	it.buf[0] = DecodedLog{}
+	it.buf = it.buf[1:]
+	if len(it.buf) == 0 {
+		it.cursor = lg.BlockNumber
+	}
+	return lg, true
+}
+
+// Cursor returns the last block whose matching logs have all been returned
+// by Next, or FromBlock-1 before the first block completes.
+func (it *LogIterator) Cursor() int64 {
+	return it.cursor
+}
+
+// Err returns the error that stopped the iteration, or nil if it ended
+// normally. Blocks after Cursor were not fully processed.
+func (it *LogIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,292 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/eventdecoder"
+	"github.com/kslamph/tronlib/pkg/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// MaxFilterBlockRange is the largest number of blocks FilterLogs scans in a
+// single call. Each block costs one RPC, so split larger ranges.
+const MaxFilterBlockRange = 1000
+
+// LogQuery selects event logs from a range of blocks.
+type LogQuery struct {
+	// FromBlock is the first block to scan.
+	FromBlock int64
+	// ToBlock is the last block to scan, inclusive. Zero means the current
+	// head block.
+	ToBlock int64
+	// ContractAddresses restricts results to logs emitted by these
+	// contracts. Empty matches any contract.
+	ContractAddresses []*types.Address
+	// EventSignatures restricts results to these events, given as canonical
+	// signatures such as "Transfer(address,address,uint256)". Empty matches
+	// any event.
+	EventSignatures []string
+}
+
+// DecodedLog is an event log together with its block and transaction context.
+type DecodedLog struct {
+	BlockNumber    int64
+	BlockTimestamp int64
+	TxID           string
+	// LogIndex is the position of the log within its transaction.
+	LogIndex int
+	Log      *core.TransactionInfo_Log
+	// Event is the log decoded with the eventdecoder registry.
+	Event *eventdecoder.DecodedEvent
+}
+
+// logFilter is a compiled LogQuery.
+type logFilter struct {
+	addresses [][]byte
+	topics    [][]byte
+}
+
+// newLogFilter validates the address and signature parts of q.
+func newLogFilter(q LogQuery) (*logFilter, error) {
+	f := &logFilter{}
+	for _, addr := range q.ContractAddresses {
+		if addr == nil {
+			return nil, fmt.Errorf("%w: contract address cannot be nil", types.ErrInvalidAddress)
+		}
+		f.addresses = append(f.addresses, addr.BytesEVM())
+	}
+	for _, sig := range q.EventSignatures {
+		if sig == "" {
+			return nil, fmt.Errorf("%w: event signature cannot be empty", types.ErrInvalidParameter)
+		}
+		h := sha3.NewLegacyKeccak256()
+		h.Write([]byte(sig))
+		f.topics = append(f.topics, h.Sum(nil))
+	}
+	return f, nil
+}
+
+// match reports whether lg passes the address and topic0 filters.
+func (f *logFilter) match(lg *core.TransactionInfo_Log) bool {
+	if len(f.addresses) > 0 && !containsBytes(f.addresses, evmAddress(lg.GetAddress())) {
+		return false
+	}
+	if len(f.topics) > 0 && (len(lg.GetTopics()) == 0 || !containsBytes(f.topics, lg.GetTopics()[0])) {
+		return false
+	}
+	return true
+}
+
+// collect decodes the logs in infos that pass the filter.
+func (f *logFilter) collect(infos []*core.TransactionInfo) []DecodedLog {
+	var out []DecodedLog
+	for _, info := range infos {
+		for i, lg := range info.GetLog() {
+			if !f.match(lg) {
+				continue
+			}
+			out = append(out, DecodedLog{
+				BlockNumber:    info.GetBlockNumber(),
+				BlockTimestamp: info.GetBlockTimeStamp(),
+				TxID:           hex.EncodeToString(info.GetId()),
+				LogIndex:       i,
+				Log:            lg,
+				Event:          decodeLog(lg),
+			})
+		}
+	}
+	return out
+}
+
+// FilterLogs scans the blocks in q's range and returns the matching event
+// logs, decoded with the eventdecoder registry, in block and log order.
+//
+// Register the ABIs of the events of interest with eventdecoder beforehand;
+// unknown events are still returned with an "unknown_event" name.
+//
+// Example:
+//
+//	logs, err := cli.FilterLogs(ctx, client.LogQuery{
+//	    FromBlock:         70_000_000,
+//	    ToBlock:           70_000_100,
+//	    ContractAddresses: []*types.Address{usdt},
+//	    EventSignatures:   []string{"Transfer(address,address,uint256)"},
+//	})
+func (c *Client) FilterLogs(ctx context.Context, q LogQuery) ([]DecodedLog, error) {
+	f, err := newLogFilter(q)
+	if err != nil {
+		return nil, err
+	}
+	if q.ToBlock == 0 {
+		head, err := lowlevel.GetNowBlock2(c, ctx, &api.EmptyMessage{})
+		if err != nil {
+			return nil, err
+		}
+		q.ToBlock = head.GetBlockHeader().GetRawData().GetNumber()
+	}
+	if q.FromBlock < 0 || q.ToBlock < q.FromBlock {
+		return nil, fmt.Errorf("%w: invalid block range %d-%d", types.ErrInvalidParameter, q.FromBlock, q.ToBlock)
+	}
+	if q.ToBlock-q.FromBlock+1 > MaxFilterBlockRange {
+		return nil, fmt.Errorf("%w: block range %d-%d exceeds %d blocks", types.ErrInvalidParameter, q.FromBlock, q.ToBlock, MaxFilterBlockRange)
+	}
+
+	var out []DecodedLog
+	for num := q.FromBlock; num <= q.ToBlock; num++ {
+		infos, err := c.blockTransactionInfos(ctx, num)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, f.collect(infos)...)
+	}
+	return out, nil
+}
+
+// blockTransactionInfos fetches the transaction infos of one block.
+func (c *Client) blockTransactionInfos(ctx context.Context, num int64) ([]*core.TransactionInfo, error) {
+	list, err := lowlevel.GetTransactionInfoByBlockNum(c, ctx, &api.NumberMessage{Num: num})
+	if err != nil {
+		return nil, fmt.Errorf("block %d: %w", num, err)
+	}
+	return list.GetTransactionInfo(), nil
+}
+
+// evmAddress strips the 0x41 prefix from a TRON address, if present. Log
+// addresses are usually 20 bytes already.
+func evmAddress(b []byte) []byte {
+	if len(b) == 21 {
+		return b[1:]
+	}
+	return b
+}
+
+func containsBytes(set [][]byte, b []byte) bool {
+	for _, s := range set {
+		if bytes.Equal(s, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// logPollInterval is how often SubscribeLogs checks for a new head block,
+// matching TRON's 3 second block time.
+var logPollInterval = 3 * time.Second
+
+// SubscribeLogs streams event logs matching q as new blocks are produced.
+//
+// Blocks are fetched strictly in sequence, so no block is skipped: if
+// FromBlock is in the past, history is replayed first, then the
+// subscription follows the head block. FromBlock zero starts at the current
+// head. When ToBlock is set, both channels are closed after that block;
+// otherwise they are closed when ctx is cancelled.
+//
+// RPC errors are sent on the error channel and the failed block is retried
+// after the poll interval, so a transient outage delays but never drops logs.
+// Read both channels until they are closed.
+//
+// Example:
+//
+//	logs, errs := cli.SubscribeLogs(ctx, client.LogQuery{
+//	    ContractAddresses: []*types.Address{usdt},
+//	    EventSignatures:   []string{"Transfer(address,address,uint256)"},
+//	})
+//	for logs != nil || errs != nil {
+//	    select {
+//	    case lg, ok := <-logs:
+//	        if !ok { logs = nil; continue }
+//	        fmt.Println(lg.TxID, lg.Event.EventName)
+//	    case err, ok := <-errs:
+//	        if !ok { errs = nil; continue }
+//	        log.Println("subscription:", err)
+//	    }
+//	}
+func (c *Client) SubscribeLogs(ctx context.Context, q LogQuery) (<-chan DecodedLog, <-chan error) {
+	out := make(chan DecodedLog)
+	errc := make(chan error, 1)
+
+	f, err := newLogFilter(q)
+	if err == nil && (q.FromBlock < 0 || (q.ToBlock != 0 && q.ToBlock < q.FromBlock)) {
+		err = fmt.Errorf("%w: invalid block range %d-%d", types.ErrInvalidParameter, q.FromBlock, q.ToBlock)
+	}
+	if err != nil {
+		errc <- err
+		close(out)
+		close(errc)
+		return out, errc
+	}
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		report := func(err error) bool {
+			select {
+			case errc <- err:
+			case <-ctx.Done():
+				return false
+			}
+			return sleepCtx(ctx, logPollInterval)
+		}
+
+		next := q.FromBlock
+		var head int64 = -1
+		for q.ToBlock == 0 || next <= q.ToBlock {
+			if next > head || next == 0 {
+				blk, err := lowlevel.GetNowBlock2(c, ctx, &api.EmptyMessage{})
+				if err != nil {
+					if !report(err) {
+						return
+					}
+					continue
+				}
+				head = blk.GetBlockHeader().GetRawData().GetNumber()
+				if next == 0 {
+					next = head
+				}
+				if next > head {
+					if !sleepCtx(ctx, logPollInterval) {
+						return
+					}
+					continue
+				}
+			}
+
+			infos, err := c.blockTransactionInfos(ctx, next)
+			if err != nil {
+				if !report(err) {
+					return
+				}
+				continue
+			}
+			for _, lg := range f.collect(infos) {
+				select {
+				case out <- lg:
+				case <-ctx.Done():
+					return
+				}
+			}
+			next++
+		}
+	}()
+	return out, errc
+}
+
+// sleepCtx waits for d and reports false if ctx was cancelled first.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
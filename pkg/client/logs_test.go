@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+	"golang.org/x/crypto/sha3"
+)
+
+func keccak(s string) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
+
+// logsServer serves blocks whose transactions each emit one Transfer log from
+// token and one Approval log from other.
+func logsServer(head func() int64, token, other *types.Address) *testWalletServer {
+	transfer := keccak("Transfer(address,address,uint256)")
+	approval := keccak("Approval(address,address,uint256)")
+	return &testWalletServer{
+		GetNowBlock2Handler: func(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+			return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: head()}}}, nil
+		},
+		GetTxInfoByBlockNumHandler: func(ctx context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error) {
+			if in.GetNum() > head() {
+				return &api.TransactionInfoList{}, nil
+			}
+			id := make([]byte, 32)
+			id[31] = byte(in.GetNum())
+			return &api.TransactionInfoList{TransactionInfo: []*core.TransactionInfo{{
+				Id:             id,
+				BlockNumber:    in.GetNum(),
+				BlockTimeStamp: in.GetNum() * 3000,
+				Log: []*core.TransactionInfo_Log{
+					{Address: other.BytesEVM(), Topics: [][]byte{approval}},
+					{Address: token.BytesEVM(), Topics: [][]byte{transfer}},
+				},
+			}}}, nil
+		},
+	}
+}
+
+func TestFilterLogs(t *testing.T) {
+	token := types.MustNewAddressFromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	other := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	lis, _, cleanupSrv := newBufconnServer(t, logsServer(func() int64 { return 12 }, token, other))
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+	ctx := context.Background()
+
+	logs, err := c.FilterLogs(ctx, LogQuery{
+		FromBlock:         10,
+		ContractAddresses: []*types.Address{token},
+		EventSignatures:   []string{"Transfer(address,address,uint256)"},
+	})
+	if err != nil {
+		t.Fatalf("FilterLogs error: %v", err)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("expected 3 logs from blocks 10-12, got %d", len(logs))
+	}
+	for i, lg := range logs {
+		if lg.BlockNumber != int64(10+i) || lg.LogIndex != 1 || lg.Event.EventName != "Transfer" {
+			t.Errorf("unexpected log %d: block=%d index=%d event=%s", i, lg.BlockNumber, lg.LogIndex, lg.Event.EventName)
+		}
+		if lg.Event.Contract != token.String() {
+			t.Errorf("unexpected contract %s", lg.Event.Contract)
+		}
+	}
+
+	all, err := c.FilterLogs(ctx, LogQuery{FromBlock: 11, ToBlock: 11})
+	if err != nil {
+		t.Fatalf("FilterLogs error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 unfiltered logs, got %d", len(all))
+	}
+
+	if _, err := c.FilterLogs(ctx, LogQuery{FromBlock: 5, ToBlock: 4}); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for inverted range, got %v", err)
+	}
+	if _, err := c.FilterLogs(ctx, LogQuery{FromBlock: 1, ToBlock: MaxFilterBlockRange + 1}); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for oversized range, got %v", err)
+	}
+}
+
+func TestSubscribeLogs(t *testing.T) {
+	defer func(d time.Duration) { logPollInterval = d }(logPollInterval)
+	logPollInterval = 10 * time.Millisecond
+
+	token := types.MustNewAddressFromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	other := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	var head int64 = 12
+	lis, _, cleanupSrv := newBufconnServer(t, logsServer(func() int64 { return atomic.LoadInt64(&head) }, token, other))
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	logs, errs := c.SubscribeLogs(ctx, LogQuery{
+		FromBlock:         10,
+		ContractAddresses: []*types.Address{token},
+	})
+
+	// Replays 10-12, then follows the head to 13
+	for want := int64(10); want <= 13; want++ {
+		select {
+		case lg := <-logs:
+			if lg.BlockNumber != want {
+				t.Fatalf("expected block %d, got %d", want, lg.BlockNumber)
+			}
+		case err := <-errs:
+			t.Fatalf("unexpected error: %v", err)
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for block %d", want)
+		}
+		if want == 12 {
+			atomic.StoreInt64(&head, 13)
+		}
+	}
+
+	cancel()
+	for range logs {
+	}
+	for range errs {
+	}
+}
+
+func TestSubscribeLogs_ToBlock(t *testing.T) {
+	token := types.MustNewAddressFromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	other := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	lis, _, cleanupSrv := newBufconnServer(t, logsServer(func() int64 { return 20 }, token, other))
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+
+	logs, errs := c.SubscribeLogs(context.Background(), LogQuery{FromBlock: 10, ToBlock: 11})
+	var got int
+	for range logs {
+		got++
+	}
+	for err := range errs {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 4 {
+		t.Fatalf("expected 4 logs from blocks 10-11, got %d", got)
+	}
+
+	_, errs = c.SubscribeLogs(context.Background(), LogQuery{FromBlock: 5, ToBlock: 4})
+	if err := <-errs; !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestFilterLogsIter(t *testing.T) {
+	token := types.MustNewAddressFromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	other := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	srv := logsServer(func() int64 { return 2000 }, token, other)
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+	ctx := context.Background()
+
+	// Ranges beyond MaxFilterBlockRange are allowed.
+	it, err := c.FilterLogsIter(ctx, LogQuery{FromBlock: 10, ToBlock: 10 + MaxFilterBlockRange, ContractAddresses: []*types.Address{token}})
+	if err != nil {
+		t.Fatalf("FilterLogsIter error: %v", err)
+	}
+	if it.Cursor() != 9 {
+		t.Fatalf("initial cursor = %d, want 9", it.Cursor())
+	}
+	n := 0
+	for lg, ok := it.Next(); ok; lg, ok = it.Next() {
+		if lg.BlockNumber != int64(10+n) || it.Cursor() != lg.BlockNumber {
+			t.Fatalf("log %d: block=%d cursor=%d", n, lg.BlockNumber, it.Cursor())
+		}
+		n++
+	}
+	if it.Err() != nil || n != MaxFilterBlockRange+1 {
+		t.Fatalf("got %d logs, err=%v", n, it.Err())
+	}
+
+	// Two logs per block: the cursor only advances after the second one.
+	it, err = c.FilterLogsIter(ctx, LogQuery{FromBlock: 5, ToBlock: 6})
+	if err != nil {
+		t.Fatalf("FilterLogsIter error: %v", err)
+	}
+	var cursors []int64
+	for _, ok := it.Next(); ok; _, ok = it.Next() {
+		cursors = append(cursors, it.Cursor())
+	}
+	if want := []int64{4, 5, 5, 6}; len(cursors) != len(want) || cursors[0] != want[0] || cursors[1] != want[1] || cursors[2] != want[2] || cursors[3] != want[3] {
+		t.Fatalf("cursors = %v, want %v", cursors, want)
+	}
+
+	// A failed block stops the iteration and leaves the cursor before it.
+	srv.GetTxInfoByBlockNumHandler = func(ctx context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error) {
+		if in.GetNum() == 3 {
+			return nil, errors.New("boom")
+		}
+		return &api.TransactionInfoList{}, nil
+	}
+	it, err = c.FilterLogsIter(ctx, LogQuery{FromBlock: 1, ToBlock: 5})
+	if err != nil {
+		t.Fatalf("FilterLogsIter error: %v", err)
+	}
+	if _, ok := it.Next(); ok || it.Err() == nil || it.Cursor() != 2 {
+		t.Fatalf("expected failure at block 3: ok=%v err=%v cursor=%d", ok, it.Err(), it.Cursor())
+	}
+
+	if _, err := c.FilterLogsIter(ctx, LogQuery{FromBlock: 5, ToBlock: 4}); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+}
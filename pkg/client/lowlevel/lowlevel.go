@@ -2,6 +2,7 @@ package lowlevel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -17,11 +18,41 @@ type ConnProvider interface {
 	GetTimeout() time.Duration
 }
 
+// ErrOffline is returned by RPCs made through Offline.
+var ErrOffline = errors.New("no node connection: provider is offline")
+
+// Offline is a ConnProvider without a node behind it, for managers that only
+// build transactions locally. Every RPC made through it fails with ErrOffline.
+var Offline ConnProvider = offlineProvider{}
+
+type offlineProvider struct{}
+
+func (offlineProvider) GetConnection(ctx context.Context) (*grpc.ClientConn, error) {
+	return nil, ErrOffline
+}
+
+func (offlineProvider) ReturnConnection(conn *grpc.ClientConn) {}
+
+func (offlineProvider) GetTimeout() time.Duration { return 30 * time.Second }
+
 // ValidationFunc allows optional validation of RPC results.
 type ValidationFunc[T any] func(result T, operation string) error
 
 // Call wraps the lifecycle for a WalletClient RPC.
 func Call[T any](cp ConnProvider, ctx context.Context, operation string, call func(client api.WalletClient, ctx context.Context) (T, error), validateFunc ...ValidationFunc[T]) (T, error) {
+	return invoke(cp, ctx, operation, api.NewWalletClient, call, validateFunc...)
+}
+
+// SolidityCall wraps the lifecycle for a WalletSolidityClient RPC. The
+// connection must reach a node that serves the WalletSolidity service, such as
+// a solidity node or the solidity port of a full node.
+func SolidityCall[T any](cp ConnProvider, ctx context.Context, operation string, call func(client api.WalletSolidityClient, ctx context.Context) (T, error), validateFunc ...ValidationFunc[T]) (T, error) {
+	return invoke(cp, ctx, operation, api.NewWalletSolidityClient, call, validateFunc...)
+}
+
+// invoke obtains a connection, builds the service client with newClient,
+// applies the provider timeout when ctx has no deadline and runs call.
+func invoke[C any, T any](cp ConnProvider, ctx context.Context, operation string, newClient func(grpc.ClientConnInterface) C, call func(client C, ctx context.Context) (T, error), validateFunc ...ValidationFunc[T]) (T, error) {
 	var zero T
 
 	conn, err := cp.GetConnection(ctx)
@@ -34,7 +65,7 @@ func Call[T any](cp ConnProvider, ctx context.Context, operation string, call fu
 		}
 	}()
 
-	cl := api.NewWalletClient(conn)
+	cl := newClient(conn)
 
 	if _, ok := ctx.Deadline(); !ok {
 		var cancel context.CancelFunc
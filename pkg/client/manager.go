@@ -2,6 +2,7 @@ package client
 
 import (
 	"github.com/kslamph/tronlib/pkg/account"
+	"github.com/kslamph/tronlib/pkg/exchange"
 	"github.com/kslamph/tronlib/pkg/network"
 	"github.com/kslamph/tronlib/pkg/resources"
 	"github.com/kslamph/tronlib/pkg/smartcontract"
@@ -39,9 +40,12 @@ func (c *Client) TRC20(addr *types.Address) *trc20.TRC20Manager {
 	return trc20mgr
 }
 
-// Network returns the high-level NetworkManager.
+// Network returns the high-level NetworkManager. The manager is created once
+// per client and reused, so its cached chain parameters are shared by all
+// callers.
 func (c *Client) Network() *network.NetworkManager {
-	return network.NewManager(c)
+	c.networkOnce.Do(func() { c.network = network.NewManager(c) })
+	return c.network
 }
 
 // Resources returns the high-level ResourcesManager.
@@ -58,3 +62,8 @@ func (c *Client) TRC10() *trc10.TRC10Manager {
 func (c *Client) Voting() *voting.VotingManager {
 	return voting.NewManager(c)
 }
+
+// Exchange returns the high-level ExchangeManager for native TRC10 exchanges.
+func (c *Client) Exchange() *exchange.ExchangeManager {
+	return exchange.NewManager(c)
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// RPCInfo describes the outcome of a single RPC, as passed to a MetricsFunc.
+// Fields may be added in later versions.
+type RPCInfo struct {
+	// Endpoint is the node address the client was created with, e.g.
+	// "grpc://grpc.trongrid.io:50051".
+	Endpoint string
+	// Method is the full gRPC method name, e.g. "/protocol.Wallet/GetAccount".
+	Method string
+	// Latency is the time the call took.
+	Latency time.Duration
+	// Err is the call's error, nil on success.
+	Err error
+}
+
+// MetricsFunc receives the outcome of every RPC made through a client.
+type MetricsFunc func(info RPCInfo)
+
+// WithMetrics registers fn to be called after every RPC made through the
+// client, including those issued by managers and the lowlevel package. fn
+// runs synchronously on the calling goroutine, so it should only record the
+// sample, e.g. observe a Prometheus histogram or send a statsd timing.
+//
+// Each sample carries the client's endpoint, so one fn can be shared by
+// several clients fronting different nodes and still attribute latency per
+// node. WithOptions views share their client's connections and keep its
+// metrics.
+//
+// Example:
+//
+//	report := client.WithMetrics(func(info client.RPCInfo) {
+//	    rpcLatency.WithLabelValues(info.Endpoint, info.Method, status.Code(info.Err).String()).Observe(info.Latency.Seconds())
+//	})
+//	primary, err := client.NewClient("grpc://grpc.trongrid.io:50051", report)
+//	backup, err := client.NewClient("grpc://backup.example.com:50051", report)
+func WithMetrics(fn MetricsFunc) Option {
+	return func(co *clientOptions) { co.metrics = fn }
+}
+
+// metricsInterceptor reports every unary call to endpoint to fn.
+func metricsInterceptor(endpoint string, fn MetricsFunc) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		fn(RPCInfo{Endpoint: endpoint, Method: method, Latency: time.Since(start), Err: err})
+		return err
+	}
+}
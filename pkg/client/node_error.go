@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// NodeError is returned when the node fails or rejects a request. It carries
+// both the gRPC status code of the call and the TRON api.Return code, so
+// callers can branch on the failure reason instead of matching strings:
+//
+//	var nerr *client.NodeError
+//	if errors.As(err, &nerr) && nerr.ReturnCode == api.Return_SERVER_BUSY {
+//	    // retry later
+//	}
+//
+// NodeError also matches the related sentinels with errors.Is, for example
+// ErrConnectionFailed for an unavailable node or types.ErrInsufficientBandwidth
+// for BANDWITH_ERROR.
+type NodeError struct {
+	Operation  string                 // operation that failed, e.g. "broadcast transaction"
+	GRPCCode   codes.Code             // gRPC status code; codes.OK when the node answered with a TRON error
+	ReturnCode api.ReturnResponseCode // TRON return code; SUCCESS when the failure was at the gRPC level
+	Message    string                 // node-provided message
+	Cause      error                  // underlying error, if any
+}
+
+// Error implements error.
+func (e *NodeError) Error() string {
+	if e.GRPCCode != codes.OK {
+		return fmt.Sprintf("%s failed: rpc error %s: %s", e.Operation, e.GRPCCode, e.Message)
+	}
+	return fmt.Sprintf("%s failed: %s: %s", e.Operation, e.ReturnCode, e.Message)
+}
+
+// Unwrap returns the underlying cause.
+func (e *NodeError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether the error corresponds to target. Besides the client
+// sentinels it maps TRON return codes onto the generic errors in package
+// types.
+func (e *NodeError) Is(target error) bool {
+	switch e.GRPCCode {
+	case codes.Unavailable:
+		if target == ErrConnectionFailed || target == types.ErrNetworkError {
+			return true
+		}
+	case codes.DeadlineExceeded:
+		if target == types.ErrTimeout || target == context.DeadlineExceeded {
+			return true
+		}
+	case codes.Canceled:
+		if target == ErrContextCancelled || target == context.Canceled {
+			return true
+		}
+	}
+
+	switch e.ReturnCode {
+	case api.Return_SIGERROR, api.Return_CONTRACT_VALIDATE_ERROR, api.Return_TAPOS_ERROR,
+		api.Return_TOO_BIG_TRANSACTION_ERROR, api.Return_TRANSACTION_EXPIRATION_ERROR:
+		return target == types.ErrInvalidTransaction
+	case api.Return_CONTRACT_EXE_ERROR:
+		return target == types.ErrContractExecutionFailed
+	case api.Return_BANDWITH_ERROR:
+		return target == types.ErrInsufficientBandwidth
+	case api.Return_DUP_TRANSACTION_ERROR:
+		return target == types.ErrAlreadyExists
+	case api.Return_SERVER_BUSY, api.Return_NO_CONNECTION, api.Return_NOT_ENOUGH_EFFECTIVE_CONNECTION:
+		return target == types.ErrNetworkError
+	}
+	return false
+}
+
+// newNodeError wraps an RPC error, preserving its gRPC status code. Errors
+// without a gRPC status, such as a failure to obtain a connection, are
+// returned wrapped but unchanged.
+func newNodeError(operation string, err error) error {
+	var nerr *NodeError
+	if errors.As(err, &nerr) {
+		return err
+	}
+	st, ok := status.FromError(unwrapStatus(err))
+	if !ok {
+		return fmt.Errorf("failed to %s: %w", operation, err)
+	}
+	return &NodeError{
+		Operation: operation,
+		GRPCCode:  st.Code(),
+		Message:   st.Message(),
+		Cause:     err,
+	}
+}
+
+// newReturnError converts a failed api.Return into a NodeError. It returns
+// nil for a successful result.
+func newReturnError(operation string, ret *api.Return) error {
+	if ret.GetResult() {
+		return nil
+	}
+	return &NodeError{
+		Operation:  operation,
+		ReturnCode: ret.GetCode(),
+		Message:    string(ret.GetMessage()),
+	}
+}
+
+// unwrapStatus finds the innermost error carrying a gRPC status in err's chain.
+func unwrapStatus(err error) error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if _, ok := e.(interface{ GRPCStatus() *status.Status }); ok {
+			return e
+		}
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSignAndBroadcast_NodeErrorFromReturn(t *testing.T) {
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			return &api.Return{Result: false, Code: api.Return_BANDWITH_ERROR, Message: []byte("account resource insufficient")}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Second))
+	res, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{WaitForReceipt: false})
+	if res == nil || res.Success || res.Code != api.Return_BANDWITH_ERROR {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	var nerr *NodeError
+	if !errors.As(err, &nerr) {
+		t.Fatalf("expected *NodeError, got %T: %v", err, err)
+	}
+	if nerr.ReturnCode != api.Return_BANDWITH_ERROR || nerr.GRPCCode != codes.OK {
+		t.Fatalf("unexpected codes: %+v", nerr)
+	}
+	if nerr.Message != "account resource insufficient" {
+		t.Fatalf("unexpected message: %q", nerr.Message)
+	}
+	if !errors.Is(err, types.ErrInsufficientBandwidth) {
+		t.Fatalf("expected errors.Is ErrInsufficientBandwidth")
+	}
+	if errors.Is(err, types.ErrInvalidTransaction) {
+		t.Fatalf("unexpected match on ErrInvalidTransaction")
+	}
+}
+
+func TestSignAndBroadcast_NodeErrorFromStatus(t *testing.T) {
+	srv := &testWalletServer{
+		BroadcastHandler: func(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+			return nil, status.Error(codes.Unavailable, "node syncing")
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	tx := buildTriggerSmartContractTx(time.Now().Add(2 * time.Second))
+	_, err := c.SignAndBroadcast(context.Background(), tx, BroadcastOptions{WaitForReceipt: false})
+	var nerr *NodeError
+	if !errors.As(err, &nerr) {
+		t.Fatalf("expected *NodeError, got %T: %v", err, err)
+	}
+	if nerr.GRPCCode != codes.Unavailable || nerr.Message != "node syncing" {
+		t.Fatalf("unexpected node error: %+v", nerr)
+	}
+	if !errors.Is(err, ErrConnectionFailed) {
+		t.Fatalf("expected errors.Is ErrConnectionFailed")
+	}
+}
+
+func TestNodeError_IsMapping(t *testing.T) {
+	tests := []struct {
+		err    *NodeError
+		target error
+	}{
+		{&NodeError{ReturnCode: api.Return_CONTRACT_VALIDATE_ERROR}, types.ErrInvalidTransaction},
+		{&NodeError{ReturnCode: api.Return_CONTRACT_EXE_ERROR}, types.ErrContractExecutionFailed},
+		{&NodeError{ReturnCode: api.Return_DUP_TRANSACTION_ERROR}, types.ErrAlreadyExists},
+		{&NodeError{ReturnCode: api.Return_SERVER_BUSY}, types.ErrNetworkError},
+		{&NodeError{GRPCCode: codes.DeadlineExceeded}, types.ErrTimeout},
+		{&NodeError{GRPCCode: codes.Canceled}, ErrContextCancelled},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.target) {
+			t.Errorf("%v: expected match on %v", tt.err, tt.target)
+		}
+	}
+}
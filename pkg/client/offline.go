@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxExpirationWindow is the furthest past its reference block a transaction
+// may expire; nodes reject anything beyond it.
+const maxExpirationWindow = types.MaxExpiration
+
+// BlockRef identifies the recent block a transaction references (TaPoS).
+// Transactions are only valid while the referenced block is among the
+// node's recent blocks, so build them from a block fetched shortly before.
+type BlockRef struct {
+	Number    int64
+	Hash      []byte
+	Timestamp int64 // block timestamp in milliseconds
+}
+
+// NewBlockRef extracts the reference fields from a block.
+func NewBlockRef(blk *api.BlockExtention) (*BlockRef, error) {
+	if blk == nil || blk.GetBlockHeader().GetRawData() == nil {
+		return nil, fmt.Errorf("%w: block header cannot be nil", types.ErrInvalidParameter)
+	}
+	if len(blk.GetBlockid()) != 32 {
+		return nil, fmt.Errorf("%w: block id must be 32 bytes, got %d", types.ErrInvalidParameter, len(blk.GetBlockid()))
+	}
+	raw := blk.GetBlockHeader().GetRawData()
+	return &BlockRef{Number: raw.GetNumber(), Hash: blk.GetBlockid(), Timestamp: raw.GetTimestamp()}, nil
+}
+
+// apply points raw at the referenced block by setting its ref_block_bytes
+// and ref_block_hash.
+func (r *BlockRef) apply(raw *core.TransactionRaw) {
+	var number [8]byte
+	binary.BigEndian.PutUint64(number[:], uint64(r.Number))
+	raw.RefBlockBytes = append([]byte(nil), number[6:8]...)
+	raw.RefBlockHash = append([]byte(nil), r.Hash[8:16]...)
+}
+
+// GetBlockRef fetches the current head block and returns it as a BlockRef,
+// ready to be carried to an offline machine.
+func (c *Client) GetBlockRef(ctx context.Context) (*BlockRef, error) {
+	blk, err := lowlevel.GetNowBlock2(c, ctx, &api.EmptyMessage{})
+	if err != nil {
+		return nil, err
+	}
+	return NewBlockRef(blk)
+}
+
+// OfflineClient builds unsigned transactions without any network access,
+// for air-gapped signing setups: fetch a BlockRef online, build and sign
+// offline, then broadcast the signed transaction online.
+//
+// OfflineClient is also a types.RefBlockProvider, so managers that accept
+// one (for example trc20.NewManagerWithRefProvider) build their transactions
+// offline against the same block. The managers returned by Client (Account,
+// TRC20, SmartContract, ...) build transactions on the node and need a
+// connected Client.
+type OfflineClient struct {
+	refBlock    BlockRef
+	chainParams map[string]int64
+	expiration  time.Duration
+	now         func() time.Time
+}
+
+// NewOfflineClient creates an OfflineClient that references refBlock.
+// chainParams is an optional snapshot of chain parameters (as returned by the
+// network manager's GetChainParametersMap) for offline fee calculations.
+//
+// Transactions expire types.DefaultExpiration after they are built; use
+// SetExpiration to change this.
+func NewOfflineClient(refBlock *BlockRef, chainParams map[string]int64) (*OfflineClient, error) {
+	if refBlock == nil {
+		return nil, fmt.Errorf("%w: reference block cannot be nil", types.ErrInvalidParameter)
+	}
+	if len(refBlock.Hash) != 32 {
+		return nil, fmt.Errorf("%w: reference block hash must be 32 bytes, got %d", types.ErrInvalidParameter, len(refBlock.Hash))
+	}
+	params := make(map[string]int64, len(chainParams))
+	for k, v := range chainParams {
+		params[k] = v
+	}
+	return &OfflineClient{
+		refBlock:    *refBlock,
+		chainParams: params,
+		expiration:  types.DefaultExpiration,
+		now:         time.Now,
+	}, nil
+}
+
+// SetExpiration sets how long after construction built transactions expire.
+// The resulting expiration must stay within 24 hours of the reference block.
+func (o *OfflineClient) SetExpiration(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("%w: expiration must be positive", types.ErrInvalidParameter)
+	}
+	o.expiration = d
+	return nil
+}
+
+// ChainParameter returns a value from the chain parameter snapshot.
+func (o *OfflineClient) ChainParameter(key string) (int64, bool) {
+	v, ok := o.chainParams[key]
+	return v, ok
+}
+
+// TransferTRX builds an unsigned TRX transfer of amount SUN.
+func (o *OfflineClient) TransferTRX(from, to *types.Address, amount int64) (*api.TransactionExtention, error) {
+	if from == nil || to == nil {
+		return nil, fmt.Errorf("%w: from and to addresses cannot be nil", types.ErrInvalidAddress)
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive, got %d SUN", types.ErrInvalidAmount, amount)
+	}
+	if from.Equal(to) {
+		return nil, fmt.Errorf("%w: from and to addresses cannot be the same: %s", types.ErrInvalidParameter, from)
+	}
+	return o.BuildTransaction(core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: from.Bytes(),
+		ToAddress:    to.Bytes(),
+		Amount:       amount,
+	}, 0)
+}
+
+// TransferTRC20 builds an unsigned TRC20 transfer(to, amount) call on token.
+// amount is in the token's smallest unit.
+func (o *OfflineClient) TransferTRC20(from, token, to *types.Address, amount *big.Int, feeLimit int64) (*api.TransactionExtention, error) {
+	if to == nil {
+		return nil, fmt.Errorf("%w: to address cannot be nil", types.ErrInvalidAddress)
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", types.ErrInvalidAmount)
+	}
+	data, err := utils.NewABIProcessor(nil).EncodeMethod("transfer", []string{"address", "uint256"}, []interface{}{to, amount})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode transfer: %v", types.ErrInvalidParameter, err)
+	}
+	return o.TriggerContract(from, token, data, 0, feeLimit)
+}
+
+// TriggerContract builds an unsigned smart contract call with pre-encoded
+// call data. Unlike the online path, no constant execution is performed, so
+// reverts are only detected after broadcast.
+func (o *OfflineClient) TriggerContract(owner, contract *types.Address, data []byte, callValue, feeLimit int64) (*api.TransactionExtention, error) {
+	if owner == nil || contract == nil {
+		return nil, fmt.Errorf("%w: owner and contract addresses cannot be nil", types.ErrInvalidAddress)
+	}
+	if callValue < 0 {
+		return nil, fmt.Errorf("%w: call value cannot be negative, got %d", types.ErrInvalidParameter, callValue)
+	}
+	if feeLimit <= 0 {
+		return nil, fmt.Errorf("%w: fee limit must be positive for contract calls", types.ErrInvalidParameter)
+	}
+	return o.BuildTransaction(core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    owner.Bytes(),
+		ContractAddress: contract.Bytes(),
+		Data:            data,
+		CallValue:       callValue,
+	}, feeLimit)
+}
+
+// RefBlock returns the reference block fields for a transaction built now,
+// expiring after the client's expiration. It makes OfflineClient a
+// types.RefBlockProvider, so managers created with a ref provider, such as
+// trc20.NewManagerWithRefProvider, build transactions offline through it.
+func (o *OfflineClient) RefBlock(ctx context.Context) (*types.RefBlock, error) {
+	now := o.now()
+	expiration := now.Add(o.expiration).UnixMilli()
+	if o.refBlock.Timestamp > 0 && expiration > o.refBlock.Timestamp+maxExpirationWindow.Milliseconds() {
+		return nil, fmt.Errorf("%w: expiration is more than %v after the reference block; fetch a newer block", types.ErrInvalidParameter, maxExpirationWindow)
+	}
+	raw := &core.TransactionRaw{}
+	o.refBlock.apply(raw)
+	return &types.RefBlock{
+		RefBlockBytes: raw.RefBlockBytes,
+		RefBlockHash:  raw.RefBlockHash,
+		Expiration:    expiration,
+		Timestamp:     now.UnixMilli(),
+	}, nil
+}
+
+// BuildTransaction wraps any contract parameter in an unsigned transaction
+// referencing the client's block. It is the building block for contract
+// types without a dedicated helper, and equivalent to types.BuildTransaction
+// with the OfflineClient as the ref provider.
+func (o *OfflineClient) BuildTransaction(contractType core.Transaction_Contract_ContractType, contract proto.Message, feeLimit int64) (*api.TransactionExtention, error) {
+	return types.BuildTransaction(context.Background(), o, contractType, contract, feeLimit)
+}
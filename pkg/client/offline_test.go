@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/trc20"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+)
+
+func testBlockRef() *BlockRef {
+	hash := make([]byte, 32)
+	for i := range hash {
+		hash[i] = byte(i)
+	}
+	return &BlockRef{Number: 0x1234abcd, Hash: hash, Timestamp: time.Now().UnixMilli()}
+}
+
+func TestOfflineClient_TransferTRX(t *testing.T) {
+	oc, err := NewOfflineClient(testBlockRef(), map[string]int64{"getEnergyFee": 420})
+	if err != nil {
+		t.Fatalf("NewOfflineClient error: %v", err)
+	}
+	from := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	to := types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+
+	txExt, err := oc.TransferTRX(from, to, 1_000_000)
+	if err != nil {
+		t.Fatalf("TransferTRX error: %v", err)
+	}
+	raw := txExt.GetTransaction().GetRawData()
+	if !bytes.Equal(raw.GetRefBlockBytes(), []byte{0xab, 0xcd}) {
+		t.Errorf("unexpected ref block bytes %x", raw.GetRefBlockBytes())
+	}
+	if !bytes.Equal(raw.GetRefBlockHash(), []byte{8, 9, 10, 11, 12, 13, 14, 15}) {
+		t.Errorf("unexpected ref block hash %x", raw.GetRefBlockHash())
+	}
+	if raw.GetExpiration() <= raw.GetTimestamp() {
+		t.Errorf("expiration %d should be after timestamp %d", raw.GetExpiration(), raw.GetTimestamp())
+	}
+	if !bytes.Equal(txExt.GetTxid(), utils.GetTransactionID(txExt.GetTransaction())) {
+		t.Error("txid does not match raw data")
+	}
+
+	summary, err := types.DecodeTransaction(txExt.GetTransaction())
+	if err != nil {
+		t.Fatalf("DecodeTransaction error: %v", err)
+	}
+	if summary.Amount != 1_000_000 || !summary.To.Equal(to) {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	if fee, ok := oc.ChainParameter("getEnergyFee"); !ok || fee != 420 {
+		t.Errorf("unexpected chain parameter %d %v", fee, ok)
+	}
+}
+
+func TestOfflineClient_TransferTRC20(t *testing.T) {
+	oc, err := NewOfflineClient(testBlockRef(), nil)
+	if err != nil {
+		t.Fatalf("NewOfflineClient error: %v", err)
+	}
+	from := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	token := types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+
+	txExt, err := oc.TransferTRC20(from, token, from, big.NewInt(5), 10_000_000)
+	if err != nil {
+		t.Fatalf("TransferTRC20 error: %v", err)
+	}
+	if txExt.GetTransaction().GetRawData().GetFeeLimit() != 10_000_000 {
+		t.Error("fee limit not set")
+	}
+	summary, err := types.DecodeTransaction(txExt.GetTransaction())
+	if err != nil {
+		t.Fatalf("DecodeTransaction error: %v", err)
+	}
+	if summary.Type != core.Transaction_Contract_TriggerSmartContract || summary.Selector != "0xa9059cbb" || len(summary.CallData) != 68 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	if _, err := oc.TransferTRC20(from, token, from, big.NewInt(5), 0); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Errorf("expected ErrInvalidParameter for zero fee limit, got %v", err)
+	}
+}
+
+func TestOfflineClient_RefBlockProvider(t *testing.T) {
+	oc, err := NewOfflineClient(testBlockRef(), nil)
+	if err != nil {
+		t.Fatalf("NewOfflineClient error: %v", err)
+	}
+	var _ types.RefBlockProvider = oc
+
+	from := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	to := types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	token := types.MustNewAddressFromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+	// A manager built on the OfflineClient references the same block.
+	mgr, err := trc20.NewManagerWithRefProvider(oc, token, 6)
+	if err != nil {
+		t.Fatalf("NewManagerWithRefProvider error: %v", err)
+	}
+	txExt, err := mgr.TransferRaw(context.Background(), from, to, big.NewInt(5))
+	if err != nil {
+		t.Fatalf("TransferRaw error: %v", err)
+	}
+	raw := txExt.GetTransaction().GetRawData()
+	if !bytes.Equal(raw.GetRefBlockBytes(), []byte{0xab, 0xcd}) || !bytes.Equal(raw.GetRefBlockHash(), []byte{8, 9, 10, 11, 12, 13, 14, 15}) {
+		t.Errorf("unexpected reference block %x %x", raw.GetRefBlockBytes(), raw.GetRefBlockHash())
+	}
+	if want, _ := types.TransactionID(txExt.GetTransaction()); !bytes.Equal(txExt.GetTxid(), want) {
+		t.Error("txid does not match raw data")
+	}
+}
+
+func TestOfflineClient_Validation(t *testing.T) {
+	if _, err := NewOfflineClient(nil, nil); err == nil {
+		t.Error("expected error for nil block ref")
+	}
+	if _, err := NewOfflineClient(&BlockRef{Hash: []byte{1}}, nil); err == nil {
+		t.Error("expected error for short block hash")
+	}
+
+	ref := testBlockRef()
+	ref.Timestamp = time.Now().Add(-25 * time.Hour).UnixMilli()
+	oc, _ := NewOfflineClient(ref, nil)
+	from := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	to := types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	if _, err := oc.TransferTRX(from, to, 1); err == nil {
+		t.Error("expected error for stale reference block")
+	}
+}
+
+func TestNewBlockRef(t *testing.T) {
+	blk := &api.BlockExtention{
+		Blockid:     make([]byte, 32),
+		BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: 7, Timestamp: 1000}},
+	}
+	ref, err := NewBlockRef(blk)
+	if err != nil {
+		t.Fatalf("NewBlockRef error: %v", err)
+	}
+	if ref.Number != 7 || ref.Timestamp != 1000 {
+		t.Errorf("unexpected ref %+v", ref)
+	}
+	if _, err := NewBlockRef(&api.BlockExtention{}); err == nil {
+		t.Error("expected error for empty block")
+	}
+}
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/eventdecoder"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// ErrReceiptTimeout is returned by WaitForReceipt when the node has not
+// indexed the transaction before the wait timeout elapses.
+var ErrReceiptTimeout = types.NewTronError(1004, "transaction receipt not available before timeout", nil)
+
+// WaitOptions controls how WaitForReceipt polls for a transaction receipt.
+type WaitOptions struct {
+	// Timeout bounds the total wait. Zero uses the default.
+	Timeout time.Duration
+	// PollInterval is the delay before the second poll; it doubles after
+	// each miss up to MaxPollInterval. Zero uses the default.
+	PollInterval time.Duration
+	// MaxPollInterval caps the backoff. Zero uses the default.
+	MaxPollInterval time.Duration
+}
+
+// DefaultWaitOptions returns the default receipt wait options:
+//   - Timeout: 60 seconds (20 blocks)
+//   - PollInterval: 1 second
+//   - MaxPollInterval: 6 seconds
+func DefaultWaitOptions() WaitOptions {
+	return WaitOptions{
+		Timeout:         60 * time.Second,
+		PollInterval:    time.Second,
+		MaxPollInterval: 6 * time.Second,
+	}
+}
+
+// Receipt is a typed view of a confirmed transaction's TransactionInfo.
+type Receipt struct {
+	TxID           string
+	BlockNumber    int64
+	BlockTimestamp int64
+
+	// Success reports whether the transaction executed successfully.
+	Success bool
+	// Result is the contract execution result (SUCCESS, REVERT,
+	// OUT_OF_ENERGY, ...). It is DEFAULT for non-contract transactions.
+	Result core.Transaction_ResultContractResult
+	// RevertReason is the decoded Error(string)/Panic(uint256) reason, or the
+	// node's result message when the revert data cannot be decoded.
+	RevertReason string
+
+	// Fee is the total TRX burned in SUN.
+	Fee        int64
+	EnergyUsed int64
+	NetUsed    int64
+
+	// ContractAddress is set for contract deployments and calls.
+	ContractAddress *types.Address
+	ContractResult  []byte
+
+	Logs []*core.TransactionInfo_Log
+	// DecodedLogs holds Logs decoded with the eventdecoder registry, in
+	// the same order; unknown events decode to an "unknown_event" name,
+	// undecodable ones have Err set and nil logs stay nil.
+	DecodedLogs []*eventdecoder.DecodedEvent
+
+	// Raw is the TransactionInfo the receipt was built from.
+	Raw *core.TransactionInfo
+}
+
+// WaitForReceipt polls GetTransactionInfoById until the transaction is
+// indexed and returns its receipt.
+//
+// Polling starts immediately and backs off exponentially between misses.
+// Transient RPC errors are retried until the timeout. If the transaction is
+// not indexed in time, ErrReceiptTimeout is returned; if ctx is cancelled
+// first, the context error is returned. A failed transaction is not an
+// error: check Receipt.Success.
+//
+// Example:
+//
+//	receipt, err := cli.WaitForReceipt(ctx, result.TxID, client.DefaultWaitOptions())
+//	if errors.Is(err, client.ErrReceiptTimeout) {
+//	    // not yet indexed; the transaction may still confirm later
+//	}
+func (c *Client) WaitForReceipt(ctx context.Context, txid string, opts WaitOptions) (*Receipt, error) {
+	id, err := hex.DecodeString(strings.TrimPrefix(txid, "0x"))
+	if err != nil || len(id) != 32 {
+		return nil, fmt.Errorf("%w: invalid transaction id %q", types.ErrInvalidParameter, txid)
+	}
+
+	def := DefaultWaitOptions()
+	if opts.Timeout <= 0 {
+		opts.Timeout = def.Timeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = def.PollInterval
+	}
+	if opts.MaxPollInterval <= 0 {
+		opts.MaxPollInterval = def.MaxPollInterval
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	interval := opts.PollInterval
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("%w: %s after %v", ErrReceiptTimeout, txid, opts.Timeout)
+		case <-timer.C:
+		}
+
+		req := &api.BytesMessage{Value: id}
+		info, err := lowlevel.Call(c, waitCtx, "get transaction info by id", func(cl api.WalletClient, ctx context.Context) (*core.TransactionInfo, error) {
+			return cl.GetTransactionInfoById(ctx, req)
+		})
+		if err == nil && info != nil && bytes.Equal(info.GetId(), id) {
+			return NewReceipt(info), nil
+		}
+
+		timer.Reset(interval)
+		interval *= 2
+		if interval > opts.MaxPollInterval {
+			interval = opts.MaxPollInterval
+		}
+	}
+}
+
+// Receipt fetches the receipt of an already confirmed transaction without
+// waiting. It returns types.ErrNotFound if the node has not indexed it.
+func (c *Client) Receipt(ctx context.Context, txid string) (*Receipt, error) {
+	info, err := c.transactionInfo(ctx, txid, false)
+	if err != nil {
+		return nil, err
+	}
+	return NewReceipt(info), nil
+}
+
+// GetEvents fetches the transaction info of txid and decodes its event logs,
+// in emission order. Events unknown to the eventdecoder registry trigger an
+// ABI fetch when auto-fetch is enabled (see eventdecoder.WithAutoFetch);
+// logs that still cannot be decoded are returned with Err set.
+//
+// It returns types.ErrNotFound if the node has not indexed the transaction.
+//
+// Example:
+//
+//	events, err := cli.GetEvents(ctx, txid)
+//	if err != nil {
+//	    // handle error
+//	}
+//	for _, ev := range events {
+//	    fmt.Println(ev.Contract, ev.EventName)
+//	}
+func (c *Client) GetEvents(ctx context.Context, txid string) ([]*eventdecoder.DecodedEvent, error) {
+	info, err := c.transactionInfo(ctx, txid, false)
+	if err != nil {
+		return nil, err
+	}
+	return eventdecoder.DecodeLogs(info.GetLog())
+}
+
+// NewReceipt builds a Receipt from a TransactionInfo, decoding logs and the
+// revert reason.
+func NewReceipt(info *core.TransactionInfo) *Receipt {
+	r := &Receipt{
+		TxID:           hex.EncodeToString(info.GetId()),
+		BlockNumber:    info.GetBlockNumber(),
+		BlockTimestamp: info.GetBlockTimeStamp(),
+		Success:        info.GetResult() == core.TransactionInfo_SUCESS,
+		Result:         info.GetReceipt().GetResult(),
+		Fee:            info.GetFee(),
+		EnergyUsed:     info.GetReceipt().GetEnergyUsageTotal(),
+		NetUsed:        info.GetReceipt().GetNetUsage(),
+		Logs:           info.GetLog(),
+		Raw:            info,
+	}
+	for _, chunk := range info.GetContractResult() {
+		r.ContractResult = append(r.ContractResult, chunk...)
+	}
+	if addr := info.GetContractAddress(); len(addr) > 0 {
+		if a, err := types.NewAddressFromBytes(addr); err == nil {
+			r.ContractAddress = a
+		}
+	}
+	if !r.Success {
+		r.RevertReason = revertReason(r.ContractResult, info.GetResMessage())
+	}
+
+	r.DecodedLogs = make([]*eventdecoder.DecodedEvent, 0, len(r.Logs))
+	for _, lg := range r.Logs {
+		r.DecodedLogs = append(r.DecodedLogs, decodeLog(lg))
+	}
+	return r
+}
+
+// decodeLog decodes lg with the eventdecoder registry (fetching the
+// contract's ABI if auto-fetch is enabled). Logs that fail to decode yield an
+// event with Err set; a nil log, which DecodeLogs skips, yields nil.
+func decodeLog(lg *core.TransactionInfo_Log) *eventdecoder.DecodedEvent {
+	evs, _ := eventdecoder.DecodeLogs([]*core.TransactionInfo_Log{lg})
+	if len(evs) == 0 {
+		return nil
+	}
+	return evs[0]
+}
+
+// revertReason decodes Solidity Error(string) and Panic(uint256) revert data,
+// falling back to the node's result message.
+func revertReason(data []byte, resMessage []byte) string {
+	if reason, err := eABI.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if len(resMessage) > 0 {
+		return string(resMessage)
+	}
+	if len(data) > 0 {
+		return "0x" + hex.EncodeToString(data)
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+const testTxID = "0102030405060708091011121314151617181920212223242526272829303132"
+
+func revertData(t *testing.T, reason string) []byte {
+	t.Helper()
+	stringType, _ := eABI.NewType("string", "", nil)
+	packed, err := eABI.Arguments{{Type: stringType}}.Pack(reason)
+	if err != nil {
+		t.Fatalf("pack revert reason: %v", err)
+	}
+	return append([]byte{0x08, 0xc3, 0x79, 0xa0}, packed...)
+}
+
+func TestWaitForReceipt_IndexedAfterPolling(t *testing.T) {
+	var calls int32
+	fake := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return &core.TransactionInfo{}, nil
+			}
+			return &core.TransactionInfo{
+				Id:             in.GetValue(),
+				BlockNumber:    123,
+				Fee:            1_000,
+				Result:         core.TransactionInfo_FAILED,
+				ContractResult: [][]byte{revertData(t, "insufficient allowance")},
+				Receipt: &core.ResourceReceipt{
+					EnergyUsageTotal: 500,
+					NetUsage:         200,
+					Result:           core.Transaction_Result_REVERT,
+				},
+				Log: []*core.TransactionInfo_Log{{
+					Address: make([]byte, 20),
+					Topics:  [][]byte{make([]byte, 32)},
+				}},
+			}, nil
+		},
+	}
+	lis, _, cleanup := newBufconnServer(t, fake)
+	defer cleanup()
+	c, closeClient := newTestClientWithBufConn(t, lis, time.Second)
+	defer closeClient()
+
+	receipt, err := c.WaitForReceipt(context.Background(), testTxID, WaitOptions{
+		Timeout:         2 * time.Second,
+		PollInterval:    10 * time.Millisecond,
+		MaxPollInterval: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if receipt.TxID != testTxID || receipt.BlockNumber != 123 {
+		t.Errorf("unexpected receipt identity: %+v", receipt)
+	}
+	if receipt.Success || receipt.Result != core.Transaction_Result_REVERT {
+		t.Errorf("expected reverted receipt, got success=%v result=%v", receipt.Success, receipt.Result)
+	}
+	if receipt.RevertReason != "insufficient allowance" {
+		t.Errorf("unexpected revert reason %q", receipt.RevertReason)
+	}
+	if receipt.EnergyUsed != 500 || receipt.NetUsed != 200 || receipt.Fee != 1_000 {
+		t.Errorf("unexpected resource usage: %+v", receipt)
+	}
+	if len(receipt.DecodedLogs) != 1 {
+		t.Errorf("expected 1 decoded log, got %d", len(receipt.DecodedLogs))
+	}
+}
+
+func TestWaitForReceipt_Timeout(t *testing.T) {
+	fake := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			return &core.TransactionInfo{}, nil
+		},
+	}
+	lis, _, cleanup := newBufconnServer(t, fake)
+	defer cleanup()
+	c, closeClient := newTestClientWithBufConn(t, lis, time.Second)
+	defer closeClient()
+
+	_, err := c.WaitForReceipt(context.Background(), testTxID, WaitOptions{
+		Timeout:      50 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+	})
+	if !errors.Is(err, ErrReceiptTimeout) {
+		t.Fatalf("expected ErrReceiptTimeout, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.WaitForReceipt(ctx, testTxID, WaitOptions{Timeout: time.Second})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestReceipt_NotFoundAndInvalid(t *testing.T) {
+	lis, _, cleanup := newBufconnServer(t, &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			return &core.TransactionInfo{}, nil
+		},
+	})
+	defer cleanup()
+	c, closeClient := newTestClientWithBufConn(t, lis, time.Second)
+	defer closeClient()
+
+	if _, err := c.Receipt(context.Background(), testTxID); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := c.Receipt(context.Background(), "abc"); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Errorf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestNewReceipt_NilLog(t *testing.T) {
+	token, _ := types.NewAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	r := NewReceipt(&core.TransactionInfo{
+		Log: []*core.TransactionInfo_Log{
+			nil,
+			{Address: token.BytesEVM(), Topics: [][]byte{keccak("Transfer(address,address,uint256)")}},
+		},
+	})
+	if len(r.DecodedLogs) != 2 || r.DecodedLogs[0] != nil {
+		t.Fatalf("expected a nil entry for the nil log, got %+v", r.DecodedLogs)
+	}
+	if r.DecodedLogs[1] == nil || r.DecodedLogs[1].EventName != "Transfer" {
+		t.Fatalf("unexpected second event %+v", r.DecodedLogs[1])
+	}
+}
+
+func TestGetEvents(t *testing.T) {
+	token, _ := types.NewAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	id, _ := hex.DecodeString(testTxID)
+	srv := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			if !bytes.Equal(in.GetValue(), id) {
+				return &core.TransactionInfo{}, nil
+			}
+			return &core.TransactionInfo{
+				Id: id,
+				Log: []*core.TransactionInfo_Log{
+					{Address: token.BytesEVM(), Topics: [][]byte{keccak("Transfer(address,address,uint256)")}},
+					{Address: token.BytesEVM(), Topics: [][]byte{make([]byte, 32)}},
+				},
+			}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	events, err := c.GetEvents(context.Background(), testTxID)
+	if err != nil {
+		t.Fatalf("GetEvents: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].EventName != "Transfer" || events[0].Contract != token.String() {
+		t.Errorf("unexpected first event %+v", events[0])
+	}
+	if events[1].Signature != "0x00000000" {
+		t.Errorf("expected unknown event selector, got %+v", events[1])
+	}
+
+	_, err = c.GetEvents(context.Background(), "ff"+testTxID[2:])
+	if !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
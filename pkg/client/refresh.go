@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// RefreshTransaction points tx at the current head block and renews its
+// timestamp and expiration, keeping the contract payload, fee limit, memo and
+// permission unchanged. The original validity window (expiration minus
+// timestamp) is preserved; when it is unknown types.DefaultExpiration is used.
+//
+// Use it when a transaction built earlier has expired, or is about to, before
+// all signatures were collected, as in offline or multi-signature flows.
+//
+// IMPORTANT: refreshing changes the raw data and therefore the txid, so any
+// existing signatures are invalid afterwards. They are removed, and every
+// signer must sign the refreshed transaction again.
+func (c *Client) RefreshTransaction(ctx context.Context, tx *core.Transaction) error {
+	if tx == nil || tx.GetRawData() == nil {
+		return fmt.Errorf("%w: transaction raw data cannot be nil", types.ErrInvalidTransaction)
+	}
+	ref, err := c.GetBlockRef(ctx)
+	if err != nil {
+		return err
+	}
+
+	raw := tx.RawData
+	window := time.Duration(raw.GetExpiration()-raw.GetTimestamp()) * time.Millisecond
+	if raw.GetTimestamp() <= 0 || window <= 0 || window > maxExpirationWindow {
+		window = types.DefaultExpiration
+	}
+	now := time.Now()
+	ref.apply(raw)
+	raw.Timestamp = now.UnixMilli()
+	raw.Expiration = now.Add(window).UnixMilli()
+	tx.Signature = nil
+	return nil
+}
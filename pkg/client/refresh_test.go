@@ -0,0 +1,79 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+)
+
+func TestRefreshTransaction(t *testing.T) {
+	blockID := make([]byte, 32)
+	for i := range blockID {
+		blockID[i] = byte(0xa0 + i)
+	}
+	srv := &testWalletServer{
+		GetNowBlock2Handler: func(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+			return &api.BlockExtention{
+				Blockid:     blockID,
+				BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: 0x0102, Timestamp: time.Now().UnixMilli()}},
+			}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+	c, cleanupClient := newTestClientWithBufConn(t, lis, time.Second)
+	t.Cleanup(cleanupClient)
+
+	// Built two minutes ago with a 60s window: already expired
+	built := time.Now().Add(-2 * time.Minute)
+	oc, err := NewOfflineClient(testBlockRef(), nil)
+	if err != nil {
+		t.Fatalf("NewOfflineClient: %v", err)
+	}
+	oc.now = func() time.Time { return built }
+	if err := oc.SetExpiration(time.Minute); err != nil {
+		t.Fatalf("SetExpiration: %v", err)
+	}
+	from := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	to := types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	ext, err := oc.TransferTRX(from, to, 1_000)
+	if err != nil {
+		t.Fatalf("TransferTRX: %v", err)
+	}
+	tx := ext.GetTransaction()
+	tx.Signature = [][]byte{{0x01}}
+	payload := tx.GetRawData().GetContract()[0].GetParameter().GetValue()
+	oldID := utils.GetTransactionID(tx)
+	if !types.IsExpired(tx) {
+		t.Fatalf("expected transaction to be expired before refresh")
+	}
+
+	if err := c.RefreshTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("RefreshTransaction: %v", err)
+	}
+	raw := tx.GetRawData()
+	if types.IsExpired(tx) {
+		t.Fatalf("expected transaction to be valid after refresh")
+	}
+	if got := raw.GetExpiration() - raw.GetTimestamp(); got != time.Minute.Milliseconds() {
+		t.Fatalf("expected validity window to be preserved, got %dms", got)
+	}
+	if !bytes.Equal(raw.GetRefBlockBytes(), []byte{0x01, 0x02}) || !bytes.Equal(raw.GetRefBlockHash(), blockID[8:16]) {
+		t.Fatalf("ref block not updated: %x %x", raw.GetRefBlockBytes(), raw.GetRefBlockHash())
+	}
+	if !bytes.Equal(raw.GetContract()[0].GetParameter().GetValue(), payload) {
+		t.Fatalf("contract payload changed")
+	}
+	if len(tx.GetSignature()) != 0 {
+		t.Fatalf("expected signatures to be cleared")
+	}
+	if bytes.Equal(utils.GetTransactionID(tx), oldID) {
+		t.Fatalf("expected txid to change")
+	}
+}
@@ -23,6 +23,55 @@ type testWalletServer struct {
 	BroadcastHandler            func(ctx context.Context, in *core.Transaction) (*api.Return, error)
 	TriggerConstantContractFunc func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error)
 	GetTxInfoByIdHandler        func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error)
+	GetNowBlock2Handler         func(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error)
+	GetTxInfoByBlockNumHandler  func(ctx context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error)
+	GetNodeInfoHandler          func(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error)
+	GetBlockByLatestNumHandler  func(ctx context.Context, in *api.NumberMessage) (*api.BlockListExtention, error)
+	GetBlockHandler             func(ctx context.Context, in *api.BlockReq) (*api.BlockExtention, error)
+	GetChainParametersHandler   func(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error)
+	GetTxFromPendingHandler     func(ctx context.Context, in *api.BytesMessage) (*core.Transaction, error)
+}
+
+func (s *testWalletServer) GetChainParameters(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+	if s.GetChainParametersHandler != nil {
+		return s.GetChainParametersHandler(ctx, in)
+	}
+	return s.UnimplementedWalletServer.GetChainParameters(ctx, in)
+}
+
+func (s *testWalletServer) GetBlockByLatestNum2(ctx context.Context, in *api.NumberMessage) (*api.BlockListExtention, error) {
+	if s.GetBlockByLatestNumHandler != nil {
+		return s.GetBlockByLatestNumHandler(ctx, in)
+	}
+	return s.UnimplementedWalletServer.GetBlockByLatestNum2(ctx, in)
+}
+
+func (s *testWalletServer) GetBlock(ctx context.Context, in *api.BlockReq) (*api.BlockExtention, error) {
+	if s.GetBlockHandler != nil {
+		return s.GetBlockHandler(ctx, in)
+	}
+	return s.UnimplementedWalletServer.GetBlock(ctx, in)
+}
+
+func (s *testWalletServer) GetNodeInfo(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+	if s.GetNodeInfoHandler != nil {
+		return s.GetNodeInfoHandler(ctx, in)
+	}
+	return s.UnimplementedWalletServer.GetNodeInfo(ctx, in)
+}
+
+func (s *testWalletServer) GetTransactionInfoByBlockNum(ctx context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error) {
+	if s.GetTxInfoByBlockNumHandler != nil {
+		return s.GetTxInfoByBlockNumHandler(ctx, in)
+	}
+	return &api.TransactionInfoList{}, nil
+}
+
+func (s *testWalletServer) GetNowBlock2(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+	if s.GetNowBlock2Handler != nil {
+		return s.GetNowBlock2Handler(ctx, in)
+	}
+	return s.UnimplementedWalletServer.GetNowBlock2(ctx, in)
 }
 
 func (s *testWalletServer) BroadcastTransaction(ctx context.Context, in *core.Transaction) (*api.Return, error) {
@@ -50,6 +99,13 @@ func (s *testWalletServer) GetTransactionInfoById(ctx context.Context, in *api.B
 	return nil, nil
 }
 
+func (s *testWalletServer) GetTransactionFromPending(ctx context.Context, in *api.BytesMessage) (*core.Transaction, error) {
+	if s.GetTxFromPendingHandler != nil {
+		return s.GetTxFromPendingHandler(ctx, in)
+	}
+	return &core.Transaction{}, nil
+}
+
 // newBufconnServer spins up a bufconn-backed gRPC server.
 // Returns listener, server, and cleanup that stops the server and closes the listener.
 func newBufconnServer(t *testing.T, impl api.WalletServer) (*bufconn.Listener, *grpc.Server, func()) {
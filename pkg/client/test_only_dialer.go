@@ -5,7 +5,6 @@ package client
 import (
 	"context"
 	"net"
-	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -15,18 +14,19 @@ import (
 // internal pool dials using the provided dialer (e.g., bufconn) rather than a real network address.
 func NewClientWithDialer(endpoint string, dialer func(ctx context.Context, s string) (net.Conn, error), opts ...Option) (*Client, error) {
 	// Prepare options
-	co := &clientOptions{timeout: 30 * time.Second, initConnections: 1, maxConnections: 2}
+	co := newClientOptions()
+	co.maxConnections = 2
 	for _, opt := range opts {
 		opt(co)
 	}
 
 	// Build a factory that uses the provided dialer
+	dialOpts := append(co.dialOptions(endpoint),
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
 	factory := func(ctx context.Context) (*grpc.ClientConn, error) {
-		return grpc.NewClient(
-			endpoint,
-			grpc.WithContextDialer(dialer),
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-		)
+		return grpc.NewClient(endpoint, dialOpts...)
 	}
 
 	// Set sane defaults mirroring NewClient
@@ -34,6 +34,7 @@ func NewClientWithDialer(endpoint string, dialer func(ctx context.Context, s str
 	if err != nil {
 		return nil, err
 	}
+	pool.startReaper(co.idleTimeout)
 
 	return &Client{
 		pool:        pool,
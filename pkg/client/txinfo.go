@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// defaultTxInfoConcurrency bounds in-flight requests of
+// GetTransactionInfoByIds. It matches the default maximum pool size.
+const defaultTxInfoConcurrency = 5
+
+// TxInfoOptions configures GetTransactionInfoByIds.
+type TxInfoOptions struct {
+	// Solidity queries the WalletSolidity service so only confirmed
+	// (solidified) transactions are found. The client endpoint must serve
+	// that service, e.g. the solidity port of a full node.
+	Solidity bool
+	// Concurrency bounds the number of in-flight requests. Zero uses the
+	// default of 5; size the pool (see WithPool) to match.
+	Concurrency int
+}
+
+// GetTransactionInfoByIds fetches the TransactionInfo of many transactions,
+// fanning out across the connection pool with bounded concurrency.
+//
+// Results are returned in input order; errs[i] is non-nil when infos[i]
+// could not be retrieved, and wraps types.ErrNotFound when the node has not
+// indexed the transaction (or, with Solidity, it is not yet confirmed). If
+// ctx is cancelled mid-batch, ids not yet queried report the context error.
+//
+// Example:
+//
+//	infos, errs := cli.GetTransactionInfoByIds(ctx, txids, client.TxInfoOptions{Solidity: true})
+//	for i := range txids {
+//	    if errs[i] != nil {
+//	        // pending, unknown or failed lookup
+//	        continue
+//	    }
+//	    fmt.Println(txids[i], infos[i].GetReceipt().GetResult())
+//	}
+func (c *Client) GetTransactionInfoByIds(ctx context.Context, txids []string, opts ...TxInfoOptions) ([]*core.TransactionInfo, []error) {
+	var opt TxInfoOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Concurrency <= 0 {
+		opt.Concurrency = defaultTxInfoConcurrency
+	}
+
+	infos := make([]*core.TransactionInfo, len(txids))
+	errs := make([]error, len(txids))
+
+	sem := make(chan struct{}, opt.Concurrency)
+	var wg sync.WaitGroup
+	for i, txid := range txids {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, txid string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			infos[i], errs[i] = c.transactionInfo(ctx, txid, opt.Solidity)
+		}(i, txid)
+	}
+	wg.Wait()
+	return infos, errs
+}
+
+// transactionInfo fetches one TransactionInfo from the full node or, with
+// solidity set, the solidified chain.
+func (c *Client) transactionInfo(ctx context.Context, txid string, solidity bool) (*core.TransactionInfo, error) {
+	id, err := hex.DecodeString(strings.TrimPrefix(txid, "0x"))
+	if err != nil || len(id) != 32 {
+		return nil, fmt.Errorf("%w: invalid transaction id %q", types.ErrInvalidParameter, txid)
+	}
+	req := &api.BytesMessage{Value: id}
+
+	var info *core.TransactionInfo
+	if solidity {
+		info, err = lowlevel.SolidityCall(c, ctx, "get transaction info by id (solidity)", func(cl api.WalletSolidityClient, ctx context.Context) (*core.TransactionInfo, error) {
+			return cl.GetTransactionInfoById(ctx, req)
+		})
+	} else {
+		info, err = lowlevel.Call(c, ctx, "get transaction info by id", func(cl api.WalletClient, ctx context.Context) (*core.TransactionInfo, error) {
+			return cl.GetTransactionInfoById(ctx, req)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(info.GetId(), id) {
+		return nil, fmt.Errorf("%w: transaction info for %s", types.ErrNotFound, txid)
+	}
+	return info, nil
+}
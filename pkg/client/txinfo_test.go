@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+func TestGetTransactionInfoByIds(t *testing.T) {
+	known := map[string]int64{
+		"11" + testTxID[2:]: 100,
+		"22" + testTxID[2:]: 200,
+		"33" + testTxID[2:]: 300,
+	}
+	srv := &testWalletServer{
+		GetTxInfoByIdHandler: func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+			if block, ok := known[hex.EncodeToString(in.GetValue())]; ok {
+				return &core.TransactionInfo{Id: in.GetValue(), BlockNumber: block}, nil
+			}
+			return &core.TransactionInfo{}, nil
+		},
+	}
+	lis, _, cleanupSrv := newBufconnServer(t, srv)
+	t.Cleanup(cleanupSrv)
+
+	c, cleanupClient := newTestClientWithBufConn(t, lis, 500*time.Millisecond)
+	t.Cleanup(cleanupClient)
+
+	txids := []string{"33" + testTxID[2:], "0x11" + testTxID[2:], "44" + testTxID[2:], "bad", "22" + testTxID[2:]}
+	infos, errs := c.GetTransactionInfoByIds(context.Background(), txids, TxInfoOptions{Concurrency: 2})
+	if len(infos) != len(txids) || len(errs) != len(txids) {
+		t.Fatalf("unexpected result lengths %d/%d", len(infos), len(errs))
+	}
+	for i, want := range []int64{300, 100, 0, 0, 200} {
+		if want == 0 {
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatalf("txid %d: %v", i, errs[i])
+		}
+		if infos[i].GetBlockNumber() != want {
+			t.Fatalf("txid %d: block %d, want %d", i, infos[i].GetBlockNumber(), want)
+		}
+	}
+	if !errors.Is(errs[2], types.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", errs[2])
+	}
+	if !errors.Is(errs[3], types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", errs[3])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs = c.GetTransactionInfoByIds(ctx, txids[:1])
+	if !errors.Is(errs[0], context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", errs[0])
+	}
+}
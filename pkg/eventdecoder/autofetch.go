@@ -0,0 +1,114 @@
+package eventdecoder
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+const (
+	// autoFetchTimeout bounds each ABI fetch, since DecodeLogs takes no context.
+	autoFetchTimeout = 10 * time.Second
+	// autoFetchRetryAfter is how long a contract whose fetch failed is left
+	// alone before it is fetched again.
+	autoFetchRetryAfter = time.Minute
+	// maxFetchedAddrs bounds the set of contracts remembered as fetched. Past
+	// it an arbitrary entry is forgotten, at worst costing one extra fetch.
+	maxFetchedAddrs = 10_000
+)
+
+var (
+	fetchMu sync.Mutex
+	fetcher lowlevel.ConnProvider
+	// fetchedAddrs maps contracts that need no fetch to the time a failed
+	// fetch may be retried; the zero time means the fetch succeeded.
+	fetchedAddrs = make(map[string]time.Time)
+	// fetchInflight holds a channel per contract being fetched, closed when
+	// the fetch finishes, so concurrent decoders share one request.
+	fetchInflight = make(map[string]chan struct{})
+)
+
+// WithAutoFetch enables fetching ABIs from the chain for unknown events.
+//
+// When DecodeLogs meets a log whose signature is not registered, the
+// emitting contract's on-chain ABI is fetched once through cp (typically a
+// *client.Client), its events are registered, and the log is decoded again.
+// A contract is fetched again only if its fetch failed, after a minute;
+// contracts without an ABI on chain are remembered so they do not cause
+// repeated requests. Concurrent decoders of the same contract share one
+// fetch, and each fetch times out after 10 seconds.
+//
+// Auto-fetch needs the log's contract address, so it applies to DecodeLogs
+// but not to DecodeLog. Pass nil to disable it again.
+func WithAutoFetch(cp lowlevel.ConnProvider) {
+	fetchMu.Lock()
+	defer fetchMu.Unlock()
+	fetcher = cp
+}
+
+// autoFetch registers the events of the contract at addr if auto-fetch is
+// enabled and the contract has not been fetched before. It reports whether
+// this call registered new events; a caller that waited for a concurrent
+// fetch of the same contract gets false.
+func autoFetch(addr *types.Address) bool {
+	key := addr.String()
+
+	fetchMu.Lock()
+	cp := fetcher
+	if cp == nil {
+		fetchMu.Unlock()
+		return false
+	}
+	if retryAt, ok := fetchedAddrs[key]; ok && (retryAt.IsZero() || time.Now().Before(retryAt)) {
+		fetchMu.Unlock()
+		return false
+	}
+	if wait, ok := fetchInflight[key]; ok {
+		fetchMu.Unlock()
+		<-wait
+		return false
+	}
+	done := make(chan struct{})
+	fetchInflight[key] = done
+	fetchMu.Unlock()
+
+	registered, err := fetchContractABI(cp, addr)
+
+	fetchMu.Lock()
+	delete(fetchInflight, key)
+	var retryAt time.Time
+	if err != nil {
+		retryAt = time.Now().Add(autoFetchRetryAfter)
+	}
+	if _, ok := fetchedAddrs[key]; !ok && len(fetchedAddrs) >= maxFetchedAddrs {
+		for k := range fetchedAddrs {
+			delete(fetchedAddrs, k)
+			break
+		}
+	}
+	fetchedAddrs[key] = retryAt
+	fetchMu.Unlock()
+	close(done)
+
+	return registered
+}
+
+// fetchContractABI fetches the ABI of the contract at addr and registers its
+// events. A contract without an ABI on chain is not an error.
+func fetchContractABI(cp lowlevel.ConnProvider, addr *types.Address) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), autoFetchTimeout)
+	defer cancel()
+
+	contract, err := lowlevel.GetContract(cp, ctx, &api.BytesMessage{Value: addr.Bytes()})
+	if err != nil {
+		return false, err
+	}
+	if len(contract.GetAbi().GetEntrys()) == 0 {
+		return false, nil
+	}
+	return RegisterABIObject(contract.GetAbi()) == nil, nil
+}
@@ -0,0 +1,206 @@
+package eventdecoder
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type contractServer struct {
+	api.UnimplementedWalletServer
+	calls int32
+	abis  map[string]*core.SmartContract_ABI
+
+	failFirst int32         // number of initial calls that fail as unavailable
+	release   chan struct{} // when set, calls block until it is closed
+}
+
+func (s *contractServer) GetContract(ctx context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
+	n := atomic.AddInt32(&s.calls, 1)
+	if s.release != nil {
+		<-s.release
+	}
+	if n <= s.failFirst {
+		return nil, status.Error(codes.Unavailable, "node restarting")
+	}
+	return &core.SmartContract{Abi: s.abis[string(in.GetValue())]}, nil
+}
+
+// serveContracts enables auto-fetch against srv and resets the fetch state
+// when the test ends.
+func serveContracts(t *testing.T, srv *contractServer) {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer()
+	api.RegisterWalletServer(gs, srv)
+	go func() { _ = gs.Serve(lis) }()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	WithAutoFetch(&testConnProvider{conn: conn})
+	t.Cleanup(func() {
+		WithAutoFetch(nil)
+		fetchMu.Lock()
+		fetchedAddrs = make(map[string]time.Time)
+		fetchMu.Unlock()
+		_ = conn.Close()
+		gs.Stop()
+	})
+}
+
+func eventTopic(sig string) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(sig))
+	return h.Sum(nil)
+}
+
+type testConnProvider struct{ conn *grpc.ClientConn }
+
+func (p *testConnProvider) GetConnection(context.Context) (*grpc.ClientConn, error) {
+	return p.conn, nil
+}
+func (p *testConnProvider) ReturnConnection(*grpc.ClientConn) {}
+func (p *testConnProvider) GetTimeout() time.Duration         { return 5 * time.Second }
+
+func TestDecodeLogsAutoFetch(t *testing.T) {
+	withABI := types.MustNewAddressFromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	noABI := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	srv := &contractServer{abis: map[string]*core.SmartContract_ABI{
+		string(withABI.Bytes()): {Entrys: []*core.SmartContract_ABI_Entry{{
+			Type: core.SmartContract_ABI_Entry_Event,
+			Name: "AutoFetched",
+			Inputs: []*core.SmartContract_ABI_Entry_Param{
+				{Name: "who", Type: "address", Indexed: true},
+			},
+		}}},
+	}}
+
+	serveContracts(t, srv)
+
+	topic0 := eventTopic("AutoFetched(address)")
+	who := make([]byte, 32)
+	copy(who[12:], noABI.BytesEVM())
+
+	logs := []*core.TransactionInfo_Log{
+		{Address: withABI.BytesEVM(), Topics: [][]byte{topic0, who}},
+		{Address: noABI.BytesEVM(), Topics: [][]byte{{0xde, 0xad, 0xbe, 0xef}}},
+		{Address: noABI.BytesEVM(), Topics: [][]byte{{0xde, 0xad, 0xbe, 0xef}}},
+	}
+	events, err := DecodeLogs(logs)
+	if err != nil {
+		t.Fatalf("DecodeLogs: %v", err)
+	}
+	if events[0].EventName != "AutoFetched" || len(events[0].Parameters) != 1 {
+		t.Fatalf("expected auto-fetched event to decode, got %+v", events[0])
+	}
+	if events[0].Parameters[0].Value != noABI.String() {
+		t.Fatalf("unexpected parameter value %s", events[0].Parameters[0].Value)
+	}
+	if events[1].EventName != "unknown_event(0xdeadbeef)" {
+		t.Fatalf("expected unknown event, got %s", events[1].EventName)
+	}
+	// Decoding again must not refetch either contract
+	if _, err := DecodeLogs(logs); err != nil {
+		t.Fatalf("DecodeLogs: %v", err)
+	}
+	if got := atomic.LoadInt32(&srv.calls); got != 2 {
+		t.Fatalf("expected one fetch per contract, got %d", got)
+	}
+}
+
+func TestAutoFetch_RetriesAfterTransientError(t *testing.T) {
+	addr := types.MustNewAddressFromBase58("TKCTfkQ8L9beavNu9iaGtCHFxrwNHUxfr2")
+	// Registered events are global, so use a fresh name on every run.
+	name := fmt.Sprintf("FetchedAfterRetry%d", time.Now().UnixNano())
+	srv := &contractServer{failFirst: 1, abis: map[string]*core.SmartContract_ABI{
+		string(addr.Bytes()): {Entrys: []*core.SmartContract_ABI_Entry{{
+			Type: core.SmartContract_ABI_Entry_Event,
+			Name: name,
+		}}},
+	}}
+	serveContracts(t, srv)
+	logs := []*core.TransactionInfo_Log{{Address: addr.BytesEVM(), Topics: [][]byte{eventTopic(name + "()")}}}
+
+	events, _ := DecodeLogs(logs)
+	if events[0].EventName == name {
+		t.Fatal("expected the failed fetch to leave the event unknown")
+	}
+	// Within the retry window the contract is not fetched again.
+	_, _ = DecodeLogs(logs)
+	if got := atomic.LoadInt32(&srv.calls); got != 1 {
+		t.Fatalf("expected 1 fetch within the retry window, got %d", got)
+	}
+
+	fetchMu.Lock()
+	fetchedAddrs[addr.String()] = time.Now().Add(-time.Second)
+	fetchMu.Unlock()
+
+	events, _ = DecodeLogs(logs)
+	if events[0].EventName != name {
+		t.Fatalf("expected event to decode after retry, got %s", events[0].EventName)
+	}
+}
+
+func TestAutoFetch_DeduplicatesConcurrentFetches(t *testing.T) {
+	addr := types.MustNewAddressFromBase58("TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH")
+	srv := &contractServer{release: make(chan struct{})}
+	serveContracts(t, srv)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			autoFetch(addr)
+		}()
+	}
+	for atomic.LoadInt32(&srv.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(srv.release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&srv.calls); got != 1 {
+		t.Fatalf("expected concurrent decoders to share 1 fetch, got %d", got)
+	}
+}
+
+func TestAutoFetch_BoundsFetchedSet(t *testing.T) {
+	serveContracts(t, &contractServer{})
+
+	fetchMu.Lock()
+	for i := 0; i < maxFetchedAddrs; i++ {
+		fetchedAddrs[fmt.Sprintf("filler-%d", i)] = time.Time{}
+	}
+	fetchMu.Unlock()
+
+	addr := types.MustNewAddressFromBase58("TZ1EafTG8FRtE6ef3H2dhaucDdjv36fzPY")
+	autoFetch(addr)
+
+	fetchMu.Lock()
+	defer fetchMu.Unlock()
+	if len(fetchedAddrs) != maxFetchedAddrs {
+		t.Fatalf("expected fetched set to stay at %d entries, got %d", maxFetchedAddrs, len(fetchedAddrs))
+	}
+	if _, ok := fetchedAddrs[addr.String()]; !ok {
+		t.Fatal("expected the new contract to be remembered")
+	}
+}
@@ -1,16 +1,17 @@
-package eventdecoder
+package eventdecoder_test
 
 import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/kslamph/tronlib/pkg/eventdecoder"
 	"github.com/kslamph/tronlib/pkg/trc20"
 )
 
 // TestBug2_ParseABINoLongerStub verifies that ParseABI now correctly parses
 // JSON ABI strings and returns the expected entries (was previously a no-op stub).
 func TestBug2_ParseABINoLongerStub(t *testing.T) {
-	parser := NewSimpleABIParser()
+	parser := eventdecoder.NewSimpleABIParser()
 
 	result, err := parser.ParseABI(trc20.ERC20ABI)
 	if err != nil {
@@ -58,7 +59,7 @@ func TestBug2_RegisterABIJSONNowWorksForCustomEvents(t *testing.T) {
 	  }
 	]`
 
-	err := RegisterABIJSON(customABI)
+	err := eventdecoder.RegisterABIJSON(customABI)
 	if err != nil {
 		t.Fatalf("RegisterABIJSON returned error: %v", err)
 	}
@@ -66,7 +67,7 @@ func TestBug2_RegisterABIJSONNowWorksForCustomEvents(t *testing.T) {
 	// keccak256("CustomSlashed(address,uint256,string)") = 0x1e13a438...
 	sigBytes, _ := hex.DecodeString("1e13a438ab0f8297e5303d8f40d2cd5da80e9e015173189d4ef72fe0f660e788")
 
-	sig, found := DecodeEventSignature(sigBytes)
+	sig, found := eventdecoder.DecodeEventSignature(sigBytes)
 	if !found {
 		t.Fatal("FIX VERIFIED: CustomSlashed event should now be registered via RegisterABIJSON")
 	}
@@ -80,7 +81,7 @@ func TestBug2_RegisterABIJSONNowWorksForCustomEvents(t *testing.T) {
 func TestBug2_BuiltinEventsStillWork(t *testing.T) {
 	transferSig, _ := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
 
-	sig, found := DecodeEventSignature(transferSig)
+	sig, found := eventdecoder.DecodeEventSignature(transferSig)
 	if !found {
 		t.Fatal("Transfer should be found in builtin registry")
 	}
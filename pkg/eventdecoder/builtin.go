@@ -5740,11 +5740,5 @@ var builtinSig4 = map[[4]byte]*EventDef{
 }
 
 func init() {
-	mu.Lock()
-	for k, v := range builtinSig4 {
-		if _, exists := sig4[k]; !exists {
-			sig4[k] = v
-		}
-	}
-	mu.Unlock()
+	registry.store(builtinSig4, false)
 }
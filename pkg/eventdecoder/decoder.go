@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	eABI "github.com/ethereum/go-ethereum/accounts/abi"
 	eCommon "github.com/ethereum/go-ethereum/common"
@@ -21,6 +22,13 @@ type DecodedEvent struct {
 	EventName  string                  `json:"eventName"`
 	Parameters []DecodedEventParameter `json:"parameters"`
 	Contract   string                  `json:"contract"`
+	// Signature is the canonical signature, e.g. "Transfer(address,address,uint256)",
+	// or the 4-byte selector as 0x-hex when the event is not registered.
+	Signature string `json:"signature"`
+	// Topic0 is the raw first topic as hex, usable to group unknown events.
+	Topic0 string `json:"topic0"`
+	// Err is set by DecodeLogs when this log could not be decoded.
+	Err error `json:"-"`
 }
 
 // DecodedEventParameter represents a decoded event parameter
@@ -29,6 +37,10 @@ type DecodedEventParameter struct {
 	Type    string `json:"type"`
 	Value   string `json:"value"`
 	Indexed bool   `json:"indexed"`
+	// HashedValue is set for indexed parameters of dynamic type (string,
+	// bytes, arrays, tuples). The topic only holds the keccak256 hash of the
+	// value, so Value is that hash in hex rather than the value itself.
+	HashedValue bool `json:"hashedValue,omitempty"`
 }
 
 // ParamDef is a compact representation of an event parameter definition
@@ -42,12 +54,12 @@ type ParamDef struct {
 type EventDef struct {
 	Name   string
 	Inputs []ParamDef
+
+	plan atomic.Pointer[eventPlan] // see decodePlan
 }
 
-var (
-	mu   sync.RWMutex
-	sig4 = make(map[[4]byte]*EventDef)
-)
+// registry is the global 4-byte signature registry used by DecodeLog.
+var registry = newSigRegistry()
 
 // RegisterABIJSON registers all event entries from a JSON ABI string
 func RegisterABIJSON(abiJSON string) error {
@@ -69,12 +81,25 @@ func RegisterABIObject(abi *core.SmartContract_ABI) error {
 
 // RegisterABIEntries registers all event entries from the provided list (non-event entries are ignored)
 func RegisterABIEntries(entries []*core.SmartContract_ABI_Entry) error {
-	if len(entries) == 0 {
+	defs := abiEventDefs(entries)
+	if len(defs) == 0 {
 		return nil
 	}
 
-	local := make(map[[4]byte]*EventDef)
+	bySig := make(map[[4]byte]*EventDef, len(defs))
+	for topic, def := range defs {
+		var key [4]byte
+		copy(key[:], topic[:4])
+		bySig[key] = def
+	}
+	registry.store(bySig, true) // overwrite by design
+	return nil
+}
 
+// abiEventDefs builds the event definitions of entries keyed by their full
+// 32-byte topic. Non-event entries are ignored.
+func abiEventDefs(entries []*core.SmartContract_ABI_Entry) map[[32]byte]*EventDef {
+	defs := make(map[[32]byte]*EventDef)
 	for _, entry := range entries {
 		if entry == nil || entry.Type != core.SmartContract_ABI_Entry_Event {
 			continue
@@ -91,30 +116,82 @@ func RegisterABIEntries(entries []*core.SmartContract_ABI_Entry) error {
 		}
 		sigStr := fmt.Sprintf("%s(%s)", entry.Name, strings.Join(inputs, ","))
 
-		// Compute 4-byte signature key
 		hasher := sha3.NewLegacyKeccak256()
 		hasher.Write([]byte(sigStr))
-		sum := hasher.Sum(nil)
+		var topic [32]byte
+		copy(topic[:], hasher.Sum(nil))
 
-		var key [4]byte
-		copy(key[:], sum[:4])
-
-		local[key] = &EventDef{
+		defs[topic] = &EventDef{
 			Name:   entry.Name,
 			Inputs: compactInputs,
 		}
 	}
+	return defs
+}
 
-	if len(local) == 0 {
-		return nil
+// maxABIEventDefs bounds the ABIs whose event definitions DecodeLogWithABI
+// keeps. Past it an arbitrary entry is forgotten and rebuilt on next use.
+const maxABIEventDefs = 1_000
+
+var (
+	abiDefsMu sync.RWMutex
+	// abiDefs caches the event definitions of each ABI passed to
+	// DecodeLogWithABI, keyed by the ABI pointer.
+	abiDefs = make(map[*core.SmartContract_ABI]map[[32]byte]*EventDef)
+)
+
+// abiEventDefsCached returns the event definitions of abi, building them on
+// first use. ABIs are shared and not modified once parsed (see
+// utils.ParseABICached), so the pointer identifies its events.
+func abiEventDefsCached(abi *core.SmartContract_ABI) map[[32]byte]*EventDef {
+	abiDefsMu.RLock()
+	defs, ok := abiDefs[abi]
+	abiDefsMu.RUnlock()
+	if ok {
+		return defs
 	}
 
-	mu.Lock()
-	for k, v := range local {
-		sig4[k] = v // overwrite by design
+	defs = abiEventDefs(abi.GetEntrys())
+	abiDefsMu.Lock()
+	defer abiDefsMu.Unlock()
+	if cached, ok := abiDefs[abi]; ok {
+		return cached
 	}
-	mu.Unlock()
-	return nil
+	if len(abiDefs) >= maxABIEventDefs {
+		for k := range abiDefs {
+			delete(abiDefs, k)
+			break
+		}
+	}
+	abiDefs[abi] = defs
+	return defs
+}
+
+// DecodeLogWithABI decodes a single log strictly against the events of abi,
+// ignoring the global registry. The full 32-byte first topic must match an
+// event of abi; otherwise the error wraps types.ErrNotFound.
+//
+// The event definitions of abi are built once and reused for later logs
+// decoded with the same abi, which must not be modified afterwards.
+func DecodeLogWithABI(abi *core.SmartContract_ABI, topics [][]byte, data []byte) (*DecodedEvent, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("no topics provided")
+	}
+	if len(topics[0]) != 32 {
+		return nil, fmt.Errorf("first topic must be 32 bytes, got %d", len(topics[0]))
+	}
+	var topic [32]byte
+	copy(topic[:], topics[0])
+
+	def := abiEventDefsCached(abi)[topic]
+	if def == nil {
+		return nil, fmt.Errorf("%w: event 0x%s is not in the ABI", types.ErrNotFound, hex.EncodeToString(topics[0]))
+	}
+	ev := &DecodedEvent{}
+	if err := decodeEventInto(ev, def, topics, data); err != nil {
+		return nil, err
+	}
+	return ev, nil
 }
 
 // DecodeEventSignature returns the canonical event signature string for the given 4-byte signature if known
@@ -126,49 +203,129 @@ func DecodeEventSignature(sig []byte) (string, bool) {
 	var key [4]byte
 	copy(key[:], sig[:4])
 
-	mu.RLock()
-	def, ok := sig4[key]
-	mu.RUnlock()
-	if !ok || def == nil {
+	def := registry.get(key)
+	if def == nil {
 		return "", false
 	}
-	types := make([]string, len(def.Inputs))
-	for i, in := range def.Inputs {
-		types[i] = in.Type
-	}
-	return fmt.Sprintf("%s(%s)", def.Name, strings.Join(types, ",")), true
+	return def.decodePlan().signature, true
 }
 
-// DecodeLog decodes a single log using the global 4-byte signature registry
+// DecodeLog decodes a single log using the global 4-byte signature registry.
+// It allocates a new DecodedEvent per call; see DecodeLogInto for hot paths.
 func DecodeLog(topics [][]byte, data []byte) (*DecodedEvent, error) {
+	ev := &DecodedEvent{}
+	if err := DecodeLogInto(ev, topics, data); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// DecodeLogInto is the allocation-conscious form of DecodeLog for
+// high-volume log processing. It overwrites every field of dst and reuses
+// the backing array of dst.Parameters, so a single DecodedEvent can be
+// decoded into repeatedly without allocating a new event or parameter slice.
+// Event ABI types and signatures are prepared once per registered event.
+//
+// The decoded values are the same as DecodeLog's. Parameter values are
+// still newly allocated strings, but dst.Parameters is overwritten by the
+// next call: copy anything that must outlive it. On error dst is left in
+// an unspecified state.
+//
+// Example:
+//
+//	var ev eventdecoder.DecodedEvent
+//	for _, lg := range logs {
+//	    if err := eventdecoder.DecodeLogInto(&ev, lg.GetTopics(), lg.GetData()); err != nil {
+//	        continue
+//	    }
+//	    process(&ev)
+//	}
+func DecodeLogInto(dst *DecodedEvent, topics [][]byte, data []byte) error {
+	if dst == nil {
+		return fmt.Errorf("destination event cannot be nil")
+	}
 	if len(topics) == 0 {
-		return nil, fmt.Errorf("no topics provided")
+		return fmt.Errorf("no topics provided")
 	}
 	sigTopic := topics[0]
 	if len(sigTopic) < 4 {
-		return nil, fmt.Errorf("first topic too short for signature: %d", len(sigTopic))
+		return fmt.Errorf("first topic too short for signature: %d", len(sigTopic))
 	}
 
 	// Lookup by first 4 bytes
 	var key [4]byte
 	copy(key[:], sigTopic[:4])
 
-	mu.RLock()
-	def := sig4[key]
-	mu.RUnlock()
-
+	def := registry.get(key)
 	if def == nil {
-		return &DecodedEvent{
-			EventName:  fmt.Sprintf("unknown_event(0x%s)", hex.EncodeToString(sigTopic[:4])),
-			Parameters: []DecodedEventParameter{},
-		}, nil
+		selector := "0x" + hex.EncodeToString(sigTopic[:4])
+		params := dst.Parameters[:0]
+		if params == nil {
+			params = []DecodedEventParameter{}
+		}
+		*dst = DecodedEvent{
+			EventName:  "unknown_event(" + selector + ")",
+			Parameters: params,
+			Signature:  selector,
+			Topic0:     hex.EncodeToString(sigTopic),
+		}
+		return nil
 	}
+	return decodeEventInto(dst, def, topics, data)
+}
 
-	// Decode using internal implementation
-	return decodeEventInternal(def, topics, data)
+// eventPlan is what decoding an event needs beyond its EventDef, prepared
+// once per definition.
+type eventPlan struct {
+	signature  string
+	nonIndexed int
+	dataArgs   eABI.Arguments // non-indexed inputs
+	err        error          // a non-indexed type go-ethereum cannot decode
 }
 
-// DecodeLogs decodes a slice of logs using the global 4-byte signature registry
+// decodePlan returns the decoding plan of d, building it on first use.
+// Concurrent first uses may build it twice; the results are equal.
+func (d *EventDef) decodePlan() *eventPlan {
+	if p := d.plan.Load(); p != nil {
+		return p
+	}
+	p := &eventPlan{signature: d.signature()}
+	for _, in := range d.Inputs {
+		if in.Indexed {
+			continue
+		}
+		p.nonIndexed++
+		if p.err != nil {
+			continue
+		}
+		abiType, err := eABI.NewType(in.Type, "", nil)
+		if err != nil {
+			p.err = fmt.Errorf("failed to create ABI type for %s: %v", in.Type, err)
+			continue
+		}
+		p.dataArgs = append(p.dataArgs, eABI.Argument{Name: in.Name, Type: abiType})
+	}
+	d.plan.Store(p)
+	return p
+}
+
+// signature returns the canonical event signature, e.g. "Transfer(address,address,uint256)".
+func (d *EventDef) signature() string {
+	paramTypes := make([]string, len(d.Inputs))
+	for i, in := range d.Inputs {
+		paramTypes[i] = in.Type
+	}
+	return fmt.Sprintf("%s(%s)", d.Name, strings.Join(paramTypes, ","))
+}
+
+// DecodeLogs decodes a slice of logs using the global 4-byte signature registry.
+// If auto-fetch is enabled (see WithAutoFetch), unknown events trigger a
+// one-time fetch of the emitting contract's ABI.
+//
+// A log that cannot be decoded does not fail the batch: its DecodedEvent has
+// Err set, along with Contract, Signature and Topic0 where available. The
+// returned error is always nil and is kept for compatibility. Nil logs are
+// skipped.
 func DecodeLogs(logs []*core.TransactionInfo_Log) ([]*DecodedEvent, error) {
 	if len(logs) == 0 {
 		return []*DecodedEvent{}, nil
@@ -178,81 +335,131 @@ func DecodeLogs(logs []*core.TransactionInfo_Log) ([]*DecodedEvent, error) {
 		if lg == nil {
 			continue
 		}
+		if !isRegistered(lg.GetTopics()) {
+			if addr, err := types.NewAddressFromBytes(lg.GetAddress()); err == nil {
+				autoFetch(addr)
+			}
+		}
 		ev, err := DecodeLog(lg.GetTopics(), lg.GetData())
 		if err != nil {
-			return nil, err
+			ev = undecodableEvent(lg.GetTopics(), err)
+		}
+		if addr, err := types.NewAddressFromBytes(lg.GetAddress()); err == nil {
+			ev.Contract = addr.String()
 		}
-		ev.Contract = types.MustNewAddressFromBytes(lg.GetAddress()).String()
 		result = append(result, ev)
 	}
 	return result, nil
 }
 
-// decodeEventInternal performs the actual event decoding without external dependencies
-func decodeEventInternal(def *EventDef, topics [][]byte, data []byte) (*DecodedEvent, error) {
-	// Separate indexed and non-indexed parameters
-	var indexedParams []ParamDef
-	var nonIndexedParams []ParamDef
-
-	for _, input := range def.Inputs {
-		if input.Indexed {
-			indexedParams = append(indexedParams, input)
-		} else {
-			nonIndexedParams = append(nonIndexedParams, input)
+// undecodableEvent describes a log that failed to decode, keeping whatever
+// identifying information the topics provide.
+func undecodableEvent(topics [][]byte, err error) *DecodedEvent {
+	ev := &DecodedEvent{
+		EventName:  "undecodable_event",
+		Parameters: []DecodedEventParameter{},
+		Err:        err,
+	}
+	if len(topics) > 0 {
+		ev.Topic0 = hex.EncodeToString(topics[0])
+		if sig, ok := DecodeEventSignature(topics[0]); ok {
+			ev.EventName = sig[:strings.IndexByte(sig, '(')]
+			ev.Signature = sig
+		} else if len(topics[0]) >= 4 {
+			ev.Signature = "0x" + hex.EncodeToString(topics[0][:4])
 		}
 	}
+	return ev
+}
 
-	// Decode indexed parameters (from topics[1:])
-	indexedValues := make([]DecodedEventParameter, 0)
-	for i, param := range indexedParams {
-		if i+1 >= len(topics) {
-			break
-		}
-		value := decodeTopicValue(topics[i+1], param.Type)
-		indexedValues = append(indexedValues, DecodedEventParameter{
-			Name:    param.Name,
-			Type:    param.Type,
-			Value:   value,
-			Indexed: true,
-		})
+// isRegistered reports whether the signature in the first topic is known.
+func isRegistered(topics [][]byte) bool {
+	if len(topics) == 0 || len(topics[0]) < 4 {
+		return false
 	}
+	var key [4]byte
+	copy(key[:], topics[0][:4])
 
-	// Decode non-indexed parameters (from data)
-	var nonIndexedValues []DecodedEventParameter
-	if len(nonIndexedParams) > 0 && len(data) > 0 {
-		decoded, err := decodeEventData(data, nonIndexedParams)
+	return registry.get(key) != nil
+}
+
+// decodeEventInto decodes topics and data with def into dst, appending the
+// parameters to dst.Parameters[:0] in declaration order.
+func decodeEventInto(dst *DecodedEvent, def *EventDef, topics [][]byte, data []byte) error {
+	plan := def.decodePlan()
+
+	var values []interface{}
+	decoded := plan.nonIndexed > 0 && len(data) > 0
+	if decoded {
+		if plan.err != nil {
+			return fmt.Errorf("failed to decode event data: %v", plan.err)
+		}
+		var err error
+		values, err = plan.dataArgs.Unpack(data)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode event data: %v", err)
+			return fmt.Errorf("failed to decode event data: failed to unpack event data: %v", err)
 		}
-		nonIndexedValues = decoded
 	}
 
-	// Combine all parameters in original order
-	allParams := make([]DecodedEventParameter, 0)
+	params := dst.Parameters[:0]
+	if params == nil {
+		params = []DecodedEventParameter{}
+	}
+	topic, value := 0, 0
 	for _, input := range def.Inputs {
 		if input.Indexed {
-			// Find corresponding indexed parameter
-			for _, indexed := range indexedValues {
-				if indexed.Name == input.Name {
-					allParams = append(allParams, indexed)
-					break
-				}
+			// Indexed parameters are in topics[1:]; missing ones are skipped.
+			topic++
+			if topic >= len(topics) {
+				continue
 			}
-		} else {
-			// Find corresponding non-indexed parameter
-			for _, nonIndexed := range nonIndexedValues {
-				if nonIndexed.Name == input.Name {
-					allParams = append(allParams, nonIndexed)
-					break
-				}
+			hashed := isHashedTopicType(input.Type)
+			v := hex.EncodeToString(topics[topic])
+			if !hashed {
+				v = decodeTopicValue(topics[topic], input.Type)
 			}
+			params = append(params, DecodedEventParameter{
+				Name:        input.Name,
+				Type:        input.Type,
+				Value:       v,
+				Indexed:     true,
+				HashedValue: hashed,
+			})
+			continue
+		}
+
+		// Non-indexed parameters are only reported when there is data.
+		if !decoded {
+			continue
+		}
+		var v string
+		if value < len(values) {
+			v = formatEventValue(values[value], input.Type)
 		}
+		value++
+		params = append(params, DecodedEventParameter{
+			Name:  input.Name,
+			Type:  input.Type,
+			Value: v,
+		})
 	}
 
-	return &DecodedEvent{
+	*dst = DecodedEvent{
 		EventName:  def.Name,
-		Parameters: allParams,
-	}, nil
+		Parameters: params,
+		Signature:  plan.signature,
+		Topic0:     hex.EncodeToString(topics[0]),
+	}
+	return nil
+}
+
+// isHashedTopicType reports whether an indexed parameter of paramType is
+// stored in its topic as a keccak256 hash: strings, dynamic bytes, arrays
+// and tuples.
+func isHashedTopicType(paramType string) bool {
+	return paramType == "string" || paramType == "bytes" ||
+		strings.HasSuffix(paramType, "]") ||
+		strings.HasPrefix(paramType, "tuple") || strings.HasPrefix(paramType, "(")
 }
 
 // decodeTopicValue decodes a topic value based on its ABI type
@@ -260,14 +467,11 @@ func decodeTopicValue(topic []byte, paramType string) string {
 	switch paramType {
 	case "address":
 		ethaddr := eCommon.BytesToAddress(topic)
-		tronAddr, err := types.NewAddressFromHex(ethaddr.Hex())
+		tronAddr, err := types.NewAddressFromBytes(ethaddr.Bytes())
 		if err != nil {
 			return hex.EncodeToString(topic)
 		}
 		return tronAddr.String()
-	case "uint256", "uint128", "uint64", "uint32", "uint16", "uint8",
-		"int256", "int128", "int64", "int32", "int16", "int8":
-		return new(big.Int).SetBytes(topic).String()
 	case "bool":
 		// ABI encodes bool as uint8 in a 32-byte word: true = 0x00...01, false = 0x00...00
 		// Check if any byte in the topic is non-zero (the 1 is in the last byte).
@@ -282,47 +486,18 @@ func decodeTopicValue(topic []byte, paramType string) string {
 	case "bytes32":
 		return hex.EncodeToString(topic)
 	default:
-		return hex.EncodeToString(topic)
-	}
-}
-
-// decodeEventData decodes non-indexed event parameters from data
-func decodeEventData(data []byte, params []ParamDef) ([]DecodedEventParameter, error) {
-	// Create ethereum ABI arguments for decoding
-	args := make([]eABI.Argument, len(params))
-	for i, param := range params {
-		abiType, err := eABI.NewType(param.Type, "", nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create ABI type for %s: %v", param.Type, err)
-		}
-		args[i] = eABI.Argument{
-			Name: param.Name,
-			Type: abiType,
-		}
-	}
-
-	// Unpack the data
-	values, err := eABI.Arguments(args).Unpack(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unpack event data: %v", err)
-	}
-
-	result := make([]DecodedEventParameter, len(params))
-	for i, param := range params {
-		var value string
-		if i < len(values) {
-			value = formatEventValue(values[i], param.Type)
-		}
-
-		result[i] = DecodedEventParameter{
-			Name:    param.Name,
-			Type:    param.Type,
-			Value:   value,
-			Indexed: false,
+		if strings.HasPrefix(paramType, "int") || strings.HasPrefix(paramType, "uint") {
+			if _, err := eABI.NewType(paramType, "", nil); err == nil {
+				v := new(big.Int).SetBytes(topic)
+				// Signed values are sign-extended to 256 bits in two's complement
+				if strings.HasPrefix(paramType, "int") && len(topic) > 0 && topic[0]&0x80 != 0 {
+					v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(topic)*8)))
+				}
+				return v.String()
+			}
 		}
+		return hex.EncodeToString(topic)
 	}
-
-	return result, nil
 }
 
 // formatEventValue formats event value for display
@@ -330,7 +505,7 @@ func formatEventValue(value interface{}, paramType string) string {
 	switch paramType {
 	case "address":
 		if addr, ok := value.(eCommon.Address); ok {
-			tronAddr, err := types.NewAddressFromHex(addr.Hex())
+			tronAddr, err := types.NewAddressFromBytes(addr.Bytes())
 			if err != nil {
 				return fmt.Sprintf("%v", value)
 			}
@@ -345,6 +520,12 @@ func formatEventValue(value interface{}, paramType string) string {
 		if bytes, ok := value.([]byte); ok {
 			return hex.EncodeToString(bytes)
 		}
+		// Fixed-size bytesN values are decoded as [N]byte arrays
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hex.EncodeToString(b)
+		}
 	case "string":
 		if s, ok := value.(string); ok {
 			return s
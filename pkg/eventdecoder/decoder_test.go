@@ -2,15 +2,22 @@ package eventdecoder
 
 import (
 	"encoding/hex"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/kslamph/tronlib/pb/core"
-	"github.com/kslamph/tronlib/pkg/trc20"
+	"golang.org/x/crypto/sha3"
 )
 
+// transferEventABI is the TRC20 Transfer event. It is spelled out rather than
+// taken from trc20.ERC20ABI, which would be an import cycle through
+// smartcontract.
+const transferEventABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`
+
 func TestRegisterAndDecodeTRC20(t *testing.T) {
-	// register ERC20 ABI
-	if err := RegisterABIJSON(trc20.ERC20ABI); err != nil {
+	// register ERC20 Transfer event
+	if err := RegisterABIJSON(transferEventABI); err != nil {
 		t.Fatalf("register ABI: %v", err)
 	}
 
@@ -225,3 +232,209 @@ func TestEdgeCases(t *testing.T) {
 		t.Errorf("DecodeLogs should handle nil log entries: %v", err)
 	}
 }
+
+func TestDecodeLogsIsolatesErrors(t *testing.T) {
+	sigTopic, _ := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	fromTopic, _ := hex.DecodeString("000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	toTopic, _ := hex.DecodeString("0000000000000000000000004e83362442b8d1bec281594cea3050c8eb01311c")
+	amountData, _ := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000003e8")
+	unknownTopic, _ := hex.DecodeString("1234567800000000000000000000000000000000000000000000000000000000")
+	contract, _ := hex.DecodeString("a614f803b6fd780986a42c78ec9c7f77e6ded13c")
+
+	logs := []*core.TransactionInfo_Log{
+		{Address: contract, Topics: [][]byte{sigTopic, fromTopic, toTopic}, Data: []byte{0x01, 0x02}}, // truncated data
+		{Address: contract, Topics: [][]byte{sigTopic, fromTopic, toTopic}, Data: amountData},
+		{Address: contract, Topics: [][]byte{unknownTopic}},
+		{Address: contract},
+	}
+	res, err := DecodeLogs(logs)
+	if err != nil {
+		t.Fatalf("decode logs: %v", err)
+	}
+	if len(res) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(res))
+	}
+
+	bad := res[0]
+	if bad.Err == nil || bad.EventName != "Transfer" || bad.Signature != "Transfer(address,address,uint256)" {
+		t.Fatalf("unexpected undecodable event: %+v", bad)
+	}
+	if bad.Contract != "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t" || bad.Topic0 != hex.EncodeToString(sigTopic) {
+		t.Fatalf("expected contract and topic0 on undecodable event: %+v", bad)
+	}
+
+	if res[1].Err != nil || res[1].Signature != "Transfer(address,address,uint256)" {
+		t.Fatalf("unexpected decoded event: %+v", res[1])
+	}
+
+	if res[2].Err != nil || res[2].Signature != "0x12345678" || res[2].Topic0 != hex.EncodeToString(unknownTopic) {
+		t.Fatalf("unexpected unknown event: %+v", res[2])
+	}
+
+	if res[3].Err == nil || res[3].Contract == "" {
+		t.Fatalf("expected error for log without topics: %+v", res[3])
+	}
+}
+
+func TestDecodeIndexedDynamicTypes(t *testing.T) {
+	abiJSON := `[{"type":"event","name":"Named","anonymous":false,"inputs":[
+		{"name":"name","type":"string","indexed":true},
+		{"name":"ids","type":"uint256[]","indexed":true},
+		{"name":"blob","type":"bytes","indexed":true},
+		{"name":"id","type":"uint256","indexed":true}]}]`
+	if err := RegisterABIJSON(abiJSON); err != nil {
+		t.Fatalf("register ABI: %v", err)
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("Named(string,uint256[],bytes,uint256)"))
+	sigTopic := h.Sum(nil)
+	h = sha3.NewLegacyKeccak256()
+	h.Write([]byte("alice"))
+	nameHash := h.Sum(nil)
+	idsHash := make([]byte, 32)
+	idsHash[0] = 0xaa
+	blobHash := make([]byte, 32)
+	blobHash[0] = 0xbb
+	id := make([]byte, 32)
+	id[31] = 7
+
+	ev, err := DecodeLog([][]byte{sigTopic, nameHash, idsHash, blobHash, id}, nil)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(ev.Parameters) != 4 {
+		t.Fatalf("unexpected param count: %d", len(ev.Parameters))
+	}
+	for i, want := range [][]byte{nameHash, idsHash, blobHash} {
+		p := ev.Parameters[i]
+		if !p.HashedValue || p.Value != hex.EncodeToString(want) {
+			t.Errorf("param %s: expected hashed value %x, got %+v", p.Name, want, p)
+		}
+	}
+	if p := ev.Parameters[0]; p.Type != "string" {
+		t.Errorf("expected type to be preserved, got %s", p.Type)
+	}
+	if p := ev.Parameters[3]; p.HashedValue || p.Value != "7" {
+		t.Errorf("unexpected static param: %+v", p)
+	}
+}
+
+func TestDecodeLogInto(t *testing.T) {
+	if err := RegisterABIJSON(transferEventABI); err != nil {
+		t.Fatalf("register ABI: %v", err)
+	}
+	topics, data := transferLog()
+
+	want, err := DecodeLog(topics, data)
+	if err != nil {
+		t.Fatalf("DecodeLog: %v", err)
+	}
+	ev := DecodedEvent{Contract: "stale", Err: fmt.Errorf("stale")}
+	if err := DecodeLogInto(&ev, topics, data); err != nil {
+		t.Fatalf("DecodeLogInto: %v", err)
+	}
+	if !reflect.DeepEqual(&ev, want) {
+		t.Fatalf("DecodeLogInto = %+v, want %+v", ev, want)
+	}
+
+	// The parameter slice is reused across calls.
+	first := &ev.Parameters[0]
+	if err := DecodeLogInto(&ev, topics, data); err != nil {
+		t.Fatalf("DecodeLogInto: %v", err)
+	}
+	if &ev.Parameters[0] != first {
+		t.Fatal("expected dst.Parameters to be reused")
+	}
+
+	unknown, _ := hex.DecodeString("1234567800000000000000000000000000000000000000000000000000000000")
+	if err := DecodeLogInto(&ev, [][]byte{unknown}, nil); err != nil {
+		t.Fatalf("DecodeLogInto unknown: %v", err)
+	}
+	if ev.EventName != "unknown_event(0x12345678)" || len(ev.Parameters) != 0 || ev.Parameters == nil {
+		t.Fatalf("unexpected unknown event: %+v", ev)
+	}
+
+	if err := DecodeLogInto(nil, topics, data); err == nil {
+		t.Fatal("expected error for nil destination")
+	}
+	if err := DecodeLogInto(&ev, nil, nil); err == nil {
+		t.Fatal("expected error for missing topics")
+	}
+}
+
+func TestDecodeLogUnnamedParameters(t *testing.T) {
+	abi := `[{"type":"event","name":"Unnamed","inputs":[{"name":"","type":"uint256","indexed":true},{"name":"","type":"uint256","indexed":true},{"name":"","type":"uint256","indexed":false},{"name":"","type":"uint256","indexed":false}]}]`
+	if err := RegisterABIJSON(abi); err != nil {
+		t.Fatalf("register ABI: %v", err)
+	}
+	word := func(v byte) []byte { b := make([]byte, 32); b[31] = v; return b }
+	data := append(word(3), word(4)...)
+
+	ev, err := DecodeLog([][]byte{hashTopic("Unnamed(uint256,uint256,uint256,uint256)"), word(1), word(2)}, data)
+	if err != nil {
+		t.Fatalf("DecodeLog: %v", err)
+	}
+	for i, p := range ev.Parameters {
+		if p.Value != fmt.Sprint(i+1) {
+			t.Fatalf("parameter %d = %q, want %d", i, p.Value, i+1)
+		}
+	}
+}
+
+func BenchmarkDecodeLog(b *testing.B) {
+	if err := RegisterABIJSON(transferEventABI); err != nil {
+		b.Fatal(err)
+	}
+	topics, data := transferLog()
+
+	b.Run("DecodeLog", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeLog(topics, data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("DecodeLogInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var ev DecodedEvent
+		for i := 0; i < b.N; i++ {
+			if err := DecodeLogInto(&ev, topics, data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestDecodeLogWithABIReusesEventDefs(t *testing.T) {
+	abi, err := NewSimpleABIParser().ParseABI(transferEventABI)
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+	sigTopic, _ := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	fromTopic, _ := hex.DecodeString("000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	toTopic, _ := hex.DecodeString("0000000000000000000000004e83362442b8d1bec281594cea3050c8eb01311c")
+	amountData, _ := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000003e8")
+	topics := [][]byte{sigTopic, fromTopic, toTopic}
+
+	for i := 0; i < 2; i++ {
+		ev, err := DecodeLogWithABI(abi, topics, amountData)
+		if err != nil {
+			t.Fatalf("decode %d: %v", i, err)
+		}
+		if ev.EventName != "Transfer" || len(ev.Parameters) != 3 {
+			t.Fatalf("decode %d: unexpected event %+v", i, ev)
+		}
+	}
+
+	var topic [32]byte
+	copy(topic[:], sigTopic)
+	first := abiEventDefsCached(abi)[topic]
+	if first == nil {
+		t.Fatal("expected Transfer to be cached for the ABI")
+	}
+	if again := abiEventDefsCached(abi)[topic]; again != first {
+		t.Fatal("expected the cached event definition to be reused")
+	}
+}
@@ -18,6 +18,19 @@
 // To expand coverage, call RegisterABIJSON or RegisterABIObject with known
 // contract ABIs. Builtins include TRC20 events.
 //
+// # High-Volume Decoding
+//
+// Indexers should use DecodeLogInto, which decodes into a caller-owned
+// DecodedEvent and reuses its parameter slice instead of allocating a new
+// event per log:
+//
+//	var ev eventdecoder.DecodedEvent
+//	for _, lg := range logs {
+//	    if err := eventdecoder.DecodeLogInto(&ev, lg.GetTopics(), lg.GetData()); err == nil {
+//	        process(&ev)
+//	    }
+//	}
+//
 // # Built-in Events
 //
 // The package includes built-in support for common TRC20 events:
@@ -0,0 +1,55 @@
+package eventdecoder
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MarshalJSON renders the event with a stable, log-friendly representation:
+// parameters are encoded as described on DecodedEventParameter.MarshalJSON
+// and Err, when set, is emitted as an "error" string.
+func (e DecodedEvent) MarshalJSON() ([]byte, error) {
+	type event DecodedEvent
+	out := struct {
+		event
+		Error string `json:"error,omitempty"`
+	}{event: event(e)}
+	if out.Parameters == nil {
+		out.Parameters = []DecodedEventParameter{}
+	}
+	if e.Err != nil {
+		out.Error = e.Err.Error()
+	}
+	return json.Marshal(out)
+}
+
+// MarshalJSON renders the parameter value according to its ABI type:
+// addresses as base58 strings, integers as decimal strings, bytes and hashed
+// indexed values as 0x-prefixed hex and bools as JSON booleans. Other types
+// keep their string form.
+func (p DecodedEventParameter) MarshalJSON() ([]byte, error) {
+	type param DecodedEventParameter
+	out := struct {
+		param
+		Value interface{} `json:"value"`
+	}{param: param(p), Value: jsonParamValue(p)}
+	return json.Marshal(out)
+}
+
+func jsonParamValue(p DecodedEventParameter) interface{} {
+	if p.HashedValue || p.Type == "bytes" || (strings.HasPrefix(p.Type, "bytes") && !strings.HasSuffix(p.Type, "]")) {
+		if strings.HasPrefix(p.Value, "0x") {
+			return p.Value
+		}
+		return "0x" + p.Value
+	}
+	if p.Type == "bool" {
+		switch p.Value {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+	}
+	return p.Value
+}
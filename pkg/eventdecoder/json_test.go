@@ -0,0 +1,80 @@
+package eventdecoder
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDecodedEventMarshalJSON(t *testing.T) {
+	ev := DecodedEvent{
+		EventName: "Transfer",
+		Contract:  "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Signature: "Transfer(address,address,uint256)",
+		Parameters: []DecodedEventParameter{
+			{Name: "from", Type: "address", Value: "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb", Indexed: true},
+			{Name: "value", Type: "uint256", Value: "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+			{Name: "data", Type: "bytes", Value: "deadbeef"},
+			{Name: "id", Type: "bytes32", Value: "0xabcd"},
+			{Name: "ok", Type: "bool", Value: "true"},
+			{Name: "name", Type: "string", Value: "ab12", Indexed: true, HashedValue: true},
+		},
+		Err: errors.New("boom"),
+	}
+
+	raw, err := json.Marshal(ev)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var got struct {
+		EventName  string `json:"eventName"`
+		Error      string `json:"error"`
+		Parameters []struct {
+			Name  string      `json:"name"`
+			Value interface{} `json:"value"`
+		} `json:"parameters"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.EventName != "Transfer" || got.Error != "boom" {
+		t.Fatalf("unexpected event fields: %s", raw)
+	}
+
+	want := map[string]interface{}{
+		"from":  "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb",
+		"value": "115792089237316195423570985008687907853269984665640564039457584007913129639935",
+		"data":  "0xdeadbeef",
+		"id":    "0xabcd",
+		"ok":    true,
+		"name":  "0xab12",
+	}
+	for _, p := range got.Parameters {
+		if p.Value != want[p.Name] {
+			t.Errorf("%s: got %#v, want %#v", p.Name, p.Value, want[p.Name])
+		}
+	}
+
+	// Without an error the field is omitted.
+	ev.Err = nil
+	raw, _ = json.Marshal(ev)
+	if containsKey(raw, "error") {
+		t.Fatalf("expected no error field: %s", raw)
+	}
+}
+
+func TestFormatFixedBytes(t *testing.T) {
+	var b [32]byte
+	b[31] = 0x01
+	if got := formatEventValue(b, "bytes32"); got != "0000000000000000000000000000000000000000000000000000000000000001" {
+		t.Fatalf("unexpected bytes32 format: %s", got)
+	}
+}
+
+func containsKey(raw []byte, key string) bool {
+	var m map[string]json.RawMessage
+	_ = json.Unmarshal(raw, &m)
+	_, ok := m[key]
+	return ok
+}
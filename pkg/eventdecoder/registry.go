@@ -0,0 +1,51 @@
+package eventdecoder
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// sigRegistry maps 4-byte event signatures to their definitions.
+//
+// Lookups dominate: indexers decode every log while registrations happen
+// once per ABI. Reads therefore load an immutable map through an atomic
+// pointer and take no lock, so concurrent decoders never contend, even when
+// they all decode the same hot event such as Transfer (which a lock sharded
+// by signature would still serialize). Writers copy the map under mu and
+// publish the copy.
+type sigRegistry struct {
+	mu   sync.Mutex // serializes writers
+	defs atomic.Pointer[map[[4]byte]*EventDef]
+}
+
+func newSigRegistry() *sigRegistry {
+	r := &sigRegistry{}
+	empty := make(map[[4]byte]*EventDef)
+	r.defs.Store(&empty)
+	return r
+}
+
+// get returns the definition registered for key, or nil.
+func (r *sigRegistry) get(key [4]byte) *EventDef {
+	return (*r.defs.Load())[key]
+}
+
+// store registers defs, replacing existing entries when overwrite is set and
+// keeping them otherwise. Readers see either none or all of defs.
+func (r *sigRegistry) store(defs map[[4]byte]*EventDef, overwrite bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := *r.defs.Load()
+	next := make(map[[4]byte]*EventDef, len(old)+len(defs))
+	for k, v := range old {
+		next[k] = v
+	}
+	for k, v := range defs {
+		if _, exists := next[k]; exists && !overwrite {
+			continue
+		}
+		next[k] = v
+	}
+	r.defs.Store(&next)
+}
@@ -0,0 +1,114 @@
+package eventdecoder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func hashTopic(sig string) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(sig))
+	return h.Sum(nil)
+}
+
+func transferLog() ([][]byte, []byte) {
+	sig, _ := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	from, _ := hex.DecodeString("000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	to, _ := hex.DecodeString("0000000000000000000000004e83362442b8d1bec281594cea3050c8eb01311c")
+	data, _ := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000003e8")
+	return [][]byte{sig, from, to}, data
+}
+
+func TestRegistryConcurrentRegisterAndDecode(t *testing.T) {
+	topics, data := transferLog()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				ev, err := DecodeLog(topics, data)
+				if err != nil || ev.EventName != "Transfer" {
+					t.Errorf("DecodeLog = %v, %v", ev, err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		abi := fmt.Sprintf(`[{"type":"event","name":"Concurrent%d","inputs":[{"name":"v","type":"uint256","indexed":false}]}]`, i)
+		if err := RegisterABIJSON(abi); err != nil {
+			t.Fatalf("register: %v", err)
+		}
+	}
+	wg.Wait()
+
+	sig := hashTopic("Concurrent49(uint256)")
+	if got, ok := DecodeEventSignature(sig); !ok || got != "Concurrent49(uint256)" {
+		t.Fatalf("DecodeEventSignature = %q, %v", got, ok)
+	}
+}
+
+func TestRegistryStoreOverwrite(t *testing.T) {
+	r := newSigRegistry()
+	key := [4]byte{1, 2, 3, 4}
+	r.store(map[[4]byte]*EventDef{key: {Name: "First"}}, false)
+	r.store(map[[4]byte]*EventDef{key: {Name: "Second"}}, false)
+	if got := r.get(key).Name; got != "First" {
+		t.Fatalf("store without overwrite replaced entry: %s", got)
+	}
+	r.store(map[[4]byte]*EventDef{key: {Name: "Third"}}, true)
+	if got := r.get(key).Name; got != "Third" {
+		t.Fatalf("store with overwrite kept entry: %s", got)
+	}
+}
+
+// BenchmarkDecodeLogParallel measures DecodeLog throughput with all
+// goroutines decoding the same event, the worst case for a locked registry.
+// Run with -cpu 1,4,16 to see how it scales.
+func BenchmarkDecodeLogParallel(b *testing.B) {
+	topics, data := transferLog()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := DecodeLog(topics, data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkRegistryLookupParallel compares the registry lookup alone with
+// the single RWMutex-guarded map it replaced.
+func BenchmarkRegistryLookupParallel(b *testing.B) {
+	var key [4]byte
+	copy(key[:], hashTopic("Transfer(address,address,uint256)"))
+
+	b.Run("registry", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if registry.get(key) == nil {
+					b.Fatal("missing Transfer")
+				}
+			}
+		})
+	})
+	b.Run("rwmutex", func(b *testing.B) {
+		var mu sync.RWMutex
+		defs := *registry.defs.Load()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				mu.RLock()
+				def := defs[key]
+				mu.RUnlock()
+				if def == nil {
+					b.Fatal("missing Transfer")
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package eventdecoder
+
+import (
+	"fmt"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"golang.org/x/crypto/sha3"
+)
+
+// TopicForSignature returns the 32-byte topic0 of an event, the keccak256
+// hash of its canonical signature, for use in log filters and bloom
+// queries. Whitespace is ignored and type aliases such as "uint" are
+// canonicalized, so "Transfer(address, address, uint)" yields the same topic
+// as "Transfer(address,address,uint256)".
+//
+// Example:
+//
+//	topic, err := eventdecoder.TopicForSignature("Transfer(address,address,uint256)")
+func TopicForSignature(signature string) ([]byte, error) {
+	sig := strings.Join(strings.Fields(signature), "")
+	open := strings.IndexByte(sig, '(')
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return nil, fmt.Errorf("invalid event signature %q: expected Name(type,...)", signature)
+	}
+	var argTypes []string
+	if args := sig[open+1 : len(sig)-1]; args != "" {
+		argTypes = splitTopLevel(args)
+	}
+	return TopicForEvent(sig[:open], argTypes)
+}
+
+// TopicForEvent returns the topic0 of the event name with the given argument
+// types, in declaration order. Tuple arguments are given in their canonical
+// form, e.g. "(address,uint256)".
+//
+// Example:
+//
+//	topic, err := eventdecoder.TopicForEvent("Approval", []string{"address", "address", "uint256"})
+func TopicForEvent(name string, argTypes []string) ([]byte, error) {
+	if name == "" || strings.ContainsAny(name, "(), \t") {
+		return nil, fmt.Errorf("invalid event name %q", name)
+	}
+	canonical := make([]string, len(argTypes))
+	for i, t := range argTypes {
+		t = strings.Join(strings.Fields(t), "")
+		if t == "" {
+			return nil, fmt.Errorf("event %s: argument %d has no type", name, i)
+		}
+		// Tuples need their components to be resolved by NewType and are
+		// already canonical once whitespace is removed.
+		if !strings.HasPrefix(t, "(") {
+			typ, err := eABI.NewType(expandIntAlias(t), "", nil)
+			if err != nil {
+				return nil, fmt.Errorf("event %s: argument %d: %w", name, i, err)
+			}
+			t = typ.String()
+		}
+		canonical[i] = t
+	}
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(fmt.Sprintf("%s(%s)", name, strings.Join(canonical, ","))))
+	return hasher.Sum(nil), nil
+}
+
+// expandIntAlias rewrites the "int" and "uint" aliases, including array
+// forms such as "uint[]", to their 256-bit canonical types.
+func expandIntAlias(t string) string {
+	for _, alias := range []string{"uint", "int"} {
+		if t == alias || strings.HasPrefix(t, alias+"[") {
+			return alias + "256" + t[len(alias):]
+		}
+	}
+	return t
+}
+
+// splitTopLevel splits s on commas that are not nested inside parentheses.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// Topic returns the topic0 of a decoded event, reconstructed from its
+// canonical Signature. It fails for unknown events, whose Signature is only
+// the 4-byte selector.
+func (e *DecodedEvent) Topic() ([]byte, error) {
+	return TopicForSignature(e.Signature)
+}
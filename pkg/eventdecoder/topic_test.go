@@ -0,0 +1,47 @@
+package eventdecoder
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestTopicForSignature(t *testing.T) {
+	want, _ := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	for _, sig := range []string{
+		"Transfer(address,address,uint256)",
+		"Transfer(address, address, uint)",
+	} {
+		got, err := TopicForSignature(sig)
+		if err != nil {
+			t.Fatalf("%s: %v", sig, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s: got %x", sig, got)
+		}
+	}
+
+	got, err := TopicForEvent("Transfer", []string{"address", "address", "uint256"})
+	if err != nil || !bytes.Equal(got, want) {
+		t.Fatalf("TopicForEvent: %x, %v", got, err)
+	}
+
+	ev := &DecodedEvent{Signature: "Transfer(address,address,uint256)"}
+	if got, err := ev.Topic(); err != nil || !bytes.Equal(got, want) {
+		t.Fatalf("Topic: %x, %v", got, err)
+	}
+
+	// Tuple arguments are split at the top level only.
+	if _, err := TopicForSignature("Order((address,uint256),bytes32)"); err != nil {
+		t.Fatalf("tuple signature: %v", err)
+	}
+
+	for _, bad := range []string{"", "Transfer", "(address)", "Transfer(adress)", "Transfer(address,)"} {
+		if _, err := TopicForSignature(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+	if _, err := (&DecodedEvent{Signature: "0xddf252ad"}).Topic(); err == nil {
+		t.Fatalf("expected error for selector-only signature")
+	}
+}
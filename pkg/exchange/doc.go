@@ -0,0 +1,34 @@
+// Package exchange provides a high-level manager for TRON's native TRC10
+// exchange (the Bancor-style on-chain pairs created with ExchangeCreate),
+// distinct from smart-contract DEXes such as SunSwap.
+//
+// # Manager Features
+//
+// The exchange manager builds exchange transactions and queries pairs:
+//
+//	cli, _ := client.NewClient("grpc://grpc.trongrid.io:50051")
+//	defer cli.Close()
+//
+//	em := exchange.NewManager(cli)
+//
+//	// Inspect a pair
+//	ex, err := em.GetExchangeById(context.Background(), 1)
+//	if err != nil { /* handle */ }
+//
+//	// Sell 1 TRX for at least 10 units of the paired token
+//	txExt, err := em.ExchangeTransaction(context.Background(), owner, ex.ID, exchange.TRX, 1_000_000, 10)
+//	if err != nil { /* handle */ }
+//
+// Token ids are TRC10 asset ids in decimal ("1000001"), or TRX ("_") for
+// the TRX side of a pair. Amounts are in the token's smallest unit (SUN for
+// TRX).
+//
+// # Error Handling
+//
+// Common error types:
+//   - ErrInvalidAddress - Invalid owner address
+//   - ErrInvalidParameter - Invalid exchange id, token id or amount
+//   - ErrNotFound - No exchange with the requested id
+//
+// Always check for errors in production code.
+package exchange
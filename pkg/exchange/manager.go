@@ -0,0 +1,207 @@
+// Package exchange provides high-level native exchange functionality
+package exchange
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// TRX is the token id of the TRX side of an exchange pair.
+const TRX = "_"
+
+// minTRC10TokenID is the lowest id assigned to a TRC10 asset.
+const minTRC10TokenID = 1000001
+
+// ExchangeManager provides native exchange operations
+type ExchangeManager struct {
+	conn lowlevel.ConnProvider
+}
+
+// NewManager creates a new exchange manager
+func NewManager(conn lowlevel.ConnProvider) *ExchangeManager {
+	return &ExchangeManager{conn: conn}
+}
+
+// Exchange is a typed view of an on-chain exchange pair.
+type Exchange struct {
+	ID                 int64
+	Creator            *types.Address
+	CreateTime         time.Time
+	FirstTokenID       string
+	FirstTokenBalance  int64
+	SecondTokenID      string
+	SecondTokenBalance int64
+}
+
+// NewExchange converts the node's Exchange message into an Exchange.
+func NewExchange(ex *core.Exchange) *Exchange {
+	creator, _ := types.NewAddressFromBytes(ex.GetCreatorAddress())
+	return &Exchange{
+		ID:                 ex.GetExchangeId(),
+		Creator:            creator,
+		CreateTime:         time.UnixMilli(ex.GetCreateTime()),
+		FirstTokenID:       string(ex.GetFirstTokenId()),
+		FirstTokenBalance:  ex.GetFirstTokenBalance(),
+		SecondTokenID:      string(ex.GetSecondTokenId()),
+		SecondTokenBalance: ex.GetSecondTokenBalance(),
+	}
+}
+
+// ValidateTokenID checks that id is TRX ("_") or a decimal TRC10 asset id.
+func ValidateTokenID(id string) error {
+	if id == TRX {
+		return nil
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n < minTRC10TokenID || strconv.FormatInt(n, 10) != id {
+		return fmt.Errorf("%w: token id must be %q or a TRC10 asset id of at least %d, got %q", types.ErrInvalidParameter, TRX, minTRC10TokenID, id)
+	}
+	return nil
+}
+
+// CreateExchange creates an unsigned transaction opening a new exchange pair
+// funded with the given initial balances of both tokens. The node charges an
+// exchange creation fee on top of the deposited balances.
+func (m *ExchangeManager) CreateExchange(ctx context.Context, ownerAddress *types.Address, firstTokenID string, firstTokenBalance int64, secondTokenID string, secondTokenBalance int64) (*api.TransactionExtention, error) {
+	if ownerAddress == nil {
+		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
+	}
+	if err := ValidateTokenID(firstTokenID); err != nil {
+		return nil, err
+	}
+	if err := ValidateTokenID(secondTokenID); err != nil {
+		return nil, err
+	}
+	if firstTokenID == secondTokenID {
+		return nil, fmt.Errorf("%w: exchange tokens must differ, got %q twice", types.ErrInvalidParameter, firstTokenID)
+	}
+	if firstTokenBalance <= 0 || secondTokenBalance <= 0 {
+		return nil, fmt.Errorf("%w: initial balances must be positive", types.ErrInvalidAmount)
+	}
+
+	req := &core.ExchangeCreateContract{
+		OwnerAddress:       ownerAddress.Bytes(),
+		FirstTokenId:       []byte(firstTokenID),
+		FirstTokenBalance:  firstTokenBalance,
+		SecondTokenId:      []byte(secondTokenID),
+		SecondTokenBalance: secondTokenBalance,
+	}
+	return lowlevel.ExchangeCreate(m.conn, ctx, req)
+}
+
+// ExchangeInject creates an unsigned transaction adding quant of tokenID to
+// an exchange the owner created; the node adds the other token in proportion.
+func (m *ExchangeManager) ExchangeInject(ctx context.Context, ownerAddress *types.Address, exchangeID int64, tokenID string, quant int64) (*api.TransactionExtention, error) {
+	if err := validateExchangeInputs(ownerAddress, exchangeID, tokenID, quant); err != nil {
+		return nil, err
+	}
+	req := &core.ExchangeInjectContract{
+		OwnerAddress: ownerAddress.Bytes(),
+		ExchangeId:   exchangeID,
+		TokenId:      []byte(tokenID),
+		Quant:        quant,
+	}
+	return lowlevel.ExchangeInject(m.conn, ctx, req)
+}
+
+// ExchangeWithdraw creates an unsigned transaction removing quant of tokenID
+// from an exchange the owner created; the node removes the other token in
+// proportion.
+func (m *ExchangeManager) ExchangeWithdraw(ctx context.Context, ownerAddress *types.Address, exchangeID int64, tokenID string, quant int64) (*api.TransactionExtention, error) {
+	if err := validateExchangeInputs(ownerAddress, exchangeID, tokenID, quant); err != nil {
+		return nil, err
+	}
+	req := &core.ExchangeWithdrawContract{
+		OwnerAddress: ownerAddress.Bytes(),
+		ExchangeId:   exchangeID,
+		TokenId:      []byte(tokenID),
+		Quant:        quant,
+	}
+	return lowlevel.ExchangeWithdraw(m.conn, ctx, req)
+}
+
+// ExchangeTransaction creates an unsigned trade selling quant of tokenID on
+// the exchange for the pair's other token. expected is the minimum amount to
+// receive; the trade fails on chain if the pool would pay less.
+//
+// Example:
+//
+//	txExt, err := em.ExchangeTransaction(ctx, owner, 1, exchange.TRX, 1_000_000, 10)
+//	if err != nil {
+//	    // handle error
+//	}
+//	result, err := cli.SignAndBroadcast(ctx, txExt, client.DefaultBroadcastOptions(), signer)
+func (m *ExchangeManager) ExchangeTransaction(ctx context.Context, ownerAddress *types.Address, exchangeID int64, tokenID string, quant int64, expected int64) (*api.TransactionExtention, error) {
+	if err := validateExchangeInputs(ownerAddress, exchangeID, tokenID, quant); err != nil {
+		return nil, err
+	}
+	if expected <= 0 {
+		return nil, fmt.Errorf("%w: expected amount must be positive, got %d", types.ErrInvalidAmount, expected)
+	}
+	req := &core.ExchangeTransactionContract{
+		OwnerAddress: ownerAddress.Bytes(),
+		ExchangeId:   exchangeID,
+		TokenId:      []byte(tokenID),
+		Quant:        quant,
+		Expected:     expected,
+	}
+	return lowlevel.ExchangeTransaction(m.conn, ctx, req)
+}
+
+// GetExchangeById retrieves an exchange pair by id. An unknown id yields
+// types.ErrNotFound.
+func (m *ExchangeManager) GetExchangeById(ctx context.Context, exchangeID int64) (*Exchange, error) {
+	if exchangeID <= 0 {
+		return nil, fmt.Errorf("%w: exchange id must be positive, got %d", types.ErrInvalidParameter, exchangeID)
+	}
+	id := make([]byte, 8)
+	binary.BigEndian.PutUint64(id, uint64(exchangeID))
+
+	ex, err := lowlevel.GetExchangeById(m.conn, ctx, &api.BytesMessage{Value: id})
+	if err != nil {
+		return nil, err
+	}
+	if ex.GetExchangeId() != exchangeID {
+		return nil, fmt.Errorf("%w: exchange %d", types.ErrNotFound, exchangeID)
+	}
+	return NewExchange(ex), nil
+}
+
+// ListExchanges retrieves all exchange pairs.
+func (m *ExchangeManager) ListExchanges(ctx context.Context) ([]*Exchange, error) {
+	list, err := lowlevel.ListExchanges(m.conn, ctx, &api.EmptyMessage{})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Exchange, 0, len(list.GetExchanges()))
+	for _, ex := range list.GetExchanges() {
+		out = append(out, NewExchange(ex))
+	}
+	return out, nil
+}
+
+// validateExchangeInputs validates the parameters shared by inject, withdraw
+// and trade.
+func validateExchangeInputs(ownerAddress *types.Address, exchangeID int64, tokenID string, quant int64) error {
+	if ownerAddress == nil {
+		return fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
+	}
+	if exchangeID <= 0 {
+		return fmt.Errorf("%w: exchange id must be positive, got %d", types.ErrInvalidParameter, exchangeID)
+	}
+	if err := ValidateTokenID(tokenID); err != nil {
+		return err
+	}
+	if quant <= 0 {
+		return fmt.Errorf("%w: quant must be positive, got %d", types.ErrInvalidAmount, quant)
+	}
+	return nil
+}
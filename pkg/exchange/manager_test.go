@@ -0,0 +1,96 @@
+package exchange_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client"
+	"github.com/kslamph/tronlib/pkg/client/clienttest"
+	"github.com/kslamph/tronlib/pkg/exchange"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+type exchangeServer struct {
+	api.UnimplementedWalletServer
+	creator []byte
+	lastTx  *core.ExchangeTransactionContract
+}
+
+func (s *exchangeServer) ExchangeCreate(ctx context.Context, in *core.ExchangeCreateContract) (*api.TransactionExtention, error) {
+	return &api.TransactionExtention{Result: &api.Return{Result: true, Code: api.Return_SUCCESS}}, nil
+}
+
+func (s *exchangeServer) ExchangeTransaction(ctx context.Context, in *core.ExchangeTransactionContract) (*api.TransactionExtention, error) {
+	s.lastTx = in
+	return &api.TransactionExtention{Result: &api.Return{Result: true, Code: api.Return_SUCCESS}}, nil
+}
+
+func (s *exchangeServer) GetExchangeById(ctx context.Context, in *api.BytesMessage) (*core.Exchange, error) {
+	if len(in.GetValue()) != 8 || binary.BigEndian.Uint64(in.GetValue()) != 1 {
+		return &core.Exchange{}, nil
+	}
+	return &core.Exchange{
+		ExchangeId:         1,
+		CreatorAddress:     s.creator,
+		CreateTime:         1700000000000,
+		FirstTokenId:       []byte("1000001"),
+		FirstTokenBalance:  500,
+		SecondTokenId:      []byte("_"),
+		SecondTokenBalance: 1_000_000,
+	}, nil
+}
+
+func newExchangeClient(t *testing.T, impl api.WalletServer) *client.Client {
+	t.Helper()
+	return clienttest.NewClient(t, impl, client.WithTimeout(500*time.Millisecond), client.WithPool(1, 1))
+}
+
+func TestExchangeManager(t *testing.T) {
+	owner := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	srv := &exchangeServer{creator: owner.Bytes()}
+	m := exchange.NewManager(newExchangeClient(t, srv))
+	ctx := context.Background()
+
+	ex, err := m.GetExchangeById(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetExchangeById: %v", err)
+	}
+	if ex.ID != 1 || ex.Creator.String() != owner.String() || ex.FirstTokenID != "1000001" || ex.SecondTokenID != exchange.TRX || ex.SecondTokenBalance != 1_000_000 {
+		t.Fatalf("unexpected exchange %+v", ex)
+	}
+	if _, err := m.GetExchangeById(ctx, 2); !errors.Is(err, types.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := m.ExchangeTransaction(ctx, owner, 1, exchange.TRX, 1_000_000, 10); err != nil {
+		t.Fatalf("ExchangeTransaction: %v", err)
+	}
+	if string(srv.lastTx.GetTokenId()) != "_" || srv.lastTx.GetQuant() != 1_000_000 || srv.lastTx.GetExpected() != 10 {
+		t.Fatalf("unexpected trade request %v", srv.lastTx)
+	}
+
+	if _, err := m.CreateExchange(ctx, owner, "1000001", 100, exchange.TRX, 1_000_000); err != nil {
+		t.Fatalf("CreateExchange: %v", err)
+	}
+	if _, err := m.CreateExchange(ctx, owner, exchange.TRX, 100, exchange.TRX, 100); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for identical tokens, got %v", err)
+	}
+}
+
+func TestValidateTokenID(t *testing.T) {
+	for _, id := range []string{exchange.TRX, "1000001", "1002000"} {
+		if err := exchange.ValidateTokenID(id); err != nil {
+			t.Errorf("ValidateTokenID(%q): %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "TRX", "1000000", "01000001", "-1", "abc"} {
+		if err := exchange.ValidateTokenID(id); !errors.Is(err, types.ErrInvalidParameter) {
+			t.Errorf("ValidateTokenID(%q): expected ErrInvalidParameter, got %v", id, err)
+		}
+	}
+}
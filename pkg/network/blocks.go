@@ -0,0 +1,250 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// defaultBlockPollInterval matches TRON's 3 second block time.
+const defaultBlockPollInterval = 3 * time.Second
+
+// SubscribeOption configures SubscribeBlocks.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	pollInterval time.Duration
+	startBlock   int64
+}
+
+// WithPollInterval sets how often the node is polled for a new head block.
+// The default is 3 seconds.
+func WithPollInterval(d time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) { o.pollInterval = d }
+}
+
+// WithStartBlock makes the subscription start at the given block number and
+// backfill every block from there up to the current head before following it.
+// By default the subscription starts at the current head block.
+func WithStartBlock(num int64) SubscribeOption {
+	return func(o *subscribeOptions) { o.startBlock = num }
+}
+
+// SubscribeBlocks polls the node for new blocks and emits each block exactly
+// once, in ascending order.
+//
+// Blocks skipped between two polls are backfilled by number, so no block is
+// dropped. Transient RPC errors are reported on the error channel and the
+// failed step is retried at the next poll; the error channel is buffered and
+// errors are dropped if it is not drained. Both channels are closed when ctx
+// is cancelled.
+//
+// Example:
+//
+//	blocks, errs := nm.SubscribeBlocks(ctx, network.WithPollInterval(time.Second))
+//	for {
+//	    select {
+//	    case blk, ok := <-blocks:
+//	        if !ok {
+//	            return
+//	        }
+//	        fmt.Println(blk.GetBlockHeader().GetRawData().GetNumber())
+//	    case err := <-errs:
+//	        log.Println("poll error:", err)
+//	    }
+//	}
+func (m *NetworkManager) SubscribeBlocks(ctx context.Context, opts ...SubscribeOption) (<-chan *api.BlockExtention, <-chan error) {
+	so := &subscribeOptions{pollInterval: defaultBlockPollInterval, startBlock: -1}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(so)
+		}
+	}
+	if so.pollInterval <= 0 {
+		so.pollInterval = defaultBlockPollInterval
+	}
+
+	out := make(chan *api.BlockExtention)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		// next is the number of the next block to emit; -1 means "start at head"
+		next := so.startBlock
+		ticker := time.NewTicker(so.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			var err error
+			next, err = m.emitNewBlocks(ctx, next, out)
+			if err != nil && ctx.Err() == nil {
+				select {
+				case errCh <- err:
+				default:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return out, errCh
+}
+
+// emitNewBlocks emits every block from next up to the current head and returns
+// the number of the next block to emit. On error it returns the number of the
+// first block that was not emitted, so the caller can resume without gaps.
+func (m *NetworkManager) emitNewBlocks(ctx context.Context, next int64, out chan<- *api.BlockExtention) (int64, error) {
+	head, err := m.GetNowBlock(ctx)
+	if err != nil {
+		return next, err
+	}
+	headNum := blockNumber(head)
+	if next < 0 {
+		next = headNum
+	}
+
+	for ; next < headNum; next++ {
+		blk, err := m.GetBlockByNumber(ctx, next)
+		if err != nil {
+			return next, err
+		}
+		if !sendBlock(ctx, out, blk) {
+			return next, ctx.Err()
+		}
+	}
+
+	if next == headNum {
+		if !sendBlock(ctx, out, head) {
+			return next, ctx.Err()
+		}
+		next++
+	}
+	return next, nil
+}
+
+// sendBlock delivers blk unless ctx is cancelled first
+func sendBlock(ctx context.Context, out chan<- *api.BlockExtention, blk *api.BlockExtention) bool {
+	select {
+	case out <- blk:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// blockNumber returns the block height from a block's header
+func blockNumber(blk *api.BlockExtention) int64 {
+	return blk.GetBlockHeader().GetRawData().GetNumber()
+}
+
+// blockResult carries the outcome of a single block fetch
+type blockResult struct {
+	block *api.BlockExtention
+	err   error
+}
+
+// GetBlocksRange fetches blocks start..end (inclusive) in parallel and returns
+// them ordered by block number.
+//
+// At most concurrency requests are in flight at once; size the client's pool
+// (see client.WithPool) accordingly. The first error aborts the fetch. For large
+// ranges prefer GetBlocksRangeChan, which does not buffer the whole range.
+func (m *NetworkManager) GetBlocksRange(ctx context.Context, start, end int64, concurrency int) ([]*api.BlockExtention, error) {
+	if err := validateBlockRange(start, end); err != nil {
+		return nil, err
+	}
+
+	blocks, errs := m.GetBlocksRangeChan(ctx, start, end, concurrency)
+	result := make([]*api.BlockExtention, 0, end-start+1)
+	for blk := range blocks {
+		result = append(result, blk)
+	}
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBlocksRangeChan is the streaming variant of GetBlocksRange. Blocks are
+// fetched in parallel but emitted in ascending order, and only a window of
+// concurrency blocks is held in memory at a time.
+//
+// Both channels are closed when the range is exhausted, an error occurs, or
+// ctx is cancelled. At most one error is sent.
+func (m *NetworkManager) GetBlocksRangeChan(ctx context.Context, start, end int64, concurrency int) (<-chan *api.BlockExtention, <-chan error) {
+	out := make(chan *api.BlockExtention)
+	errCh := make(chan error, 1)
+
+	if err := validateBlockRange(start, end); err != nil {
+		errCh <- err
+		close(out)
+		close(errCh)
+		return out, errCh
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// pending preserves request order; its capacity bounds the fetch window
+		pending := make(chan chan blockResult, concurrency-1)
+		go func() {
+			defer close(pending)
+			for num := start; num <= end; num++ {
+				res := make(chan blockResult, 1)
+				select {
+				case pending <- res:
+				case <-ctx.Done():
+					return
+				}
+				go func(num int64) {
+					blk, err := m.GetBlockByNumber(ctx, num)
+					res <- blockResult{block: blk, err: err}
+				}(num)
+			}
+		}()
+
+		for res := range pending {
+			r := <-res
+			if r.err != nil {
+				errCh <- r.err
+				return
+			}
+			select {
+			case out <- r.block:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return out, errCh
+}
+
+// validateBlockRange checks an inclusive block range
+func validateBlockRange(start, end int64) error {
+	if start < 0 {
+		return fmt.Errorf("%w: start number must be non-negative", types.ErrInvalidParameter)
+	}
+	if end < start {
+		return fmt.Errorf("%w: end number must be greater than or equal to start number", types.ErrInvalidParameter)
+	}
+	return nil
+}
@@ -18,6 +18,17 @@
 //	info, err := nm.GetNodeInfo(context.Background())
 //	if err != nil { /* handle */ }
 //
+// # Block Subscription
+//
+// SubscribeBlocks polls for new blocks and emits each one exactly once,
+// backfilling any blocks skipped between polls:
+//
+//	blocks, errs := nm.SubscribeBlocks(ctx, network.WithPollInterval(time.Second))
+//	for blk := range blocks {
+//	    _ = blk // process block
+//	}
+//	_ = errs // transient errors; polling continues
+//
 // # Error Handling
 //
 // Common error types:
@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/kslamph/tronlib/pb/api"
 	"github.com/kslamph/tronlib/pb/core"
@@ -16,6 +18,12 @@ import (
 // NetworkManager provides high-level network operations
 type NetworkManager struct {
 	conn lowlevel.ConnProvider
+
+	// Cached chain parameters, see GetChainParametersMap
+	paramsMu       sync.Mutex
+	params         map[string]int64
+	paramsFetched  time.Time
+	paramsInflight *paramsFetch
 }
 
 // NewManager creates a new network manager
@@ -125,24 +133,9 @@ func (m *NetworkManager) GetNowBlock(ctx context.Context) (*api.BlockExtention,
 
 // GetTransactionInfoById retrieves transaction information by transaction ID (hex string)
 func (m *NetworkManager) GetTransactionInfoById(ctx context.Context, txIdHex string) (*core.TransactionInfo, error) {
-	if txIdHex == "" {
-		return nil, fmt.Errorf("%w: transaction ID cannot be empty", types.ErrInvalidParameter)
-	}
-
-	// Remove 0x prefix if present
-	if strings.HasPrefix(txIdHex, "0x") || strings.HasPrefix(txIdHex, "0X") {
-		txIdHex = txIdHex[2:]
-	}
-
-	// Validate hex string length (should be 64 characters for 32 bytes)
-	if len(txIdHex) != 64 {
-		return nil, fmt.Errorf("%w: transaction ID must be 64 hex characters (32 bytes), got %d", types.ErrInvalidParameter, len(txIdHex))
-	}
-
-	// Convert hex string to bytes
-	txIdBytes, err := hex.DecodeString(txIdHex)
+	txIdBytes, err := decodeTxID(txIdHex)
 	if err != nil {
-		return nil, fmt.Errorf("%w: invalid hex string: %w", types.ErrInvalidParameter, err)
+		return nil, err
 	}
 
 	req := &api.BytesMessage{Value: txIdBytes}
@@ -153,6 +146,20 @@ func (m *NetworkManager) GetTransactionInfoById(ctx context.Context, txIdHex str
 
 // GetTransactionById retrieves transaction by transaction ID (hex string)
 func (m *NetworkManager) GetTransactionById(ctx context.Context, txIdHex string) (*core.Transaction, error) {
+	txIdBytes, err := decodeTxID(txIdHex)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &api.BytesMessage{Value: txIdBytes}
+	return lowlevel.Call(m.conn, ctx, "get transaction by id", func(cl api.WalletClient, ctx context.Context) (*core.Transaction, error) {
+		return cl.GetTransactionById(ctx, req)
+	})
+}
+
+// decodeTxID validates a 64-character hex transaction ID, with optional 0x
+// prefix, and returns its bytes.
+func decodeTxID(txIdHex string) ([]byte, error) {
 	if txIdHex == "" {
 		return nil, fmt.Errorf("%w: transaction ID cannot be empty", types.ErrInvalidParameter)
 	}
@@ -172,9 +179,5 @@ func (m *NetworkManager) GetTransactionById(ctx context.Context, txIdHex string)
 	if err != nil {
 		return nil, fmt.Errorf("%w: invalid hex string: %w", types.ErrInvalidParameter, err)
 	}
-
-	req := &api.BytesMessage{Value: txIdBytes}
-	return lowlevel.Call(m.conn, ctx, "get transaction by id", func(cl api.WalletClient, ctx context.Context) (*core.Transaction, error) {
-		return cl.GetTransactionById(ctx, req)
-	})
+	return txIdBytes, nil
 }
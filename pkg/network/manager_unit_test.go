@@ -2,12 +2,15 @@ package network
 
 import (
 	"context"
+	"errors"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/kslamph/tronlib/pb/api"
 	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/test/bufconn"
 )
@@ -28,6 +31,10 @@ type fakeWalletServer struct {
 	GetNowBlock2Func                 func(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error)
 	GetTransactionInfoByIdFunc       func(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error)
 	GetTransactionByIdFunc           func(ctx context.Context, in *api.BytesMessage) (*core.Transaction, error)
+	GetEnergyPricesFunc              func(ctx context.Context, in *api.EmptyMessage) (*api.PricesResponseMessage, error)
+	GetBandwidthPricesFunc           func(ctx context.Context, in *api.EmptyMessage) (*api.PricesResponseMessage, error)
+	ListWitnessesFunc                func(ctx context.Context, in *api.EmptyMessage) (*api.WitnessList, error)
+	GetNextMaintenanceTimeFunc       func(ctx context.Context, in *api.EmptyMessage) (*api.NumberMessage, error)
 }
 
 func (s *fakeWalletServer) GetNodeInfo(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
@@ -107,6 +114,34 @@ func (s *fakeWalletServer) GetTransactionById(ctx context.Context, in *api.Bytes
 	return &core.Transaction{}, nil
 }
 
+func (s *fakeWalletServer) GetEnergyPrices(ctx context.Context, in *api.EmptyMessage) (*api.PricesResponseMessage, error) {
+	if s.GetEnergyPricesFunc != nil {
+		return s.GetEnergyPricesFunc(ctx, in)
+	}
+	return &api.PricesResponseMessage{}, nil
+}
+
+func (s *fakeWalletServer) GetBandwidthPrices(ctx context.Context, in *api.EmptyMessage) (*api.PricesResponseMessage, error) {
+	if s.GetBandwidthPricesFunc != nil {
+		return s.GetBandwidthPricesFunc(ctx, in)
+	}
+	return &api.PricesResponseMessage{}, nil
+}
+
+func (s *fakeWalletServer) ListWitnesses(ctx context.Context, in *api.EmptyMessage) (*api.WitnessList, error) {
+	if s.ListWitnessesFunc != nil {
+		return s.ListWitnessesFunc(ctx, in)
+	}
+	return &api.WitnessList{}, nil
+}
+
+func (s *fakeWalletServer) GetNextMaintenanceTime(ctx context.Context, in *api.EmptyMessage) (*api.NumberMessage, error) {
+	if s.GetNextMaintenanceTimeFunc != nil {
+		return s.GetNextMaintenanceTimeFunc(ctx, in)
+	}
+	return &api.NumberMessage{}, nil
+}
+
 type mockConnProvider struct {
 	conn *grpc.ClientConn
 }
@@ -166,6 +201,39 @@ func TestGetNodeInfo(t *testing.T) {
 	}
 }
 
+func TestGetNodeStatus(t *testing.T) {
+	fake := &fakeWalletServer{
+		GetNodeInfoFunc: func(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+			return &core.NodeInfo{
+				Block:               "Num:70000020,ID:00000000042c1d94aa",
+				SolidityBlock:       "Num:70000001,ID:00000000042c1d81bb",
+				CurrentConnectCount: 30,
+				ActiveConnectCount:  10,
+				PassiveConnectCount: 20,
+				TotalFlow:           123456,
+				ConfigNodeInfo:      &core.NodeInfo_ConfigNodeInfo{CodeVersion: "4.7.4", P2PVersion: "11111"},
+			}, nil
+		},
+	}
+	mgr, cleanup := setupTestServer(t, fake)
+	defer cleanup()
+
+	st, err := mgr.GetNodeStatus(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.BlockHeight != 70000020 || st.SolidityBlockHeight != 70000001 || st.SolidityLag != 19 {
+		t.Fatalf("unexpected heights: %+v", st)
+	}
+	if st.BlockID != "00000000042c1d94aa" || st.Version != "4.7.4" || st.ActiveConnectCount != 10 || st.PassiveConnectCount != 20 || st.TotalFlow != 123456 {
+		t.Fatalf("unexpected status: %+v", st)
+	}
+
+	if _, err := NewNodeStatus(&core.NodeInfo{Block: "garbage"}); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
 func TestGetChainParameters(t *testing.T) {
 	mgr, cleanup := setupTestServer(t, &fakeWalletServer{})
 	defer cleanup()
@@ -448,3 +516,339 @@ func TestGetTransactionById(t *testing.T) {
 		}
 	})
 }
+
+func TestGetChainParametersMap(t *testing.T) {
+	var calls int
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{
+		GetChainParametersFunc: func(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+			calls++
+			return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+				{Key: ParamEnergyFee, Value: 210},
+				{Key: ParamTransactionFee, Value: 1000},
+				{Key: ParamCreateAccountFee, Value: 100000},
+			}}, nil
+		},
+	})
+	defer cleanup()
+	ctx := context.Background()
+
+	params, err := mgr.GetChainParametersMap(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params[ParamEnergyFee] != 210 {
+		t.Errorf("expected energy fee 210, got %d", params[ParamEnergyFee])
+	}
+
+	// Mutating the returned map must not affect the cache
+	params[ParamEnergyFee] = 1
+
+	energyFee, err := mgr.GetEnergyFee(ctx)
+	if err != nil || energyFee != 210 {
+		t.Errorf("GetEnergyFee: got %d, %v", energyFee, err)
+	}
+	txFee, err := mgr.GetTransactionFee(ctx)
+	if err != nil || txFee != 1000 {
+		t.Errorf("GetTransactionFee: got %d, %v", txFee, err)
+	}
+	accountFee, err := mgr.GetCreateAccountFee(ctx)
+	if err != nil || accountFee != 100000 {
+		t.Errorf("GetCreateAccountFee: got %d, %v", accountFee, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected chain parameters to be fetched once, got %d", calls)
+	}
+}
+
+func TestGetChainParametersMap_ConcurrentRefreshSharesFetch(t *testing.T) {
+	var calls atomic.Int32
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{
+		GetChainParametersFunc: func(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+			if calls.Add(1) == 1 {
+				close(entered)
+			}
+			<-release
+			return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+				{Key: ParamEnergyFee, Value: 210},
+			}}, nil
+		},
+	})
+	defer cleanup()
+
+	// An expired cache entry must not let every caller refetch
+	mgr.params = map[string]int64{ParamEnergyFee: 100}
+	mgr.paramsFetched = time.Now().Add(-2 * chainParametersTTL)
+
+	const callers = 8
+	errs := make(chan error, callers)
+	fees := make(chan int64, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			fee, err := mgr.GetEnergyFee(context.Background())
+			errs <- err
+			fees <- fee
+		}()
+	}
+	<-entered
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < callers; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fee := <-fees; fee != 210 {
+			t.Errorf("expected refreshed energy fee 210, got %d", fee)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected one shared fetch, got %d", n)
+	}
+}
+
+func TestGetChainParameter_Missing(t *testing.T) {
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{})
+	defer cleanup()
+
+	_, err := mgr.GetEnergyFee(context.Background())
+	if !errors.Is(err, types.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func blockWithNumber(num int64) *api.BlockExtention {
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: num}}}
+}
+
+func TestSubscribeBlocks_BackfillsGaps(t *testing.T) {
+	// Head jumps 10 -> 13 -> 13 -> 16; every block in between must be emitted once.
+	heads := []int64{10, 13, 13, 16}
+	var polls int32
+	var failedOnce bool
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{
+		GetNowBlock2Func: func(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+			i := int(atomic.AddInt32(&polls, 1)) - 1
+			if i >= len(heads) {
+				i = len(heads) - 1
+			}
+			return blockWithNumber(heads[i]), nil
+		},
+		GetBlockByNum2Func: func(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+			if in.GetNum() == 14 && !failedOnce {
+				failedOnce = true
+				return nil, errors.New("transient")
+			}
+			return blockWithNumber(in.GetNum()), nil
+		},
+	})
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	blocks, errs := mgr.SubscribeBlocks(ctx, WithPollInterval(10*time.Millisecond))
+
+	var got []int64
+	for len(got) < 7 {
+		select {
+		case blk := <-blocks:
+			got = append(got, blockNumber(blk))
+		case <-errs:
+		case <-ctx.Done():
+			t.Fatalf("timed out, got blocks %v", got)
+		}
+	}
+	for i, num := range got {
+		if num != int64(10+i) {
+			t.Fatalf("expected contiguous blocks from 10, got %v", got)
+		}
+	}
+
+	cancel()
+	for range blocks {
+	}
+}
+
+func TestSubscribeBlocks_StartBlock(t *testing.T) {
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{
+		GetNowBlock2Func: func(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+			return blockWithNumber(5), nil
+		},
+		GetBlockByNum2Func: func(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+			return blockWithNumber(in.GetNum()), nil
+		},
+	})
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	blocks, _ := mgr.SubscribeBlocks(ctx, WithStartBlock(2), WithPollInterval(10*time.Millisecond))
+	for want := int64(2); want <= 5; want++ {
+		blk := <-blocks
+		if got := blockNumber(blk); got != want {
+			t.Fatalf("expected block %d, got %d", want, got)
+		}
+	}
+}
+
+func TestGetBlocksRange(t *testing.T) {
+	var inFlight, maxInFlight int32
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{
+		GetBlockByNum2Func: func(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				cur := atomic.LoadInt32(&maxInFlight)
+				if n <= cur || atomic.CompareAndSwapInt32(&maxInFlight, cur, n) {
+					break
+				}
+			}
+			// Later blocks return sooner to exercise reordering
+			time.Sleep(time.Duration(30-in.GetNum()) * time.Millisecond)
+			return blockWithNumber(in.GetNum()), nil
+		},
+	})
+	defer cleanup()
+
+	blocks, err := mgr.GetBlocksRange(context.Background(), 5, 20, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(blocks) != 16 {
+		t.Fatalf("expected 16 blocks, got %d", len(blocks))
+	}
+	for i, blk := range blocks {
+		if got := blockNumber(blk); got != int64(5+i) {
+			t.Fatalf("block %d out of order: got %d", i, got)
+		}
+	}
+	if maxInFlight > 4 {
+		t.Errorf("expected at most 4 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestGetBlocksRange_Errors(t *testing.T) {
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{
+		GetBlockByNum2Func: func(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+			if in.GetNum() == 7 {
+				return nil, errors.New("boom")
+			}
+			return blockWithNumber(in.GetNum()), nil
+		},
+	})
+	defer cleanup()
+	ctx := context.Background()
+
+	if _, err := mgr.GetBlocksRange(ctx, 5, 10, 2); err == nil {
+		t.Fatal("expected error from failing block fetch")
+	}
+	if _, err := mgr.GetBlocksRange(ctx, 10, 5, 2); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for inverted range, got %v", err)
+	}
+	if _, err := mgr.GetBlocksRange(ctx, -1, 5, 2); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for negative start, got %v", err)
+	}
+}
+
+func TestGetResourcePrices(t *testing.T) {
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{
+		GetEnergyPricesFunc: func(ctx context.Context, in *api.EmptyMessage) (*api.PricesResponseMessage, error) {
+			return &api.PricesResponseMessage{Prices: "0:100,1575871200000:10,1606537680000:40"}, nil
+		},
+		GetBandwidthPricesFunc: func(ctx context.Context, in *api.EmptyMessage) (*api.PricesResponseMessage, error) {
+			return &api.PricesResponseMessage{Prices: "0:10;1606537680000:1000"}, nil
+		},
+	})
+	defer cleanup()
+	ctx := context.Background()
+
+	energy, err := mgr.GetEnergyPrices(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(energy) != 3 {
+		t.Fatalf("expected 3 energy price points, got %d", len(energy))
+	}
+	if energy[1].Price != 10 || !energy[1].Timestamp.Equal(time.UnixMilli(1575871200000)) {
+		t.Errorf("unexpected energy price point: %+v", energy[1])
+	}
+
+	bandwidth, err := mgr.GetBandwidthPrices(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bandwidth) != 2 || bandwidth[1].Price != 1000 {
+		t.Errorf("unexpected bandwidth prices: %+v", bandwidth)
+	}
+}
+
+func TestParsePrices(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"empty", "", 0, false},
+		{"single", "0:420", 1, false},
+		{"comma separated", "0:100,1:200", 2, false},
+		{"semicolon separated", "0:100;1:200", 2, false},
+		{"missing colon", "0100", 0, true},
+		{"bad timestamp", "x:100", 0, true},
+		{"bad price", "0:y", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePrices(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePrices(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && len(got) != tt.want {
+				t.Errorf("ParsePrices(%q) returned %d points, want %d", tt.input, len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestGetWitnessStats(t *testing.T) {
+	low := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	high := types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	fake := &fakeWalletServer{
+		ListWitnessesFunc: func(ctx context.Context, in *api.EmptyMessage) (*api.WitnessList, error) {
+			return &api.WitnessList{Witnesses: []*core.Witness{
+				{Address: low.Bytes(), VoteCount: 10, Url: "https://low.example"},
+				{Address: high.Bytes(), VoteCount: 1000, IsJobs: true, TotalProduced: 990, TotalMissed: 10, LatestBlockNum: 70000000, LatestSlotNum: 580000000},
+			}}, nil
+		},
+		GetNextMaintenanceTimeFunc: func(ctx context.Context, in *api.EmptyMessage) (*api.NumberMessage, error) {
+			return &api.NumberMessage{Num: 1700006400000}, nil
+		},
+	}
+	mgr, cleanup := setupTestServer(t, fake)
+	defer cleanup()
+
+	stats, err := mgr.GetWitnessStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats) != 2 || stats[0].Address.String() != high.String() || !stats[0].Active {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if stats[0].LatestBlockNum != 70000000 || stats[0].MissRate() != 0.01 {
+		t.Fatalf("unexpected production record: %+v", stats[0])
+	}
+	if stats[1].URL != "https://low.example" || stats[1].MissRate() != 0 {
+		t.Fatalf("unexpected second witness: %+v", stats[1])
+	}
+
+	next, err := mgr.GetNextMaintenanceTime(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.UnixMilli() != 1700006400000 {
+		t.Fatalf("unexpected maintenance time %v", next)
+	}
+}
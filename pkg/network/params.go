@@ -0,0 +1,133 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// Chain parameter keys as returned by GetChainParameters.
+const (
+	ParamEnergyFee        = "getEnergyFee"
+	ParamTransactionFee   = "getTransactionFee"
+	ParamCreateAccountFee = "getCreateAccountFee"
+	// ParamCreateNewAccountFeeInSystemContract is the fee in SUN a transfer
+	// pays on top of bandwidth when it activates the recipient account.
+	ParamCreateNewAccountFeeInSystemContract = "getCreateNewAccountFeeInSystemContract"
+	// ParamCreateNewAccountBandwidthRate multiplies the bandwidth charged to
+	// staked bandwidth for a transaction that activates an account.
+	ParamCreateNewAccountBandwidthRate = "getCreateNewAccountBandwidthRate"
+)
+
+// chainParametersTTL is how long GetChainParametersMap serves cached values.
+// Chain parameters only change through governance proposals, so a short TTL
+// is enough to avoid repeated round trips without serving stale values for long.
+const chainParametersTTL = time.Minute
+
+// paramsFetch is a chain parameters request shared by concurrent callers of
+// GetChainParametersMap; done is closed once params and err are set.
+type paramsFetch struct {
+	done   chan struct{}
+	params map[string]int64
+	err    error
+}
+
+// GetChainParametersMap returns the chain parameters as a key/value map.
+//
+// Results are cached on the manager for a short TTL, so reuse the same
+// NetworkManager (such as the one returned by client.Client.Network) to
+// benefit from caching. The cache lock is not held while fetching, so a slow
+// refresh does not block callers that are served from the cache. Callers that
+// miss the cache while a refresh is running wait for it and share its result.
+func (m *NetworkManager) GetChainParametersMap(ctx context.Context) (map[string]int64, error) {
+	m.paramsMu.Lock()
+	if m.params != nil && time.Since(m.paramsFetched) < chainParametersTTL {
+		defer m.paramsMu.Unlock()
+		return copyParams(m.params), nil
+	}
+	if f := m.paramsInflight; f != nil {
+		m.paramsMu.Unlock()
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if f.err != nil {
+			return nil, f.err
+		}
+		return copyParams(f.params), nil
+	}
+	f := &paramsFetch{done: make(chan struct{})}
+	m.paramsInflight = f
+	m.paramsMu.Unlock()
+
+	fetched := time.Now()
+	f.params, f.err = m.fetchChainParameters(ctx)
+
+	m.paramsMu.Lock()
+	m.paramsInflight = nil
+	// Never replace a cached result that is newer than this one
+	if f.err == nil && fetched.After(m.paramsFetched) {
+		m.params = f.params
+		m.paramsFetched = fetched
+	}
+	m.paramsMu.Unlock()
+	close(f.done)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	return copyParams(f.params), nil
+}
+
+// fetchChainParameters requests the chain parameters from the node
+func (m *NetworkManager) fetchChainParameters(ctx context.Context) (map[string]int64, error) {
+	params, err := m.GetChainParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]int64, len(params.GetChainParameter()))
+	for _, p := range params.GetChainParameter() {
+		result[p.GetKey()] = p.GetValue()
+	}
+	return result, nil
+}
+
+// GetEnergyFee returns the current energy price in SUN per unit of energy.
+func (m *NetworkManager) GetEnergyFee(ctx context.Context) (int64, error) {
+	return m.getChainParameter(ctx, ParamEnergyFee)
+}
+
+// GetTransactionFee returns the current bandwidth price in SUN per byte.
+func (m *NetworkManager) GetTransactionFee(ctx context.Context) (int64, error) {
+	return m.getChainParameter(ctx, ParamTransactionFee)
+}
+
+// GetCreateAccountFee returns the fee in SUN charged for creating a new account.
+func (m *NetworkManager) GetCreateAccountFee(ctx context.Context) (int64, error) {
+	return m.getChainParameter(ctx, ParamCreateAccountFee)
+}
+
+// getChainParameter looks up a single chain parameter by key
+func (m *NetworkManager) getChainParameter(ctx context.Context, key string) (int64, error) {
+	params, err := m.GetChainParametersMap(ctx)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := params[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: chain parameter %s", types.ErrNotFound, key)
+	}
+	return value, nil
+}
+
+// copyParams returns a copy so callers cannot mutate the cached map
+func copyParams(params map[string]int64) map[string]int64 {
+	out := make(map[string]int64, len(params))
+	for k, v := range params {
+		out[k] = v
+	}
+	return out
+}
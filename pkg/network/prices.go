@@ -0,0 +1,74 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// PricePoint is a resource price that took effect at a point in time.
+// Price is in SUN per unit (energy) or per byte (bandwidth).
+type PricePoint struct {
+	Timestamp time.Time
+	Price     int64
+}
+
+// GetEnergyPrices returns the history of energy prices, oldest first.
+//
+// Use the last entry whose Timestamp is not after a transaction's block time
+// to price that transaction.
+func (m *NetworkManager) GetEnergyPrices(ctx context.Context) ([]PricePoint, error) {
+	req := &api.EmptyMessage{}
+	resp, err := lowlevel.Call(m.conn, ctx, "get energy prices", func(cl api.WalletClient, ctx context.Context) (*api.PricesResponseMessage, error) {
+		return cl.GetEnergyPrices(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrices(resp.GetPrices())
+}
+
+// GetBandwidthPrices returns the history of bandwidth prices, oldest first.
+func (m *NetworkManager) GetBandwidthPrices(ctx context.Context) ([]PricePoint, error) {
+	req := &api.EmptyMessage{}
+	resp, err := lowlevel.Call(m.conn, ctx, "get bandwidth prices", func(cl api.WalletClient, ctx context.Context) (*api.PricesResponseMessage, error) {
+		return cl.GetBandwidthPrices(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrices(resp.GetPrices())
+}
+
+// ParsePrices parses a node price history string of "timestamp:price" pairs,
+// where timestamp is in milliseconds. Pairs may be separated by commas or
+// semicolons.
+func ParsePrices(prices string) ([]PricePoint, error) {
+	fields := strings.FieldsFunc(prices, func(r rune) bool {
+		return r == ',' || r == ';'
+	})
+
+	result := make([]PricePoint, 0, len(fields))
+	for _, field := range fields {
+		ts, price, ok := strings.Cut(strings.TrimSpace(field), ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed price entry %q", types.ErrInvalidParameter, field)
+		}
+		millis, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid timestamp in price entry %q: %w", types.ErrInvalidParameter, field, err)
+		}
+		value, err := strconv.ParseInt(price, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid price in price entry %q: %w", types.ErrInvalidParameter, field, err)
+		}
+		result = append(result, PricePoint{Timestamp: time.UnixMilli(millis), Price: value})
+	}
+	return result, nil
+}
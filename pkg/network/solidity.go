@@ -0,0 +1,58 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// The methods below query the WalletSolidity service, which only returns
+// solidified (irreversible) data. The manager's connection must point at a
+// node that serves it, for example a solidity node or the solidity gRPC port
+// of a full node (50061 by default, grpc.trongrid.io:50052 on TronGrid):
+//
+//	solid, err := client.NewClient("grpc://grpc.trongrid.io:50052")
+//	if err != nil {
+//	    // handle error
+//	}
+//	block, err := solid.Network().GetNowBlockSolidity(ctx)
+
+// GetNowBlockSolidity retrieves the latest solidified block.
+func (m *NetworkManager) GetNowBlockSolidity(ctx context.Context) (*api.BlockExtention, error) {
+	req := &api.EmptyMessage{}
+	return lowlevel.SolidityCall(m.conn, ctx, "get now block2 (solidity)", func(cl api.WalletSolidityClient, ctx context.Context) (*api.BlockExtention, error) {
+		return cl.GetNowBlock2(ctx, req)
+	})
+}
+
+// GetBlockByNumSolidity retrieves a solidified block by its number. Blocks
+// that are not yet solidified are returned empty by the node.
+func (m *NetworkManager) GetBlockByNumSolidity(ctx context.Context, blockNumber int64) (*api.BlockExtention, error) {
+	if blockNumber < 0 {
+		return nil, fmt.Errorf("%w: block number must be non-negative", types.ErrInvalidParameter)
+	}
+
+	req := &api.NumberMessage{Num: blockNumber}
+	return lowlevel.SolidityCall(m.conn, ctx, "get block by num2 (solidity)", func(cl api.WalletSolidityClient, ctx context.Context) (*api.BlockExtention, error) {
+		return cl.GetBlockByNum2(ctx, req)
+	})
+}
+
+// GetTransactionInfoByIdSolidity retrieves transaction information by
+// transaction ID (hex string) from the solidified chain. An empty result
+// means the transaction is not yet confirmed.
+func (m *NetworkManager) GetTransactionInfoByIdSolidity(ctx context.Context, txIdHex string) (*core.TransactionInfo, error) {
+	txIdBytes, err := decodeTxID(txIdHex)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &api.BytesMessage{Value: txIdBytes}
+	return lowlevel.SolidityCall(m.conn, ctx, "get transaction info by id (solidity)", func(cl api.WalletSolidityClient, ctx context.Context) (*core.TransactionInfo, error) {
+		return cl.GetTransactionInfoById(ctx, req)
+	})
+}
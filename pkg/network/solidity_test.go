@@ -0,0 +1,96 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeSolidityServer struct {
+	api.UnimplementedWalletSolidityServer
+}
+
+func (s *fakeSolidityServer) GetNowBlock2(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: 500}}}, nil
+}
+
+func (s *fakeSolidityServer) GetBlockByNum2(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: in.GetNum()}}}, nil
+}
+
+func (s *fakeSolidityServer) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+	return &core.TransactionInfo{Id: in.GetValue(), BlockNumber: 480}, nil
+}
+
+func setupSolidityTestServer(t *testing.T) (*NetworkManager, func()) {
+	t.Helper()
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	api.RegisterWalletSolidityServer(srv, &fakeSolidityServer{})
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.Dial("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufnet: %v", err)
+	}
+
+	mgr := NewManager(&mockConnProvider{conn: conn})
+	cleanup := func() {
+		conn.Close()
+		srv.Stop()
+		lis.Close()
+	}
+	return mgr, cleanup
+}
+
+func TestSolidityAccessors(t *testing.T) {
+	mgr, cleanup := setupSolidityTestServer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	blk, err := mgr.GetNowBlockSolidity(ctx)
+	if err != nil || blk.GetBlockHeader().GetRawData().GetNumber() != 500 {
+		t.Fatalf("GetNowBlockSolidity = %v, %v", blk, err)
+	}
+
+	blk, err = mgr.GetBlockByNumSolidity(ctx, 42)
+	if err != nil || blk.GetBlockHeader().GetRawData().GetNumber() != 42 {
+		t.Fatalf("GetBlockByNumSolidity = %v, %v", blk, err)
+	}
+	if _, err := mgr.GetBlockByNumSolidity(ctx, -1); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+
+	info, err := mgr.GetTransactionInfoByIdSolidity(ctx, "0x"+testTxID)
+	if err != nil || info.GetBlockNumber() != 480 {
+		t.Fatalf("GetTransactionInfoByIdSolidity = %v, %v", info, err)
+	}
+	if _, err := mgr.GetTransactionInfoByIdSolidity(ctx, "abc"); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestSolidityAccessorsRequireSolidityService(t *testing.T) {
+	// A full-node-only endpoint does not serve WalletSolidity.
+	mgr, cleanup := setupTestServer(t, &fakeWalletServer{})
+	defer cleanup()
+
+	_, err := mgr.GetNowBlockSolidity(context.Background())
+	if status.Code(errors.Unwrap(err)) != codes.Unimplemented {
+		t.Fatalf("expected Unimplemented, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// NodeStatus is a typed summary of the commonly needed NodeInfo fields.
+type NodeStatus struct {
+	// BlockHeight and BlockID identify the node's latest block.
+	BlockHeight int64
+	BlockID     string
+	// SolidityBlockHeight and SolidityBlockID identify the latest
+	// irreversible block.
+	SolidityBlockHeight int64
+	SolidityBlockID     string
+	// SolidityLag is BlockHeight - SolidityBlockHeight. On a healthy node it
+	// stays around 19 blocks (two thirds of the 27 super representatives).
+	SolidityLag int64
+
+	CurrentConnectCount int32
+	ActiveConnectCount  int32
+	PassiveConnectCount int32
+	// TotalFlow is the node's total network traffic in bytes.
+	TotalFlow int64
+
+	// Version is the java-tron code version, e.g. "4.7.4".
+	Version    string
+	P2PVersion string
+}
+
+// GetNodeStatus fetches NodeInfo and extracts block heights, connection
+// counts and version into a NodeStatus.
+func (m *NetworkManager) GetNodeStatus(ctx context.Context) (*NodeStatus, error) {
+	info, err := m.GetNodeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewNodeStatus(info)
+}
+
+// NewNodeStatus builds a NodeStatus from a NodeInfo message.
+func NewNodeStatus(info *core.NodeInfo) (*NodeStatus, error) {
+	if info == nil {
+		return nil, fmt.Errorf("%w: node info is nil", types.ErrInvalidParameter)
+	}
+	s := &NodeStatus{
+		CurrentConnectCount: info.GetCurrentConnectCount(),
+		ActiveConnectCount:  info.GetActiveConnectCount(),
+		PassiveConnectCount: info.GetPassiveConnectCount(),
+		TotalFlow:           info.GetTotalFlow(),
+		Version:             info.GetConfigNodeInfo().GetCodeVersion(),
+		P2PVersion:          info.GetConfigNodeInfo().GetP2PVersion(),
+	}
+	var err error
+	if s.BlockHeight, s.BlockID, err = parseNodeBlock(info.GetBlock()); err != nil {
+		return nil, err
+	}
+	if s.SolidityBlockHeight, s.SolidityBlockID, err = parseNodeBlock(info.GetSolidityBlock()); err != nil {
+		return nil, err
+	}
+	s.SolidityLag = s.BlockHeight - s.SolidityBlockHeight
+	return s, nil
+}
+
+// parseNodeBlock parses the "Num:<number>,ID:<hash>" block description used
+// by NodeInfo. An empty string yields zero values.
+func parseNodeBlock(block string) (int64, string, error) {
+	if block == "" {
+		return 0, "", nil
+	}
+	var num int64
+	var id string
+	for _, field := range strings.Split(block, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), ":")
+		if !ok {
+			return 0, "", fmt.Errorf("%w: malformed block entry %q", types.ErrInvalidParameter, block)
+		}
+		switch key {
+		case "Num":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, "", fmt.Errorf("%w: invalid block number in %q: %w", types.ErrInvalidParameter, block, err)
+			}
+			num = n
+		case "ID":
+			id = value
+		}
+	}
+	return num, id, nil
+}
@@ -0,0 +1,95 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// WitnessStat is a typed view of a witness (super representative candidate)
+// and its block production record.
+type WitnessStat struct {
+	Address   *types.Address
+	URL       string
+	VoteCount int64
+	// Active reports whether the witness is currently producing blocks, i.e.
+	// is one of the elected super representatives.
+	Active bool
+
+	TotalProduced  int64
+	TotalMissed    int64
+	LatestBlockNum int64
+	LatestSlotNum  int64
+}
+
+// MissRate returns the fraction of scheduled blocks the witness missed, or
+// zero if it has never been scheduled.
+func (w *WitnessStat) MissRate() float64 {
+	total := w.TotalProduced + w.TotalMissed
+	if total == 0 {
+		return 0
+	}
+	return float64(w.TotalMissed) / float64(total)
+}
+
+// NewWitnessStat converts the node's Witness message into a WitnessStat.
+func NewWitnessStat(w *core.Witness) *WitnessStat {
+	addr, _ := types.NewAddressFromBytes(w.GetAddress())
+	return &WitnessStat{
+		Address:        addr,
+		URL:            w.GetUrl(),
+		VoteCount:      w.GetVoteCount(),
+		Active:         w.GetIsJobs(),
+		TotalProduced:  w.GetTotalProduced(),
+		TotalMissed:    w.GetTotalMissed(),
+		LatestBlockNum: w.GetLatestBlockNum(),
+		LatestSlotNum:  w.GetLatestSlotNum(),
+	}
+}
+
+// GetWitnessStats lists all witnesses with their block production counts,
+// ordered by vote count, highest first, which is the election ranking.
+//
+// Example:
+//
+//	stats, err := nm.GetWitnessStats(ctx)
+//	if err != nil {
+//	    // handle error
+//	}
+//	for _, w := range stats {
+//	    if w.Active && w.MissRate() > 0.01 {
+//	        fmt.Printf("%s missed %d blocks\n", w.Address, w.TotalMissed)
+//	    }
+//	}
+func (m *NetworkManager) GetWitnessStats(ctx context.Context) ([]WitnessStat, error) {
+	list, err := lowlevel.ListWitnesses(m.conn, ctx, &api.EmptyMessage{})
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]WitnessStat, 0, len(list.GetWitnesses()))
+	for _, w := range list.GetWitnesses() {
+		stats = append(stats, *NewWitnessStat(w))
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return stats[i].VoteCount > stats[j].VoteCount })
+	return stats, nil
+}
+
+// GetNextMaintenanceTime returns when the next maintenance period starts.
+// Votes cast before then, and approved parameter changes, take effect at
+// that boundary.
+func (m *NetworkManager) GetNextMaintenanceTime(ctx context.Context) (time.Time, error) {
+	msg, err := lowlevel.GetNextMaintenanceTime(m.conn, ctx, &api.EmptyMessage{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if msg.GetNum() <= 0 {
+		return time.Time{}, fmt.Errorf("%w: node returned invalid next maintenance time %d", types.ErrInvalidParameter, msg.GetNum())
+	}
+	return time.UnixMilli(msg.GetNum()), nil
+}
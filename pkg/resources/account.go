@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// AccountResources is a typed view of an account's bandwidth and energy.
+//
+// Bandwidth comes from two pools: the daily free allowance every activated
+// account receives, and bandwidth obtained by staking TRX (or delegated to the
+// account). The Bandwidth* fields are the sum of both. Available values are
+// limit minus used, never negative.
+type AccountResources struct {
+	FreeBandwidthLimit     int64
+	FreeBandwidthUsed      int64
+	FreeBandwidthAvailable int64
+
+	StakedBandwidthLimit     int64
+	StakedBandwidthUsed      int64
+	StakedBandwidthAvailable int64
+
+	BandwidthLimit     int64
+	BandwidthUsed      int64
+	BandwidthAvailable int64
+
+	EnergyLimit     int64
+	EnergyUsed      int64
+	EnergyAvailable int64
+
+	// TronPowerLimit and TronPowerUsed describe voting power.
+	TronPowerLimit int64
+	TronPowerUsed  int64
+}
+
+// NewAccountResources converts the node's AccountResourceMessage into
+// AccountResources.
+func NewAccountResources(msg *api.AccountResourceMessage) *AccountResources {
+	r := &AccountResources{
+		FreeBandwidthLimit:   msg.GetFreeNetLimit(),
+		FreeBandwidthUsed:    msg.GetFreeNetUsed(),
+		StakedBandwidthLimit: msg.GetNetLimit(),
+		StakedBandwidthUsed:  msg.GetNetUsed(),
+		EnergyLimit:          msg.GetEnergyLimit(),
+		EnergyUsed:           msg.GetEnergyUsed(),
+		TronPowerLimit:       msg.GetTronPowerLimit(),
+		TronPowerUsed:        msg.GetTronPowerUsed(),
+	}
+	r.FreeBandwidthAvailable = available(r.FreeBandwidthLimit, r.FreeBandwidthUsed)
+	r.StakedBandwidthAvailable = available(r.StakedBandwidthLimit, r.StakedBandwidthUsed)
+	r.BandwidthLimit = r.FreeBandwidthLimit + r.StakedBandwidthLimit
+	r.BandwidthUsed = r.FreeBandwidthUsed + r.StakedBandwidthUsed
+	r.BandwidthAvailable = r.FreeBandwidthAvailable + r.StakedBandwidthAvailable
+	r.EnergyAvailable = available(r.EnergyLimit, r.EnergyUsed)
+	return r
+}
+
+// GetAccountResources fetches the account's resource usage and returns it as
+// AccountResources with the available amounts already computed.
+func (m *ResourcesManager) GetAccountResources(ctx context.Context, address *types.Address) (*AccountResources, error) {
+	if address == nil {
+		return nil, fmt.Errorf("%w: address cannot be nil", types.ErrInvalidAddress)
+	}
+
+	msg, err := lowlevel.GetAccountResource(m.conn, ctx, &core.Account{Address: address.Bytes()})
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountResources(msg), nil
+}
+
+// available returns limit - used, floored at zero.
+func available(limit, used int64) int64 {
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
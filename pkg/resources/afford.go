@@ -0,0 +1,202 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/network"
+	"github.com/kslamph/tronlib/pkg/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// Bandwidth accounting constants used by the node.
+const (
+	// resultSizeInTx is the fixed number of bytes the node adds for the
+	// transaction result when charging bandwidth.
+	resultSizeInTx = 64
+	// signatureSize is the serialized size of one signature entry.
+	signatureSize = 67
+)
+
+// AffordabilityReport describes whether an account can pay for a transaction
+// with its current resources and balance. Amounts are in SUN.
+type AffordabilityReport struct {
+	// Resources is the account's resource state the report is based on.
+	Resources *AccountResources
+	// Balance is the account's TRX balance.
+	Balance int64
+	// Value is the TRX moved by the transaction itself (transfer amount or
+	// call value), which must be covered by Balance on top of any fees.
+	Value int64
+
+	// Bandwidth is the estimated bandwidth in bytes, assuming one signature
+	// if the transaction is not signed yet.
+	Bandwidth int64
+	// BandwidthCovered reports whether staked or free bandwidth covers the
+	// transaction. The node charges either pool in full, never a mix. When
+	// the transaction activates an account only staked bandwidth counts, at
+	// the getCreateNewAccountBandwidthRate multiple.
+	BandwidthCovered bool
+	// BandwidthFee is the TRX burned for bandwidth when it is not covered:
+	// Bandwidth times the bandwidth price, or the flat getCreateAccountFee
+	// when the transaction activates an account.
+	BandwidthFee int64
+
+	// CreatesAccount reports whether the transaction activates its recipient,
+	// a TRX or TRC10 transfer to an account that does not exist yet.
+	CreatesAccount bool
+	// AccountCreationFee is the getCreateNewAccountFeeInSystemContract fee
+	// burned when CreatesAccount is true, on top of BandwidthFee.
+	AccountCreationFee int64
+
+	// Energy is the simulated energy usage; zero for non-contract transactions.
+	Energy int64
+	// EnergyCovered reports whether the account's available energy covers Energy.
+	EnergyCovered bool
+	// EnergyFee is the TRX burned for energy not covered by available energy.
+	EnergyFee int64
+
+	// TRXBurned is BandwidthFee plus AccountCreationFee plus EnergyFee.
+	TRXBurned int64
+	// FeeLimit is the transaction's fee limit.
+	FeeLimit int64
+	// FeeLimitSufficient reports whether FeeLimit allows EnergyFee to be
+	// burned; when false the call runs out of energy and reverts.
+	FeeLimitSufficient bool
+
+	// CanAfford is true when the fee limit is sufficient and Balance covers
+	// TRXBurned plus Value.
+	CanAfford bool
+}
+
+// CanAfford estimates whether from can pay for txExt right now. It combines
+// the account's resources and balance, the transaction's bandwidth, the fees
+// for activating a new recipient account and, for smart contract calls, the
+// energy used by simulating the call. from must be the transaction's owner;
+// otherwise an error wrapping types.ErrInvalidParameter is returned.
+//
+// The estimate ignores energy paid by the contract owner through
+// consume_user_resource_percent, so it is conservative for contracts that
+// share energy costs. A simulated call that reverts is reported as an error
+// wrapping types.ErrContractExecutionFailed.
+func (m *ResourcesManager) CanAfford(ctx context.Context, from *types.Address, txExt *api.TransactionExtention) (*AffordabilityReport, error) {
+	if from == nil {
+		return nil, fmt.Errorf("%w: from address cannot be nil", types.ErrInvalidAddress)
+	}
+	tx := txExt.GetTransaction()
+	if tx.GetRawData() == nil || len(tx.GetRawData().GetContract()) != 1 {
+		return nil, fmt.Errorf("%w: transaction must have raw data with exactly one contract", types.ErrInvalidTransaction)
+	}
+	summary, err := types.DecodeTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	if !from.Equal(summary.From) {
+		return nil, fmt.Errorf("%w: transaction owner is %s, not %s", types.ErrInvalidParameter, summary.From, from)
+	}
+
+	acct, err := lowlevel.GetAccount(m.conn, ctx, &core.Account{Address: from.Bytes()})
+	if err != nil {
+		return nil, err
+	}
+	resources, err := m.GetAccountResources(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	params, err := m.network.GetChainParametersMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	energyPrice, err := chainParameter(params, network.ParamEnergyFee)
+	if err != nil {
+		return nil, err
+	}
+	bandwidthPrice, err := chainParameter(params, network.ParamTransactionFee)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &AffordabilityReport{
+		Resources: resources,
+		Balance:   acct.GetBalance(),
+		Bandwidth: estimateBandwidth(tx),
+		FeeLimit:  tx.GetRawData().GetFeeLimit(),
+	}
+
+	var recipient []byte
+	switch c := summary.Contract.(type) {
+	case *core.TransferContract:
+		r.Value = c.GetAmount()
+		recipient = c.GetToAddress()
+	case *core.TransferAssetContract:
+		recipient = c.GetToAddress()
+	case *core.TriggerSmartContract:
+		r.Value = c.GetCallValue()
+		ext, err := lowlevel.TriggerConstantContract(m.conn, ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		if !ext.GetResult().GetResult() {
+			return nil, fmt.Errorf("%w: simulation failed: %s", types.ErrContractExecutionFailed, ext.GetResult().GetMessage())
+		}
+		r.Energy = ext.GetEnergyUsed()
+	}
+
+	if recipient != nil {
+		to, err := lowlevel.GetAccount(m.conn, ctx, &core.Account{Address: recipient})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check recipient account: %w", err)
+		}
+		r.CreatesAccount = len(to.GetAddress()) == 0
+	}
+
+	if r.CreatesAccount {
+		rate, err := chainParameter(params, network.ParamCreateNewAccountBandwidthRate)
+		if err != nil {
+			return nil, err
+		}
+		if r.AccountCreationFee, err = chainParameter(params, network.ParamCreateNewAccountFeeInSystemContract); err != nil {
+			return nil, err
+		}
+		r.BandwidthCovered = resources.StakedBandwidthAvailable >= r.Bandwidth*rate
+		if !r.BandwidthCovered {
+			if r.BandwidthFee, err = chainParameter(params, network.ParamCreateAccountFee); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		r.BandwidthCovered = resources.StakedBandwidthAvailable >= r.Bandwidth || resources.FreeBandwidthAvailable >= r.Bandwidth
+		if !r.BandwidthCovered {
+			r.BandwidthFee = r.Bandwidth * bandwidthPrice
+		}
+	}
+	r.EnergyCovered = resources.EnergyAvailable >= r.Energy
+	if !r.EnergyCovered {
+		r.EnergyFee = (r.Energy - resources.EnergyAvailable) * energyPrice
+	}
+	r.TRXBurned = r.BandwidthFee + r.AccountCreationFee + r.EnergyFee
+	r.FeeLimitSufficient = r.EnergyFee <= r.FeeLimit
+	r.CanAfford = r.FeeLimitSufficient && r.Balance >= r.TRXBurned+r.Value
+	return r, nil
+}
+
+// chainParameter looks up key in params fetched by GetChainParametersMap.
+func chainParameter(params map[string]int64, key string) (int64, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: chain parameter %s", types.ErrNotFound, key)
+	}
+	return v, nil
+}
+
+// estimateBandwidth returns the bandwidth the node will charge for tx.
+func estimateBandwidth(tx *core.Transaction) int64 {
+	size := int64(proto.Size(tx)) + resultSizeInTx
+	if len(tx.GetSignature()) == 0 {
+		size += signatureSize
+	}
+	return size
+}
@@ -0,0 +1,120 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/network"
+	"github.com/kslamph/tronlib/pkg/types"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func buildTestTx(t *testing.T, contractType core.Transaction_Contract_ContractType, msg *anypb.Any, feeLimit int64) *api.TransactionExtention {
+	t.Helper()
+	return &api.TransactionExtention{Transaction: &core.Transaction{RawData: &core.TransactionRaw{
+		FeeLimit: feeLimit,
+		Contract: []*core.Transaction_Contract{{Type: contractType, Parameter: msg}},
+	}}}
+}
+
+func TestCanAfford(t *testing.T) {
+	fake := &fakeWalletServer{
+		GetAccountFunc: func(ctx context.Context, in *core.Account) (*core.Account, error) {
+			// testAddr and testAddr2 exist; any other address is inactive.
+			if !testAddr.Equal(types.MustNewAddressFromBytes(in.GetAddress())) && !testAddr2.Equal(types.MustNewAddressFromBytes(in.GetAddress())) {
+				return &core.Account{}, nil
+			}
+			return &core.Account{Address: in.GetAddress(), Balance: 5_000_000}, nil
+		},
+		GetAccountResourceFunc: func(ctx context.Context, in *core.Account) (*api.AccountResourceMessage, error) {
+			return &api.AccountResourceMessage{FreeNetLimit: 600, EnergyLimit: 10_000, EnergyUsed: 0}, nil
+		},
+		GetChainParametersFunc: func(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+			return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+				{Key: network.ParamEnergyFee, Value: 100},
+				{Key: network.ParamTransactionFee, Value: 1000},
+				{Key: network.ParamCreateAccountFee, Value: 100_000},
+				{Key: network.ParamCreateNewAccountFeeInSystemContract, Value: 1_000_000},
+				{Key: network.ParamCreateNewAccountBandwidthRate, Value: 1},
+			}}, nil
+		},
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{Result: &api.Return{Result: true}, EnergyUsed: 30_000}, nil
+		},
+	}
+	mgr, cleanup := setupTestServer(t, fake)
+	defer cleanup()
+	ctx := context.Background()
+
+	t.Run("trx transfer covered by free bandwidth", func(t *testing.T) {
+		param, _ := anypb.New(&core.TransferContract{OwnerAddress: testAddr.Bytes(), ToAddress: testAddr2.Bytes(), Amount: 1_000_000})
+		r, err := mgr.CanAfford(ctx, testAddr, buildTestTx(t, core.Transaction_Contract_TransferContract, param, 0))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !r.BandwidthCovered || r.TRXBurned != 0 || r.Value != 1_000_000 || !r.CanAfford {
+			t.Errorf("unexpected report: %+v", r)
+		}
+	})
+
+	t.Run("trx transfer activating an account", func(t *testing.T) {
+		inactive := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+		param, _ := anypb.New(&core.TransferContract{OwnerAddress: testAddr.Bytes(), ToAddress: inactive.Bytes(), Amount: 4_000_000})
+		r, err := mgr.CanAfford(ctx, testAddr, buildTestTx(t, core.Transaction_Contract_TransferContract, param, 0))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Free bandwidth cannot pay for activation: 0.1 TRX bandwidth fee plus 1 TRX creation fee.
+		if !r.CreatesAccount || r.BandwidthCovered || r.BandwidthFee != 100_000 || r.AccountCreationFee != 1_000_000 || r.TRXBurned != 1_100_000 {
+			t.Errorf("unexpected report: %+v", r)
+		}
+		if r.CanAfford {
+			t.Errorf("expected 4 TRX + 1.1 TRX fees to exceed a 5 TRX balance: %+v", r)
+		}
+	})
+
+	t.Run("from is not the owner", func(t *testing.T) {
+		param, _ := anypb.New(&core.TransferContract{OwnerAddress: testAddr.Bytes(), ToAddress: testAddr2.Bytes(), Amount: 1})
+		_, err := mgr.CanAfford(ctx, testAddr2, buildTestTx(t, core.Transaction_Contract_TransferContract, param, 0))
+		if !errors.Is(err, types.ErrInvalidParameter) {
+			t.Fatalf("expected ErrInvalidParameter, got %v", err)
+		}
+	})
+
+	t.Run("contract call burns energy", func(t *testing.T) {
+		param, _ := anypb.New(&core.TriggerSmartContract{OwnerAddress: testAddr.Bytes(), ContractAddress: testAddr2.Bytes()})
+		r, err := mgr.CanAfford(ctx, testAddr, buildTestTx(t, core.Transaction_Contract_TriggerSmartContract, param, 1_000_000))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if r.Energy != 30_000 || r.EnergyCovered || r.EnergyFee != 2_000_000 {
+			t.Errorf("unexpected energy estimate: %+v", r)
+		}
+		if r.FeeLimitSufficient || r.CanAfford {
+			t.Errorf("expected fee limit of 1 TRX to be insufficient: %+v", r)
+		}
+	})
+
+	t.Run("reverting call", func(t *testing.T) {
+		fake.TriggerConstantContractFunc = func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{Result: &api.Return{Result: false, Message: []byte("REVERT")}}, nil
+		}
+		param, _ := anypb.New(&core.TriggerSmartContract{OwnerAddress: testAddr.Bytes(), ContractAddress: testAddr2.Bytes()})
+		_, err := mgr.CanAfford(ctx, testAddr, buildTestTx(t, core.Transaction_Contract_TriggerSmartContract, param, 1_000_000))
+		if !errors.Is(err, types.ErrContractExecutionFailed) {
+			t.Fatalf("expected ErrContractExecutionFailed, got %v", err)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		if _, err := mgr.CanAfford(ctx, nil, &api.TransactionExtention{}); err == nil {
+			t.Fatal("expected error for nil address")
+		}
+		if _, err := mgr.CanAfford(ctx, testAddr, &api.TransactionExtention{}); err == nil {
+			t.Fatal("expected error for empty transaction")
+		}
+	})
+}
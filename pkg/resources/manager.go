@@ -8,17 +8,31 @@ import (
 	"github.com/kslamph/tronlib/pb/api"
 	"github.com/kslamph/tronlib/pb/core"
 	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/network"
 	"github.com/kslamph/tronlib/pkg/types"
 )
 
 // ResourcesManager provides high-level resource management operations
 type ResourcesManager struct {
-	conn lowlevel.ConnProvider
+	conn    lowlevel.ConnProvider
+	network *network.NetworkManager // chain parameters for CanAfford
+}
+
+// networkProvider is satisfied by *client.Client, whose NetworkManager keeps
+// one chain parameter cache for the lifetime of the client.
+type networkProvider interface {
+	Network() *network.NetworkManager
 }
 
 // NewManager creates a new resource manager
 func NewManager(conn lowlevel.ConnProvider) *ResourcesManager {
-	return &ResourcesManager{conn: conn}
+	m := &ResourcesManager{conn: conn}
+	if np, ok := conn.(networkProvider); ok {
+		m.network = np.Network()
+	} else {
+		m.network = network.NewManager(conn)
+	}
+	return m
 }
 
 // ResourceType represents the type of resource
@@ -30,6 +30,10 @@ type fakeWalletServer struct {
 	GetCanDelegatedMaxSizeFunc             func(ctx context.Context, in *api.CanDelegatedMaxSizeRequestMessage) (*api.CanDelegatedMaxSizeResponseMessage, error)
 	GetAvailableUnfreezeCountFunc          func(ctx context.Context, in *api.GetAvailableUnfreezeCountRequestMessage) (*api.GetAvailableUnfreezeCountResponseMessage, error)
 	GetCanWithdrawUnfreezeAmountFunc       func(ctx context.Context, in *api.CanWithdrawUnfreezeAmountRequestMessage) (*api.CanWithdrawUnfreezeAmountResponseMessage, error)
+	GetAccountResourceFunc                 func(ctx context.Context, in *core.Account) (*api.AccountResourceMessage, error)
+	GetAccountFunc                         func(ctx context.Context, in *core.Account) (*core.Account, error)
+	GetChainParametersFunc                 func(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error)
+	TriggerConstantContractFunc            func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error)
 }
 
 func (s *fakeWalletServer) FreezeBalanceV2(ctx context.Context, in *core.FreezeBalanceV2Contract) (*api.TransactionExtention, error) {
@@ -109,6 +113,34 @@ func (s *fakeWalletServer) GetCanWithdrawUnfreezeAmount(ctx context.Context, in
 	return &api.CanWithdrawUnfreezeAmountResponseMessage{}, nil
 }
 
+func (s *fakeWalletServer) GetAccountResource(ctx context.Context, in *core.Account) (*api.AccountResourceMessage, error) {
+	if s.GetAccountResourceFunc != nil {
+		return s.GetAccountResourceFunc(ctx, in)
+	}
+	return &api.AccountResourceMessage{}, nil
+}
+
+func (s *fakeWalletServer) GetAccount(ctx context.Context, in *core.Account) (*core.Account, error) {
+	if s.GetAccountFunc != nil {
+		return s.GetAccountFunc(ctx, in)
+	}
+	return &core.Account{}, nil
+}
+
+func (s *fakeWalletServer) GetChainParameters(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+	if s.GetChainParametersFunc != nil {
+		return s.GetChainParametersFunc(ctx, in)
+	}
+	return &core.ChainParameters{}, nil
+}
+
+func (s *fakeWalletServer) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	if s.TriggerConstantContractFunc != nil {
+		return s.TriggerConstantContractFunc(ctx, in)
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}}, nil
+}
+
 // setupTestServer creates a bufconn gRPC server and returns a ResourcesManager connected to it.
 func setupTestServer(t *testing.T, fake *fakeWalletServer) (*ResourcesManager, func()) {
 	t.Helper()
@@ -499,3 +531,44 @@ func TestGetCanWithdrawUnfreezeAmount(t *testing.T) {
 		}
 	})
 }
+
+func TestGetAccountResources(t *testing.T) {
+	fake := &fakeWalletServer{
+		GetAccountResourceFunc: func(ctx context.Context, in *core.Account) (*api.AccountResourceMessage, error) {
+			return &api.AccountResourceMessage{
+				FreeNetLimit: 600,
+				FreeNetUsed:  250,
+				NetLimit:     1000,
+				NetUsed:      1200, // over-used after a stake was reduced
+				EnergyLimit:  50000,
+				EnergyUsed:   12000,
+			}, nil
+		},
+	}
+	mgr, cleanup := setupTestServer(t, fake)
+	defer cleanup()
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		r, err := mgr.GetAccountResources(ctx, testAddr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if r.FreeBandwidthAvailable != 350 || r.StakedBandwidthAvailable != 0 {
+			t.Errorf("unexpected bandwidth split: free=%d staked=%d", r.FreeBandwidthAvailable, r.StakedBandwidthAvailable)
+		}
+		if r.BandwidthLimit != 1600 || r.BandwidthUsed != 1450 || r.BandwidthAvailable != 350 {
+			t.Errorf("unexpected bandwidth totals: %+v", r)
+		}
+		if r.EnergyAvailable != 38000 {
+			t.Errorf("expected 38000 energy available, got %d", r.EnergyAvailable)
+		}
+	})
+
+	t.Run("nil address", func(t *testing.T) {
+		_, err := mgr.GetAccountResources(ctx, nil)
+		if err == nil {
+			t.Fatal("expected error for nil address")
+		}
+	})
+}
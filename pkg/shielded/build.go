@@ -0,0 +1,279 @@
+package shielded
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// Method selectors of the shielded TRC20 contract.
+var (
+	mintSelector          = mustDecodeHex("855d175e") // mint(uint256,bytes32[9],bytes32[2],bytes32[21])
+	burnSelector          = mustDecodeHex("cc105875") // burn(bytes32[10],bytes32[2],uint256,bytes32[2],address,bytes32[3],bytes32[9][],bytes32[21][])
+	getPathSelector       = mustDecodeHex("e1765073") // getPath(uint256)
+	scalingFactorSelector = mustDecodeHex("ed3437f8") // scalingFactor()
+)
+
+// defaultFeeLimit is stamped on built mint and burn transactions (350 TRX);
+// proof verification costs far more energy than an ordinary contract call.
+const defaultFeeLimit = 350_000_000
+
+// merkleDepth is the depth of the contract's note commitment tree.
+const merkleDepth = 32
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// constantCall runs a read-only call on contract and returns its output.
+func (m *Manager) constantCall(ctx context.Context, contract *types.Address, data []byte) ([]byte, error) {
+	ext, err := lowlevel.TriggerConstantContract(m.conn, ctx, &core.TriggerSmartContract{
+		OwnerAddress:    contract.Bytes(),
+		ContractAddress: contract.Bytes(),
+		Data:            data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reverted := len(ext.GetTransaction().GetRet()) > 0 && ext.GetTransaction().GetRet()[0].GetRet() != core.Transaction_Result_SUCESS
+	if !ext.GetResult().GetResult() || reverted {
+		return nil, fmt.Errorf("%w: %s", types.ErrContractExecutionFailed, ext.GetResult().GetMessage())
+	}
+	var out []byte
+	for _, r := range ext.GetConstantResult() {
+		out = append(out, r...)
+	}
+	return out, nil
+}
+
+// ScalingFactor returns the contract's scaling factor: a note of value v
+// holds v * ScalingFactor units of the underlying TRC20 token, so mint and
+// burn amounts must be multiples of it.
+func (m *Manager) ScalingFactor(ctx context.Context, contract *types.Address) (*big.Int, error) {
+	if contract == nil {
+		return nil, fmt.Errorf("%w: contract address cannot be nil", types.ErrInvalidAddress)
+	}
+	out, err := m.constantCall(ctx, contract, scalingFactorSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scaling factor: %w", err)
+	}
+	if len(out) != 32 {
+		return nil, fmt.Errorf("unexpected scalingFactor output length %d", len(out))
+	}
+	factor := new(big.Int).SetBytes(out)
+	if factor.Sign() == 0 {
+		return nil, fmt.Errorf("%w: contract reports a zero scaling factor", types.ErrInvalidContract)
+	}
+	return factor, nil
+}
+
+// MerklePath returns the current tree root and the authentication path of
+// the note at position, as needed to spend it. It fails with an error
+// wrapping types.ErrContractExecutionFailed while the position is not yet in
+// the tree.
+func (m *Manager) MerklePath(ctx context.Context, contract *types.Address, position int64) (root, path []byte, err error) {
+	if contract == nil {
+		return nil, nil, fmt.Errorf("%w: contract address cannot be nil", types.ErrInvalidAddress)
+	}
+	if position < 0 {
+		return nil, nil, fmt.Errorf("%w: note position cannot be negative", types.ErrInvalidParameter)
+	}
+	data := append(append([]byte{}, getPathSelector...), math.U256Bytes(big.NewInt(position))...)
+	out, err := m.constantCall(ctx, contract, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get merkle path for position %d: %w", position, err)
+	}
+	if len(out) != 32*(1+merkleDepth) {
+		return nil, nil, fmt.Errorf("unexpected getPath output length %d", len(out))
+	}
+	return out[:32], out[32:], nil
+}
+
+// toNoteValue converts a TRC20 amount to a note value, checking that it is a
+// positive multiple of scaling.
+func toNoteValue(amount, scaling *big.Int) (int64, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return 0, fmt.Errorf("%w: amount must be positive", types.ErrInvalidAmount)
+	}
+	value, rem := new(big.Int).QuoRem(amount, scaling, new(big.Int))
+	if rem.Sign() != 0 {
+		return 0, fmt.Errorf("%w: amount %s is not a multiple of the scaling factor %s", types.ErrInvalidAmount, amount, scaling)
+	}
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("%w: amount %s is too large for a note", types.ErrInvalidAmount, amount)
+	}
+	return value.Int64(), nil
+}
+
+// newRcm asks the node for a fresh random commitment trapdoor.
+func (m *Manager) newRcm(ctx context.Context) ([]byte, error) {
+	rcm, err := lowlevel.GetRcm(m.conn, ctx, &api.EmptyMessage{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate rcm: %w", err)
+	}
+	return rcm.GetValue(), nil
+}
+
+// buildCall creates the unsigned contract call for selector and the
+// node-generated parameters.
+func (m *Manager) buildCall(ctx context.Context, owner, contract *types.Address, selector []byte, params *api.PrivateShieldedTRC20Parameters) (*api.TransactionExtention, error) {
+	result, err := lowlevel.CreateShieldedContractParameters(m.conn, ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shielded contract parameters: %w", err)
+	}
+	input, err := hex.DecodeString(result.GetTriggerContractInput())
+	if err != nil {
+		return nil, fmt.Errorf("invalid trigger contract input from node: %w", err)
+	}
+
+	txExt, err := lowlevel.TriggerContract(m.conn, ctx, &core.TriggerSmartContract{
+		OwnerAddress:    owner.Bytes(),
+		ContractAddress: contract.Bytes(),
+		Data:            append(append([]byte{}, selector...), input...),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if raw := txExt.GetTransaction().GetRawData(); raw != nil && raw.GetFeeLimit() == 0 {
+		raw.FeeLimit = defaultFeeLimit
+		txid, err := types.TransactionID(txExt.GetTransaction())
+		if err != nil {
+			return nil, err
+		}
+		txExt.Txid = txid
+	}
+	return txExt, nil
+}
+
+// BuildMint creates an unsigned transaction, sent by owner, that moves
+// amount of the underlying TRC20 token into a new note for
+// keys.PaymentAddress. owner must first approve contract to spend amount on
+// the TRC20 token, and amount must be a multiple of the scaling factor.
+//
+// The transaction carries a 350 TRX fee limit; a non-zero
+// BroadcastOptions.FeeLimit replaces it at signing.
+//
+// Example:
+//
+//	txExt, err := shieldedMgr.BuildMint(ctx, owner, contract, keys, big.NewInt(10_000_000))
+//	if err != nil {
+//	    // handle error
+//	}
+//	res, err := cli.SignAndBroadcast(ctx, txExt, client.DefaultBroadcastOptions(), signer)
+func (m *Manager) BuildMint(ctx context.Context, owner, contract *types.Address, keys *Keys, amount *big.Int) (*api.TransactionExtention, error) {
+	if owner == nil || contract == nil {
+		return nil, fmt.Errorf("%w: owner and contract addresses cannot be nil", types.ErrInvalidAddress)
+	}
+	if keys == nil || keys.PaymentAddress == "" || len(keys.Ovk) == 0 {
+		return nil, fmt.Errorf("%w: keys must include ovk and a payment address", types.ErrInvalidParameter)
+	}
+	scaling, err := m.ScalingFactor(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	value, err := toNoteValue(amount, scaling)
+	if err != nil {
+		return nil, err
+	}
+	rcm, err := m.newRcm(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.buildCall(ctx, owner, contract, mintSelector, &api.PrivateShieldedTRC20Parameters{
+		Ovk:        keys.Ovk,
+		FromAmount: amount.String(),
+		ShieldedReceives: []*api.ReceiveNote{{
+			Note: &api.Note{Value: value, PaymentAddress: keys.PaymentAddress, Rcm: rcm},
+		}},
+		Shielded_TRC20ContractAddress: contract.Bytes(),
+	})
+}
+
+// BuildBurn creates an unsigned transaction, sent by owner, that spends note
+// and pays amount of the underlying TRC20 token to the transparent address
+// to. When the note is worth more than amount, the remainder goes to a new
+// change note for keys.PaymentAddress. keys must hold the spending keys the
+// note was received with.
+//
+// The note must already be in the contract's merkle tree (see MerklePath).
+// The transaction carries a 350 TRX fee limit; a non-zero
+// BroadcastOptions.FeeLimit replaces it at signing.
+//
+// Example:
+//
+//	txExt, err := shieldedMgr.BuildBurn(ctx, owner, contract, keys, note, owner, big.NewInt(5_000_000))
+func (m *Manager) BuildBurn(ctx context.Context, owner, contract *types.Address, keys *Keys, note *Note, to *types.Address, amount *big.Int) (*api.TransactionExtention, error) {
+	if owner == nil || contract == nil || to == nil {
+		return nil, fmt.Errorf("%w: owner, contract and recipient addresses cannot be nil", types.ErrInvalidAddress)
+	}
+	if keys == nil || len(keys.Ask) == 0 || len(keys.Nsk) == 0 || len(keys.Ovk) == 0 {
+		return nil, fmt.Errorf("%w: keys must include ask, nsk and ovk", types.ErrInvalidParameter)
+	}
+	if note == nil {
+		return nil, fmt.Errorf("%w: note cannot be nil", types.ErrInvalidParameter)
+	}
+	if note.IsSpent {
+		return nil, fmt.Errorf("%w: note at position %d is already spent", types.ErrInvalidParameter, note.Position)
+	}
+	scaling, err := m.ScalingFactor(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	value, err := toNoteValue(amount, scaling)
+	if err != nil {
+		return nil, err
+	}
+	if value > note.Value {
+		return nil, fmt.Errorf("%w: note value %d is less than %d", types.ErrInsufficientBalance, note.Value, value)
+	}
+
+	root, path, err := m.MerklePath(ctx, contract, note.Position)
+	if err != nil {
+		return nil, err
+	}
+	alpha, err := m.newRcm(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &api.PrivateShieldedTRC20Parameters{
+		Ask: keys.Ask,
+		Nsk: keys.Nsk,
+		Ovk: keys.Ovk,
+		ShieldedSpends: []*api.SpendNoteTRC20{{
+			Note:  &api.Note{Value: note.Value, PaymentAddress: note.PaymentAddress, Rcm: note.Rcm, Memo: note.Memo},
+			Alpha: alpha,
+			Root:  root,
+			Path:  path,
+			Pos:   note.Position,
+		}},
+		TransparentToAddress:          to.Bytes(),
+		ToAmount:                      amount.String(),
+		Shielded_TRC20ContractAddress: contract.Bytes(),
+	}
+	if change := note.Value - value; change > 0 {
+		if keys.PaymentAddress == "" {
+			return nil, fmt.Errorf("%w: a payment address is needed for the change note", types.ErrInvalidParameter)
+		}
+		rcm, err := m.newRcm(ctx)
+		if err != nil {
+			return nil, err
+		}
+		params.ShieldedReceives = []*api.ReceiveNote{{
+			Note: &api.Note{Value: change, PaymentAddress: keys.PaymentAddress, Rcm: rcm},
+		}}
+	}
+	return m.buildCall(ctx, owner, contract, burnSelector, params)
+}
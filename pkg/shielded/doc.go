@@ -0,0 +1,30 @@
+// Package shielded provides typed access to shielded TRC20 contracts.
+//
+// Manager wraps the node's shielded APIs: generating and deriving key sets,
+// scanning blocks for notes received by a key set, and building mint
+// (transparent to shielded) and burn (shielded to transparent) transactions.
+// The node derives keys, decrypts notes and generates proofs, so it sees the
+// keys passed to it; only use a node you trust with them.
+//
+// # Quick Start
+//
+//	mgr := shielded.NewManager(cli)
+//
+//	keys, err := mgr.GenerateKeys(ctx)
+//	if err != nil { /* handle */ }
+//
+//	// owner must have approved the shielded contract on the TRC20 token.
+//	txExt, err := mgr.BuildMint(ctx, owner, contract, keys, big.NewInt(10_000_000))
+//	if err != nil { /* handle */ }
+//	res, err := cli.SignAndBroadcast(ctx, txExt, client.DefaultBroadcastOptions(), signer)
+//
+//	notes, err := mgr.ScanNotes(ctx, contract, keys, startBlock, endBlock)
+//	if err != nil { /* handle */ }
+//	// A new note can only be spent once it is in the contract's merkle tree.
+//	err = mgr.WaitForNoteConfirmation(ctx, notes[0], 2*time.Minute)
+//	txExt, err = mgr.BuildBurn(ctx, owner, contract, keys, notes[0], owner, big.NewInt(10_000_000))
+//
+// Amounts passed to BuildMint and BuildBurn are in units of the underlying
+// TRC20 token and must be multiples of ScalingFactor; Note.Value is in
+// shielded units.
+package shielded
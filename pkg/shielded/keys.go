@@ -0,0 +1,114 @@
+package shielded
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// Manager provides high-level shielded TRC20 operations: key generation,
+// note scanning and building mint and burn transactions. Key derivation,
+// proof generation and note decryption are performed by the node, so the
+// node must have shielded APIs enabled and is trusted with the keys.
+type Manager struct {
+	conn lowlevel.ConnProvider
+}
+
+// NewManager creates a shielded manager using conn (typically a
+// *client.Client).
+func NewManager(conn lowlevel.ConnProvider) *Manager {
+	return &Manager{conn: conn}
+}
+
+// Keys holds a shielded key set and the payment address derived from it.
+// Sk is the root secret; the others are derived from it by DeriveKeys.
+//
+// IMPORTANT: Sk, Ask and Nsk allow spending notes and must be stored as
+// securely as a private key. Ovk, Ak, Nk and Ivk only allow viewing.
+type Keys struct {
+	Sk  []byte `json:"sk"`  // spending key
+	Ask []byte `json:"ask"` // spend authorizing key
+	Nsk []byte `json:"nsk"` // nullifier private key
+	Ovk []byte `json:"ovk"` // outgoing viewing key
+	Ak  []byte `json:"ak"`  // spend validating key
+	Nk  []byte `json:"nk"`  // nullifier deriving key
+	Ivk []byte `json:"ivk"` // incoming viewing key
+	D   []byte `json:"d"`   // diversifier
+
+	// PaymentAddress is the shielded address ("ztron1...") notes are sent to.
+	PaymentAddress string `json:"paymentAddress"`
+}
+
+// GenerateKeys creates a new spending key on the node and derives the full
+// key set and a payment address from it.
+//
+// Example:
+//
+//	keys, err := shieldedMgr.GenerateKeys(ctx)
+//	if err != nil {
+//	    // handle error
+//	}
+//	fmt.Println("receive at", keys.PaymentAddress)
+func (m *Manager) GenerateKeys(ctx context.Context) (*Keys, error) {
+	sk, err := lowlevel.GetSpendingKey(m.conn, ctx, &api.EmptyMessage{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate spending key: %w", err)
+	}
+	return m.DeriveKeys(ctx, sk.GetValue(), nil)
+}
+
+// DeriveKeys derives the key set from spending key sk: ask, nsk and ovk from
+// the expanded spending key, then ak, nk and ivk, and the payment address for
+// diversifier d. A nil d asks the node for a new random diversifier; pass
+// the stored one to restore the same payment address.
+func (m *Manager) DeriveKeys(ctx context.Context, sk, d []byte) (*Keys, error) {
+	if len(sk) != 32 {
+		return nil, fmt.Errorf("%w: spending key must be 32 bytes, got %d", types.ErrInvalidParameter, len(sk))
+	}
+
+	expanded, err := lowlevel.GetExpandedSpendingKey(m.conn, ctx, &api.BytesMessage{Value: sk})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand spending key: %w", err)
+	}
+	keys := &Keys{Sk: sk, Ask: expanded.GetAsk(), Nsk: expanded.GetNsk(), Ovk: expanded.GetOvk()}
+
+	ak, err := lowlevel.GetAkFromAsk(m.conn, ctx, &api.BytesMessage{Value: keys.Ask})
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive ak: %w", err)
+	}
+	keys.Ak = ak.GetValue()
+
+	nk, err := lowlevel.GetNkFromNsk(m.conn, ctx, &api.BytesMessage{Value: keys.Nsk})
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive nk: %w", err)
+	}
+	keys.Nk = nk.GetValue()
+
+	ivk, err := lowlevel.GetIncomingViewingKey(m.conn, ctx, &api.ViewingKeyMessage{Ak: keys.Ak, Nk: keys.Nk})
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive ivk: %w", err)
+	}
+	keys.Ivk = ivk.GetIvk()
+
+	if d == nil {
+		div, err := lowlevel.GetDiversifier(m.conn, ctx, &api.EmptyMessage{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate diversifier: %w", err)
+		}
+		d = div.GetD()
+	}
+	keys.D = d
+
+	addr, err := lowlevel.GetZenPaymentAddress(m.conn, ctx, &api.IncomingViewingKeyDiversifierMessage{
+		Ivk: &api.IncomingViewingKeyMessage{Ivk: keys.Ivk},
+		D:   &api.DiversifierMessage{D: d},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive payment address: %w", err)
+	}
+	keys.PaymentAddress = addr.GetPaymentAddress()
+	return keys, nil
+}
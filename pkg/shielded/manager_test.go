@@ -0,0 +1,260 @@
+package shielded_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client"
+	"github.com/kslamph/tronlib/pkg/client/clienttest"
+	"github.com/kslamph/tronlib/pkg/shielded"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+var (
+	contract = types.MustNewAddressFromBase58("TKCTfkQ8L9beavNu9iaGtCHFxrwNHUxfr2")
+	owner    = types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+)
+
+func fill(b byte, n int) []byte { return bytes.Repeat([]byte{b}, n) }
+
+func word(v int64) []byte {
+	out := make([]byte, 32)
+	big.NewInt(v).FillBytes(out)
+	return out
+}
+
+type shieldedServer struct {
+	clienttest.TriggerRecorder
+
+	mu     sync.Mutex
+	scans  []*api.IvkDecryptTRC20Parameters
+	params *api.PrivateShieldedTRC20Parameters
+}
+
+func (s *shieldedServer) GetSpendingKey(ctx context.Context, in *api.EmptyMessage) (*api.BytesMessage, error) {
+	return &api.BytesMessage{Value: fill(1, 32)}, nil
+}
+
+func (s *shieldedServer) GetExpandedSpendingKey(ctx context.Context, in *api.BytesMessage) (*api.ExpandedSpendingKeyMessage, error) {
+	return &api.ExpandedSpendingKeyMessage{Ask: fill(2, 32), Nsk: fill(3, 32), Ovk: fill(4, 32)}, nil
+}
+
+func (s *shieldedServer) GetAkFromAsk(ctx context.Context, in *api.BytesMessage) (*api.BytesMessage, error) {
+	return &api.BytesMessage{Value: fill(5, 32)}, nil
+}
+
+func (s *shieldedServer) GetNkFromNsk(ctx context.Context, in *api.BytesMessage) (*api.BytesMessage, error) {
+	return &api.BytesMessage{Value: fill(6, 32)}, nil
+}
+
+func (s *shieldedServer) GetIncomingViewingKey(ctx context.Context, in *api.ViewingKeyMessage) (*api.IncomingViewingKeyMessage, error) {
+	return &api.IncomingViewingKeyMessage{Ivk: fill(7, 32)}, nil
+}
+
+func (s *shieldedServer) GetDiversifier(ctx context.Context, in *api.EmptyMessage) (*api.DiversifierMessage, error) {
+	return &api.DiversifierMessage{D: fill(8, 11)}, nil
+}
+
+func (s *shieldedServer) GetZenPaymentAddress(ctx context.Context, in *api.IncomingViewingKeyDiversifierMessage) (*api.PaymentAddressMessage, error) {
+	return &api.PaymentAddressMessage{PaymentAddress: "ztron1" + hex.EncodeToString(in.GetD().GetD())}, nil
+}
+
+func (s *shieldedServer) GetRcm(ctx context.Context, in *api.EmptyMessage) (*api.BytesMessage, error) {
+	return &api.BytesMessage{Value: fill(9, 32)}, nil
+}
+
+func (s *shieldedServer) ScanShieldedTRC20NotesByIvk(ctx context.Context, in *api.IvkDecryptTRC20Parameters) (*api.DecryptNotesTRC20, error) {
+	s.mu.Lock()
+	s.scans = append(s.scans, in)
+	s.mu.Unlock()
+	return &api.DecryptNotesTRC20{NoteTxs: []*api.DecryptNotesTRC20_NoteTx{{
+		Note:     &api.Note{Value: 10, PaymentAddress: "ztron1abc"},
+		Position: in.GetStartBlockIndex(),
+	}}}, nil
+}
+
+func (s *shieldedServer) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	var out []byte
+	switch hex.EncodeToString(in.Data[:4]) {
+	case "ed3437f8": // scalingFactor()
+		out = word(100)
+	case "e1765073": // getPath(uint256)
+		if new(big.Int).SetBytes(in.Data[4:]).Int64() >= 5 {
+			return &api.TransactionExtention{Result: &api.Return{Result: false, Message: []byte("REVERT opcode executed")}}, nil
+		}
+		out = append(fill(0xaa, 32), fill(0xbb, 32*32)...)
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{out}}, nil
+}
+
+func (s *shieldedServer) CreateShieldedContractParameters(ctx context.Context, in *api.PrivateShieldedTRC20Parameters) (*api.ShieldedTRC20Parameters, error) {
+	s.mu.Lock()
+	s.params = in
+	s.mu.Unlock()
+	return &api.ShieldedTRC20Parameters{TriggerContractInput: "c0ffee"}, nil
+}
+
+func newManager(t *testing.T) (*shielded.Manager, *shieldedServer) {
+	t.Helper()
+	impl := &shieldedServer{}
+	c := clienttest.NewClient(t, impl, client.WithTimeout(time.Second), client.WithPool(1, 1))
+	return shielded.NewManager(c), impl
+}
+
+func TestGenerateKeys(t *testing.T) {
+	m, _ := newManager(t)
+	keys, err := m.GenerateKeys(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateKeys: %v", err)
+	}
+	if !bytes.Equal(keys.Sk, fill(1, 32)) || !bytes.Equal(keys.Ask, fill(2, 32)) || !bytes.Equal(keys.Ivk, fill(7, 32)) || !bytes.Equal(keys.D, fill(8, 11)) {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+	if keys.PaymentAddress != "ztron1"+hex.EncodeToString(fill(8, 11)) {
+		t.Fatalf("PaymentAddress = %q", keys.PaymentAddress)
+	}
+
+	restored, err := m.DeriveKeys(context.Background(), keys.Sk, fill(0x11, 11))
+	if err != nil || !bytes.Equal(restored.D, fill(0x11, 11)) {
+		t.Fatalf("DeriveKeys with diversifier = %+v, %v", restored, err)
+	}
+	if _, err := m.DeriveKeys(context.Background(), []byte{1}, nil); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for short sk, got %v", err)
+	}
+}
+
+func TestScanNotes_Chunks(t *testing.T) {
+	m, srv := newManager(t)
+	keys := &shielded.Keys{Ivk: fill(7, 32), Ak: fill(5, 32), Nk: fill(6, 32)}
+
+	notes, err := m.ScanNotes(context.Background(), contract, keys, 100, 2600)
+	if err != nil {
+		t.Fatalf("ScanNotes: %v", err)
+	}
+	if len(srv.scans) != 3 || len(notes) != 3 {
+		t.Fatalf("got %d scans and %d notes, want 3 each", len(srv.scans), len(notes))
+	}
+	wantRanges := [][2]int64{{100, 1100}, {1100, 2100}, {2100, 2600}}
+	for i, r := range wantRanges {
+		if got := srv.scans[i]; got.StartBlockIndex != r[0] || got.EndBlockIndex != r[1] {
+			t.Fatalf("scan %d = [%d, %d), want [%d, %d)", i, got.StartBlockIndex, got.EndBlockIndex, r[0], r[1])
+		}
+	}
+	if notes[1].Value != 10 || notes[1].Position != 1100 {
+		t.Fatalf("unexpected note: %+v", notes[1])
+	}
+
+	if _, err := m.ScanNotes(context.Background(), contract, keys, 10, 10); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for empty range, got %v", err)
+	}
+	if _, err := m.ScanNotes(context.Background(), contract, &shielded.Keys{}, 0, 10); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter without ivk, got %v", err)
+	}
+}
+
+func TestBuildMint(t *testing.T) {
+	m, srv := newManager(t)
+	keys := &shielded.Keys{Ovk: fill(4, 32), PaymentAddress: "ztron1abc"}
+
+	txExt, err := m.BuildMint(context.Background(), owner, contract, keys, big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("BuildMint: %v", err)
+	}
+	if want := "855d175ec0ffee"; hex.EncodeToString(srv.LastData()) != want {
+		t.Fatalf("trigger data = %x, want %s", srv.LastData(), want)
+	}
+	if got := txExt.GetTransaction().GetRawData().GetFeeLimit(); got != 350_000_000 {
+		t.Fatalf("FeeLimit = %d, want 350_000_000", got)
+	}
+	if bytes.Equal(txExt.GetTxid(), make([]byte, 32)) {
+		t.Fatal("Txid was not recomputed after stamping the fee limit")
+	}
+	recv := srv.params.GetShieldedReceives()
+	if srv.params.GetFromAmount() != "1000" || len(recv) != 1 || recv[0].GetNote().GetValue() != 10 {
+		t.Fatalf("unexpected mint parameters: %v", srv.params)
+	}
+
+	if _, err := m.BuildMint(context.Background(), owner, contract, keys, big.NewInt(1050)); !errors.Is(err, types.ErrInvalidAmount) {
+		t.Fatalf("expected ErrInvalidAmount for unscaled amount, got %v", err)
+	}
+}
+
+func TestBuildBurn(t *testing.T) {
+	m, srv := newManager(t)
+	keys := &shielded.Keys{Ask: fill(2, 32), Nsk: fill(3, 32), Ovk: fill(4, 32), PaymentAddress: "ztron1abc"}
+	note := &shielded.Note{Value: 10, PaymentAddress: "ztron1abc", Rcm: fill(9, 32), Position: 3}
+
+	if _, err := m.BuildBurn(context.Background(), owner, contract, keys, note, owner, big.NewInt(600)); err != nil {
+		t.Fatalf("BuildBurn: %v", err)
+	}
+	if hex.EncodeToString(srv.LastData()[:4]) != "cc105875" {
+		t.Fatalf("trigger data = %x, want burn selector", srv.LastData())
+	}
+	spends := srv.params.GetShieldedSpends()
+	if len(spends) != 1 || spends[0].GetPos() != 3 || !bytes.Equal(spends[0].GetRoot(), fill(0xaa, 32)) || len(spends[0].GetPath()) != 1024 {
+		t.Fatalf("unexpected spend: %v", spends)
+	}
+	if recv := srv.params.GetShieldedReceives(); len(recv) != 1 || recv[0].GetNote().GetValue() != 4 {
+		t.Fatalf("expected a change note of 4, got %v", recv)
+	}
+	if srv.params.GetToAmount() != "600" {
+		t.Fatalf("ToAmount = %q", srv.params.GetToAmount())
+	}
+
+	if _, err := m.BuildBurn(context.Background(), owner, contract, keys, note, owner, big.NewInt(2000)); !errors.Is(err, types.ErrInsufficientBalance) {
+		t.Fatalf("expected ErrInsufficientBalance, got %v", err)
+	}
+	unindexed := &shielded.Note{Value: 10, Position: 7}
+	if _, err := m.BuildBurn(context.Background(), owner, contract, keys, unindexed, owner, big.NewInt(100)); !errors.Is(err, types.ErrContractExecutionFailed) {
+		t.Fatalf("expected ErrContractExecutionFailed for unindexed note, got %v", err)
+	}
+}
+
+func TestIsNoteSpendable(t *testing.T) {
+	m, _ := newManager(t)
+	ctx := context.Background()
+
+	notes, err := m.ScanNotes(ctx, contract, &shielded.Keys{Ivk: fill(7, 32)}, 3, 4)
+	if err != nil || len(notes) != 1 || notes[0].Contract != contract {
+		t.Fatalf("ScanNotes = %v, %v; want one note with its contract", notes, err)
+	}
+	if ok, err := m.IsNoteSpendable(ctx, nil, notes[0]); err != nil || !ok {
+		t.Fatalf("IsNoteSpendable(indexed) = %v, %v", ok, err)
+	}
+	if ok, err := m.IsNoteSpendable(ctx, contract, &shielded.Note{Position: 7}); err != nil || ok {
+		t.Fatalf("IsNoteSpendable(unindexed) = %v, %v", ok, err)
+	}
+	if ok, err := m.IsNoteSpendable(ctx, contract, &shielded.Note{Position: 3, IsSpent: true}); err != nil || ok {
+		t.Fatalf("IsNoteSpendable(spent) = %v, %v", ok, err)
+	}
+	if _, err := m.IsNoteSpendable(ctx, nil, &shielded.Note{Position: 3}); !errors.Is(err, types.ErrInvalidAddress) {
+		t.Fatalf("expected ErrInvalidAddress without a contract, got %v", err)
+	}
+}
+
+func TestWaitForNoteConfirmation(t *testing.T) {
+	m, _ := newManager(t)
+	ctx := context.Background()
+
+	if err := m.WaitForNoteConfirmation(ctx, &shielded.Note{Position: 3, Contract: contract}, time.Second); err != nil {
+		t.Fatalf("WaitForNoteConfirmation(indexed): %v", err)
+	}
+	err := m.WaitForNoteConfirmation(ctx, &shielded.Note{Position: 7, Contract: contract}, 50*time.Millisecond)
+	if !errors.Is(err, types.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := m.WaitForNoteConfirmation(cctx, &shielded.Note{Position: 7, Contract: contract}, time.Second); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package shielded
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// maxScanBlocks is the widest block range the node scans in one request.
+const maxScanBlocks = 1000
+
+// Note is a shielded note decrypted from a shielded TRC20 contract.
+type Note struct {
+	// Value is the note value in shielded units; multiply by the contract's
+	// scaling factor for the TRC20 amount.
+	Value          int64
+	PaymentAddress string
+	Rcm            []byte
+	Memo           []byte
+
+	// Position is the note's leaf index in the contract's merkle tree.
+	Position int64
+	// TxID and Index identify the transaction output that created the note.
+	TxID  []byte
+	Index int32
+	// IsSpent reports whether the note's nullifier was published as of the
+	// scan.
+	IsSpent bool
+	// Contract is the shielded TRC20 contract the note was scanned from.
+	Contract *types.Address
+}
+
+// newNote converts a scanned note.
+func newNote(contract *types.Address, tx *api.DecryptNotesTRC20_NoteTx) *Note {
+	n := tx.GetNote()
+	return &Note{
+		Value:          n.GetValue(),
+		PaymentAddress: n.GetPaymentAddress(),
+		Rcm:            n.GetRcm(),
+		Memo:           n.GetMemo(),
+		Position:       tx.GetPosition(),
+		TxID:           tx.GetTxid(),
+		Index:          tx.GetIndex(),
+		IsSpent:        tx.GetIsSpent(),
+		Contract:       contract,
+	}
+}
+
+// ScanNotes returns the notes of contract received by keys in blocks
+// [from, to). The node limits a scan to 1000 blocks, so longer ranges are
+// scanned in consecutive chunks. keys must carry Ivk; Ak and Nk are also
+// needed for IsSpent to be reported.
+//
+// Example:
+//
+//	notes, err := shieldedMgr.ScanNotes(ctx, contract, keys, 59_808_000, 59_812_000)
+//	if err != nil {
+//	    // handle error
+//	}
+//	for _, n := range notes {
+//	    if !n.IsSpent {
+//	        fmt.Printf("note %d: %d\n", n.Position, n.Value)
+//	    }
+//	}
+func (m *Manager) ScanNotes(ctx context.Context, contract *types.Address, keys *Keys, from, to int64) ([]*Note, error) {
+	if contract == nil {
+		return nil, fmt.Errorf("%w: contract address cannot be nil", types.ErrInvalidAddress)
+	}
+	if keys == nil || len(keys.Ivk) == 0 {
+		return nil, fmt.Errorf("%w: incoming viewing key is required", types.ErrInvalidParameter)
+	}
+	if from < 0 || to <= from {
+		return nil, fmt.Errorf("%w: invalid block range [%d, %d)", types.ErrInvalidParameter, from, to)
+	}
+
+	var notes []*Note
+	for start := from; start < to; start += maxScanBlocks {
+		end := start + maxScanBlocks
+		if end > to {
+			end = to
+		}
+		resp, err := lowlevel.ScanShieldedTRC20NotesByIvk(m.conn, ctx, &api.IvkDecryptTRC20Parameters{
+			StartBlockIndex:               start,
+			EndBlockIndex:                 end,
+			Shielded_TRC20ContractAddress: contract.Bytes(),
+			Ivk:                           keys.Ivk,
+			Ak:                            keys.Ak,
+			Nk:                            keys.Nk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blocks %d-%d: %w", start, end, err)
+		}
+		for _, tx := range resp.GetNoteTxs() {
+			notes = append(notes, newNote(contract, tx))
+		}
+	}
+	return notes, nil
+}
+
+// notePollInterval is how often WaitForNoteConfirmation checks the merkle
+// tree; it matches TRON's 3 second block time.
+const notePollInterval = 3 * time.Second
+
+// IsNoteSpendable reports whether note can be spent now: it is not known to
+// be spent and its commitment has been added to the merkle tree of contract,
+// so MerklePath (and therefore BuildBurn) succeeds. A nil contract falls back
+// to note.Contract.
+func (m *Manager) IsNoteSpendable(ctx context.Context, contract *types.Address, note *Note) (bool, error) {
+	if note == nil {
+		return false, fmt.Errorf("%w: note cannot be nil", types.ErrInvalidParameter)
+	}
+	if contract == nil {
+		contract = note.Contract
+	}
+	if contract == nil {
+		return false, fmt.Errorf("%w: contract address cannot be nil", types.ErrInvalidAddress)
+	}
+	if note.IsSpent {
+		return false, nil
+	}
+	if _, _, err := m.MerklePath(ctx, contract, note.Position); err != nil {
+		if errors.Is(err, types.ErrContractExecutionFailed) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// WaitForNoteConfirmation polls until note's commitment is in the merkle tree
+// of note.Contract, so the note can be spent. It returns an error wrapping
+// types.ErrTimeout if the note is still not in the tree after timeout, or the
+// context's error if ctx ends first. A timeout <= 0 waits until ctx ends.
+//
+// Example:
+//
+//	notes, _ := shieldedMgr.ScanNotes(ctx, contract, keys, from, to)
+//	if err := shieldedMgr.WaitForNoteConfirmation(ctx, notes[0], 2*time.Minute); err != nil {
+//	    // handle error
+//	}
+//	txExt, err := shieldedMgr.BuildBurn(ctx, owner, contract, keys, notes[0], owner, amount)
+func (m *Manager) WaitForNoteConfirmation(ctx context.Context, note *Note, timeout time.Duration) error {
+	if note == nil {
+		return fmt.Errorf("%w: note cannot be nil", types.ErrInvalidParameter)
+	}
+	if note.IsSpent {
+		return fmt.Errorf("%w: note at position %d is already spent", types.ErrInvalidParameter, note.Position)
+	}
+	parent := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(notePollInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := m.IsNoteSpendable(ctx, nil, note)
+		if ok {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			if parent.Err() == nil {
+				return fmt.Errorf("%w: note at position %d not in merkle tree after %v", types.ErrTimeout, note.Position, timeout)
+			}
+			return parent.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package signer
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/kslamph/tronlib/pb/core"
+)
+
+// SignTxBatch signs each transaction in txs with s and returns one error per
+// transaction (nil on success), in the same order.
+//
+// PrivateKeySigner and HDWalletSigner hold only an immutable key and are safe
+// for concurrent use, so their batches are signed in parallel across
+// GOMAXPROCS workers. Any other Signer (for example one backed by a KMS or
+// hardware wallet session) is assumed to be stateful and is called
+// sequentially.
+//
+// Example:
+//
+//	errs := signer.SignTxBatch(pk, txs)
+//	for i, err := range errs {
+//	    if err != nil {
+//	        // handle failure for txs[i]
+//	    }
+//	}
+func SignTxBatch(s Signer, txs []*core.Transaction) []error {
+	errs := make([]error, len(txs))
+	sign := func(i int) {
+		if txs[i] == nil {
+			errs[i] = fmt.Errorf("transaction cannot be nil")
+			return
+		}
+		errs[i] = SignTx(s, txs[i])
+	}
+
+	if !isConcurrencySafe(s) || len(txs) < 2 {
+		for i := range txs {
+			sign(i)
+		}
+		return errs
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				sign(i)
+			}
+		}()
+	}
+	for i := range txs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return errs
+}
+
+// isConcurrencySafe reports whether s is a signer implementation known to be
+// safe for concurrent Sign calls.
+func isConcurrencySafe(s Signer) bool {
+	switch s.(type) {
+	case *PrivateKeySigner, *HDWalletSigner:
+		return true
+	default:
+		return false
+	}
+}
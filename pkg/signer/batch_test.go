@@ -0,0 +1,39 @@
+package signer
+
+import (
+	"testing"
+
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignTxBatch(t *testing.T) {
+	pk, err := NewPrivateKeySigner("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+
+	txs := make([]*core.Transaction, 50)
+	for i := range txs {
+		txs[i] = &core.Transaction{RawData: &core.TransactionRaw{Timestamp: int64(i)}}
+	}
+	txs[7] = nil
+
+	for _, s := range []Signer{pk, highSSigner{pk}} {
+		errs := SignTxBatch(s, txs)
+		require.Len(t, errs, len(txs))
+		for i, err := range errs {
+			if i == 7 {
+				assert.Error(t, err)
+				continue
+			}
+			assert.NoError(t, err)
+		}
+	}
+	for i, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		require.Len(t, tx.Signature, 2, "tx %d", i)
+		assert.Equal(t, tx.Signature[0], tx.Signature[1], "deterministic signatures should match for tx %d", i)
+	}
+}
@@ -0,0 +1,56 @@
+package signer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// IsCanonical reports whether sig is a 64- or 65-byte [R || S || V] secp256k1
+// signature whose S value lies in the lower half of the curve order.
+//
+// ECDSA signatures are malleable: (R, S) and (R, N-S) both verify. Many
+// verifiers only accept the low-S form.
+func IsCanonical(sig []byte) bool {
+	if len(sig) != 64 && len(sig) != 65 {
+		return false
+	}
+	s := new(big.Int).SetBytes(sig[32:64])
+	return s.Sign() > 0 && s.Cmp(secp256k1HalfN) <= 0
+}
+
+// NormalizeSignature returns a copy of sig converted to its canonical low-S
+// form. When S is replaced by N-S the recovery ID in V is flipped so the
+// signature still recovers to the same public key; both 0/1 and 27/28 V
+// encodings are handled.
+//
+// Signatures that are not 64 or 65 bytes long, or are already canonical,
+// are returned unchanged (as a copy).
+func NormalizeSignature(sig []byte) []byte {
+	out := append([]byte(nil), sig...)
+	if len(out) != 64 && len(out) != 65 {
+		return out
+	}
+
+	s := new(big.Int).SetBytes(out[32:64])
+	if s.Cmp(secp256k1HalfN) <= 0 {
+		return out
+	}
+
+	s.Sub(secp256k1N, s)
+	s.FillBytes(out[32:64])
+	if len(out) == 65 {
+		switch out[64] {
+		case 0, 1:
+			out[64] ^= 1
+		case 27, 28:
+			out[64] = 55 - out[64]
+		}
+	}
+	return out
+}
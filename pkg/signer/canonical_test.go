@@ -0,0 +1,76 @@
+package signer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// highSSigner wraps a PrivateKeySigner and returns the malleated high-S form
+// of every signature.
+type highSSigner struct {
+	*PrivateKeySigner
+}
+
+func (s highSSigner) Sign(hash []byte) ([]byte, error) {
+	sig, err := s.PrivateKeySigner.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	return toHighS(sig), nil
+}
+
+func toHighS(sig []byte) []byte {
+	out := append([]byte(nil), sig...)
+	sv := new(big.Int).SetBytes(out[32:64])
+	sv.Sub(secp256k1N, sv)
+	sv.FillBytes(out[32:64])
+	out[64] ^= 1
+	return out
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	hash := crypto.Keccak256([]byte("canonical"))
+
+	sig, err := crypto.Sign(hash, key)
+	require.NoError(t, err)
+	require.True(t, IsCanonical(sig))
+
+	high := toHighS(sig)
+	assert.False(t, IsCanonical(high))
+
+	normalized := NormalizeSignature(high)
+	assert.True(t, IsCanonical(normalized))
+	assert.Equal(t, sig, normalized)
+
+	pub, err := crypto.SigToPub(hash, normalized)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pub))
+
+	// 27/28 recovery IDs are flipped as well
+	high[64] += 27
+	assert.Equal(t, sig[64]+27, NormalizeSignature(high)[64])
+
+	// Canonical and malformed input is returned unchanged
+	assert.Equal(t, sig, NormalizeSignature(sig))
+	assert.Equal(t, []byte{1, 2}, NormalizeSignature([]byte{1, 2}))
+	assert.False(t, IsCanonical([]byte{1, 2}))
+}
+
+func TestSignTxNormalizesSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pk, err := NewPrivateKeySignerFromECDSA(key)
+	require.NoError(t, err)
+
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1}}
+	require.NoError(t, SignTx(highSSigner{pk}, tx))
+	require.Len(t, tx.Signature, 1)
+	assert.True(t, IsCanonical(tx.Signature[0]))
+}
@@ -0,0 +1,89 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// MultiSigner combines several signers for multi-signature transactions.
+// SignTx applies every child signer in order, appending one signature each,
+// so a single SignTx or SignAndBroadcast call covers the whole multisig flow.
+// The account permission must already be configured on-chain and, where it
+// is not the owner permission, selected on the transaction before signing.
+type MultiSigner struct {
+	signers []Signer
+}
+
+// NewMultiSigner creates a MultiSigner from signers. The first signer is the
+// primary: its address and key back Address, PublicKey and Sign. At least one
+// signer is required and each address may appear only once.
+//
+// Example:
+//
+//	ms, err := signer.NewMultiSigner(owner, cosigner)
+//	if err != nil {
+//	    // handle error
+//	}
+//	res, err := cli.SignAndBroadcast(ctx, tx, opts, ms)
+func NewMultiSigner(signers ...Signer) (*MultiSigner, error) {
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("multi signer requires at least one signer")
+	}
+	seen := make(map[string]bool, len(signers))
+	for i, s := range signers {
+		if s == nil || s.Address() == nil {
+			return nil, fmt.Errorf("signer %d is nil", i)
+		}
+		addr := s.Address().String()
+		if seen[addr] {
+			return nil, fmt.Errorf("duplicate signer %s", addr)
+		}
+		seen[addr] = true
+	}
+	return &MultiSigner{signers: append([]Signer(nil), signers...)}, nil
+}
+
+// Address returns the primary signer's address.
+func (m *MultiSigner) Address() *types.Address {
+	return m.signers[0].Address()
+}
+
+// PublicKey returns the primary signer's public key.
+func (m *MultiSigner) PublicKey() *ecdsa.PublicKey {
+	return m.signers[0].PublicKey()
+}
+
+// Sign signs hash with the primary signer only. Use SignTx to collect the
+// signatures of every child signer.
+func (m *MultiSigner) Sign(hash []byte) ([]byte, error) {
+	return m.signers[0].Sign(hash)
+}
+
+// Signers returns the child signers in signing order.
+func (m *MultiSigner) Signers() []Signer {
+	return append([]Signer(nil), m.signers...)
+}
+
+// signAll signs hash with every child signer and returns sigs with the new
+// normalized signatures appended. It fails without modifying sigs if any
+// signature is already present.
+func (m *MultiSigner) signAll(hash []byte, sigs [][]byte) ([][]byte, error) {
+	out := append([][]byte(nil), sigs...)
+	for _, s := range m.signers {
+		sig, err := s.Sign(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign transaction with %s: %w", s.Address(), err)
+		}
+		sig = NormalizeSignature(sig)
+		for _, existing := range out {
+			if bytes.Equal(existing, sig) {
+				return nil, fmt.Errorf("duplicate signature from %s", s.Address())
+			}
+		}
+		out = append(out, sig)
+	}
+	return out, nil
+}
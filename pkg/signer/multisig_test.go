@@ -0,0 +1,38 @@
+package signer
+
+import (
+	"testing"
+
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSigner(t *testing.T) {
+	pk1, err := NewPrivateKeySigner("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	pk2, err := NewPrivateKeySigner("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+
+	_, err = NewMultiSigner()
+	assert.Error(t, err)
+	_, err = NewMultiSigner(pk1, pk1)
+	assert.Error(t, err)
+
+	ms, err := NewMultiSigner(pk1, pk2)
+	require.NoError(t, err)
+	assert.Equal(t, pk1.Address().String(), ms.Address().String())
+
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1}}
+	require.NoError(t, SignTx(ms, tx))
+	require.Len(t, tx.Signature, 2)
+
+	single := &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1}}
+	require.NoError(t, SignTx(pk1, single))
+	require.NoError(t, SignTx(pk2, single))
+	assert.Equal(t, single.Signature, tx.Signature)
+
+	// Signing again would duplicate both signatures and must leave tx intact.
+	assert.Error(t, SignTx(ms, tx))
+	assert.Len(t, tx.Signature, 2)
+}
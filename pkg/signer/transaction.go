@@ -22,7 +22,11 @@ import (
 // raw data. Changing transaction parameters after signing will invalidate the signature.
 //
 // For multi-signature transactions, call this function multiple times with different
-// signers on the same transaction object.
+// signers on the same transaction object, or pass a MultiSigner to apply all of
+// them at once.
+//
+// Signatures are normalized to the canonical low-S form before being attached,
+// so Signer implementations backed by external key stores may return either form.
 //
 // Example:
 //
@@ -62,12 +66,11 @@ func SignTx(s Signer, tx any) error {
 		h256h.Write(rawData)
 		hash := h256h.Sum(nil)
 
-		// Sign the hash
-		signature, err := s.Sign(hash)
+		sigs, err := appendSignatures(s, hash, t.Signature)
 		if err != nil {
-			return fmt.Errorf("failed to sign transaction: %w", err)
+			return err
 		}
-		t.Signature = append(t.Signature, signature)
+		t.Signature = sigs
 
 	case *api.TransactionExtention:
 		rawData, err = proto.Marshal(t.GetTransaction().GetRawData())
@@ -79,12 +82,11 @@ func SignTx(s Signer, tx any) error {
 		h256h.Write(rawData)
 		hash := h256h.Sum(nil)
 
-		// Sign the hash
-		signature, err := s.Sign(hash)
+		sigs, err := appendSignatures(s, hash, t.Transaction.Signature)
 		if err != nil {
-			return fmt.Errorf("failed to sign transaction: %w", err)
+			return err
 		}
-		t.Transaction.Signature = append(t.Transaction.Signature, signature)
+		t.Transaction.Signature = sigs
 
 	default:
 		return fmt.Errorf("unsupported transaction type: %T", tx)
@@ -93,6 +95,19 @@ func SignTx(s Signer, tx any) error {
 	return nil
 }
 
+// appendSignatures signs hash with s and returns sigs with the normalized
+// signature appended. A MultiSigner contributes one signature per child.
+func appendSignatures(s Signer, hash []byte, sigs [][]byte) ([][]byte, error) {
+	if ms, ok := s.(*MultiSigner); ok {
+		return ms.signAll(hash, sigs)
+	}
+	signature, err := s.Sign(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return append(sigs, NormalizeSignature(signature)), nil
+}
+
 // SignMessageV2 signs a message using the TIP-191 format (v2) with the provided signer.
 // It prefixes the message, hashes it, and then signs the hash.
 //
@@ -127,6 +142,7 @@ func SignMessageV2(s Signer, message string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to sign message: %w", err)
 	}
+	signature = NormalizeSignature(signature)
 
 	// Adjust the recovery ID (v). go-ethereum's Sign function returns
 	// the signature in [R || S || V] format, where V is 0 or 1. Tron
@@ -0,0 +1,63 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// ComputeCreate2Address returns the address a contract will be deployed to by
+// the CREATE2 opcode, given the deploying contract, the salt and the keccak256
+// hash of the init code.
+//
+// TRON hashes the 0x41-prefixed deployer address where Ethereum uses a 0xff
+// marker byte: keccak256(0x41 ++ deployer ++ salt ++ initCodeHash). The last
+// 20 bytes of the hash are taken and prefixed with 0x41.
+//
+// Returns nil if deployer is nil.
+func ComputeCreate2Address(deployer *types.Address, salt [32]byte, initCodeHash [32]byte) *types.Address {
+	if deployer == nil {
+		return nil
+	}
+	data := make([]byte, 0, 21+32+32)
+	data = append(data, deployer.Bytes()...)
+	data = append(data, salt[:]...)
+	data = append(data, initCodeHash[:]...)
+	return addressFromHash(crypto.Keccak256(data))
+}
+
+// ComputeContractAddress returns the address of a contract created by a
+// CreateSmartContract transaction, given the transaction ID and the owner
+// (deployer) address.
+//
+// Unlike Ethereum, TRON does not use an account nonce for top-level
+// deployments; the address is derived from keccak256(txID ++ owner). This is
+// the address the node reports once the deployment transaction is included.
+//
+// Returns nil if owner is nil.
+func ComputeContractAddress(txID []byte, owner *types.Address) *types.Address {
+	if owner == nil {
+		return nil
+	}
+	data := make([]byte, 0, len(txID)+21)
+	data = append(data, txID...)
+	data = append(data, owner.Bytes()...)
+	return addressFromHash(crypto.Keccak256(data))
+}
+
+// ComputeCreateAddress returns the address of a contract created by the
+// CREATE opcode during execution of a transaction. TRON derives it from the
+// root transaction ID and an internal nonce that counts contract creations
+// within that transaction, starting at 0: keccak256(rootTxID ++ uint64be(nonce)).
+func ComputeCreateAddress(rootTxID []byte, nonce uint64) *types.Address {
+	data := make([]byte, 0, len(rootTxID)+8)
+	data = append(data, rootTxID...)
+	data = binary.BigEndian.AppendUint64(data, nonce)
+	return addressFromHash(crypto.Keccak256(data))
+}
+
+// addressFromHash takes the last 20 bytes of a 32-byte hash as a TRON address.
+func addressFromHash(hash []byte) *types.Address {
+	return types.MustNewAddressFromBytes(hash[12:])
+}
@@ -0,0 +1,82 @@
+package smartcontract
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// The expected addresses below are fixed known-answer values, not derived in
+// the test, so a change to the hashing scheme fails them. The node-reported
+// address of a real deployment is checked against ComputeContractAddress in
+// integration_test/write_tests.
+
+var vectorDeployer = types.MustNewAddressFromHex("41a614f803b6fd780986a42c78ec9c7f77e6ded13c")
+
+func mustHash32(t *testing.T, s string) [32]byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 32 {
+		t.Fatalf("bad 32-byte hex %q", s)
+	}
+	var h [32]byte
+	copy(h[:], b)
+	return h
+}
+
+func TestComputeCreate2Address(t *testing.T) {
+	cases := []struct {
+		name         string
+		salt         string
+		initCodeHash string
+		want         string
+	}{
+		{
+			name:         "zero salt and hash",
+			salt:         "0000000000000000000000000000000000000000000000000000000000000000",
+			initCodeHash: "0000000000000000000000000000000000000000000000000000000000000000",
+			want:         "TLpKbHw3fc4m51PL1Ao6MXFoNzQUTRuFr4",
+		},
+		{
+			// initCodeHash is keccak256(0x60806040)
+			name:         "salt 1",
+			salt:         "0000000000000000000000000000000000000000000000000000000000000001",
+			initCodeHash: "c688f92bc1557ca1b3c5a2e10c354abf09210aebb62fadc4b62310122f8d377b",
+			want:         "TSFx9DBiyNfipfbZzoDEap5aMaJAQRG46b",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ComputeCreate2Address(vectorDeployer, mustHash32(t, tc.salt), mustHash32(t, tc.initCodeHash))
+			if got == nil || got.String() != tc.want {
+				t.Errorf("ComputeCreate2Address() = %v, want %s", got, tc.want)
+			}
+		})
+	}
+
+	if ComputeCreate2Address(nil, [32]byte{}, [32]byte{}) != nil {
+		t.Error("expected nil for nil deployer")
+	}
+}
+
+func TestComputeContractAddress(t *testing.T) {
+	txID, _ := hex.DecodeString("f4e654a0a9b2953a6fd9084842d9b9abc308341e6cd2ab57856441c542e51525")
+
+	if got := ComputeContractAddress(txID, vectorDeployer); got.String() != "TMk4dzywFr4SkAu838wyRzXLR5jYNqeze6" {
+		t.Errorf("ComputeContractAddress() = %s, want TMk4dzywFr4SkAu838wyRzXLR5jYNqeze6", got)
+	}
+	if ComputeContractAddress(txID, nil) != nil {
+		t.Error("expected nil for nil owner")
+	}
+}
+
+func TestComputeCreateAddress(t *testing.T) {
+	root, _ := hex.DecodeString("d6c66cad06fe14fdb6ce9297d80d32f24d7428996d0045cbf90cc345c677ba16")
+
+	for nonce, want := range []string{"TScBpoo3GGTwizXDoZ6ouD8kSvynYqgpp5", "TDGT2Ai1ctE49PYUT68HTQY2fyJNcGRMWL"} {
+		if got := ComputeCreateAddress(root, uint64(nonce)); got.String() != want {
+			t.Errorf("ComputeCreateAddress(nonce %d) = %s, want %s", nonce, got, want)
+		}
+	}
+}
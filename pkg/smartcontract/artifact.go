@@ -0,0 +1,135 @@
+package smartcontract
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+)
+
+// DeployOptions carries the deployment settings that Deploy otherwise takes
+// positionally.
+type DeployOptions struct {
+	// Name overrides the contract name; defaults to the artifact's contractName.
+	Name string
+	// FeeLimit, if non-zero, is set on the returned transaction (in SUN).
+	// Note that client.SignAndBroadcast applies BroadcastOptions.FeeLimit,
+	// which takes precedence when signing through it.
+	FeeLimit int64
+	// ConsumeUserResourcePercent is the share of energy paid by callers (0-100).
+	ConsumeUserResourcePercent int64
+	// OriginEnergyLimit caps the energy the deployer provides per call.
+	OriginEnergyLimit int64
+	// CallValue is the TRX sent to the constructor (in SUN). The constructor
+	// must be payable when this is non-zero.
+	CallValue int64
+	// TokenID and TokenValue send a TRC10 token amount with the deployment.
+	TokenID    int64
+	TokenValue int64
+}
+
+// DefaultDeployOptions returns deployment options where callers pay all
+// energy and the origin energy limit is 10,000,000.
+func DefaultDeployOptions() DeployOptions {
+	return DeployOptions{
+		ConsumeUserResourcePercent: 100,
+		OriginEnergyLimit:          10_000_000,
+	}
+}
+
+// Artifact is a compiled contract as emitted by Hardhat, Truffle or Foundry.
+type Artifact struct {
+	ContractName string
+	ABI          string
+	Bytecode     []byte
+}
+
+// ParseArtifact extracts the contract name, ABI and creation bytecode from a
+// compiler artifact JSON. The bytecode may be a hex string (with or without
+// 0x prefix) or a Foundry-style object with an "object" field.
+func ParseArtifact(artifactJSON []byte) (*Artifact, error) {
+	var raw struct {
+		ContractName string          `json:"contractName"`
+		ABI          json.RawMessage `json:"abi"`
+		Bytecode     json.RawMessage `json:"bytecode"`
+	}
+	if err := json.Unmarshal(artifactJSON, &raw); err != nil {
+		return nil, fmt.Errorf("%w: invalid artifact JSON: %v", types.ErrInvalidParameter, err)
+	}
+	if len(raw.ABI) == 0 || string(raw.ABI) == "null" {
+		return nil, fmt.Errorf("%w: artifact has no abi field", types.ErrInvalidParameter)
+	}
+	if len(raw.Bytecode) == 0 || string(raw.Bytecode) == "null" {
+		return nil, fmt.Errorf("%w: artifact has no bytecode field", types.ErrInvalidParameter)
+	}
+
+	var code string
+	if err := json.Unmarshal(raw.Bytecode, &code); err != nil {
+		var obj struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(raw.Bytecode, &obj); err != nil {
+			return nil, fmt.Errorf("%w: artifact bytecode must be a hex string or an object with an object field", types.ErrInvalidParameter)
+		}
+		code = obj.Object
+	}
+
+	code = strings.TrimPrefix(strings.TrimSpace(code), "0x")
+	if code == "" {
+		return nil, fmt.Errorf("%w: artifact bytecode is empty (abstract contract or interface?)", types.ErrInvalidParameter)
+	}
+	if strings.Contains(code, "__") {
+		return nil, fmt.Errorf("%w: artifact bytecode contains unlinked library placeholders", types.ErrInvalidParameter)
+	}
+	bytecode, err := hex.DecodeString(code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid artifact bytecode: %v", types.ErrInvalidParameter, err)
+	}
+
+	return &Artifact{
+		ContractName: raw.ContractName,
+		ABI:          string(raw.ABI),
+		Bytecode:     bytecode,
+	}, nil
+}
+
+// DeployFromArtifact deploys a contract from a compiler artifact JSON
+// containing "abi" and "bytecode" fields, encoding constructorParams with
+// the artifact ABI. The transaction is not signed or broadcast.
+//
+// Example:
+//
+//	artifact, _ := os.ReadFile("artifacts/contracts/Token.sol/Token.json")
+//	txExt, err := contractMgr.DeployFromArtifact(ctx, owner, artifact, smartcontract.DefaultDeployOptions(), "Token", "TKN")
+//	if err != nil {
+//	    // handle error
+//	}
+func (m *Manager) DeployFromArtifact(ctx context.Context, ownerAddress *types.Address, artifactJSON []byte, opts DeployOptions, constructorParams ...interface{}) (*api.TransactionExtention, error) {
+	artifact, err := ParseArtifact(artifactJSON)
+	if err != nil {
+		return nil, err
+	}
+	if opts.FeeLimit < 0 {
+		return nil, fmt.Errorf("%w: fee limit cannot be negative", types.ErrInvalidParameter)
+	}
+
+	if opts.Name == "" {
+		opts.Name = artifact.ContractName
+	}
+
+	txExt, err := m.deploy(ctx, ownerAddress, artifact.ABI, artifact.Bytecode, opts, constructorParams)
+	if err != nil {
+		return nil, err
+	}
+	if opts.FeeLimit > 0 {
+		if err := utils.SetFeeLimit(txExt, opts.FeeLimit); err != nil {
+			return nil, fmt.Errorf("%w: failed to set fee limit: %v", types.ErrInvalidTransaction, err)
+		}
+	}
+	return txExt, nil
+}
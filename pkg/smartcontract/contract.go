@@ -1,13 +1,16 @@
 package smartcontract
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/kslamph/tronlib/pb/api"
 	"github.com/kslamph/tronlib/pb/core"
 	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/eventdecoder"
 	"github.com/kslamph/tronlib/pkg/types"
 	"github.com/kslamph/tronlib/pkg/utils"
 )
@@ -32,6 +35,8 @@ type methodCache struct {
 // its methods, both state-changing (Invoke) and read-only (Call) functions.
 // It handles ABI encoding/decoding automatically.
 type Instance struct {
+	// ABI is shared with other instances created from the same ABI JSON
+	// (see utils.ParseABICached) and must not be modified.
 	ABI     *core.SmartContract_ABI
 	Address *types.Address
 	Client  contractClient
@@ -46,15 +51,50 @@ type Instance struct {
 	// Cache for constructor types
 	constructorTypes []string
 	constructorOnce  sync.Once
+
+	// feeLimit is stamped on transactions built by Invoke and InvokeRaw
+	feeLimit int64
+
+	// archive serves CallAtBlock; see WithArchive
+	archive HistoricalCaller
 }
 
+// defaultFeeLimit is the fee limit, in SUN, stamped on built transactions
+// when NewInstance is not given WithDefaultFeeLimit. It matches the fallback
+// of client.SignAndBroadcast (150 TRX).
+const defaultFeeLimit = 150_000_000
+
+// InstanceOption configures an Instance. Options are passed to NewInstance
+// alongside the ABI argument.
+type InstanceOption func(*Instance)
+
+// WithDefaultFeeLimit sets the fee limit, in SUN, that Invoke and InvokeRaw
+// stamp on the transactions they build; zero leaves the fee limit unset. The
+// default is 150 TRX.
+//
+// A non-zero BroadcastOptions.FeeLimit (or AutoFeeLimit) passed to
+// client.SignAndBroadcast replaces the stamped value; with FeeLimit left zero
+// the stamped value is kept.
+func WithDefaultFeeLimit(sun int64) InstanceOption {
+	return func(i *Instance) {
+		i.feeLimit = sun
+	}
+}
+
+// ErrNoABIOnChain is returned by NewInstance when the ABI is to be fetched
+// from the network but the contract has none stored. It wraps
+// types.ErrNotFound.
+var ErrNoABIOnChain = fmt.Errorf("%w: contract has no ABI stored on chain", types.ErrNotFound)
+
 // NewInstance constructs a contract instance for the given address using the
-// provided TRON client. The ABI can be omitted to fetch from the network, or
-// supplied as either a JSON string or a *core.SmartContract_ABI.
+// provided TRON client. The ABI can be omitted (or given as "") to fetch from
+// the network, or supplied as either a JSON string or a *core.SmartContract_ABI.
+// InstanceOption values such as WithDefaultFeeLimit may follow the ABI, or
+// replace it when the ABI is fetched.
 //
 // This function creates a new Instance for interacting with a deployed smart contract.
-// If no ABI is provided, it will be fetched from the network (the contract must have
-// its ABI published on-chain).
+// If no ABI is provided, it will be fetched from the network; ErrNoABIOnChain
+// is returned when the contract has no ABI published on-chain.
 //
 // Example:
 //
@@ -66,10 +106,24 @@ type Instance struct {
 //
 //	// Without ABI (fetch from network)
 //	instance, err := smartcontract.NewInstance(cli, contractAddr)
-//	if err != nil {
-//	    // handle error
+//	if errors.Is(err, smartcontract.ErrNoABIOnChain) {
+//	    // contract was deployed without an ABI
 //	}
+//
+//	// With a default fee limit of 50 TRX on built transactions
+//	instance, err := smartcontract.NewInstance(cli, contractAddr, abiJSON, smartcontract.WithDefaultFeeLimit(50_000_000))
 func NewInstance(tronClient contractClient, contractAddress *types.Address, abi ...any) (*Instance, error) {
+	var opts []InstanceOption
+	abiArgs := abi[:0:0]
+	for _, a := range abi {
+		if opt, ok := a.(InstanceOption); ok {
+			opts = append(opts, opt)
+			continue
+		}
+		abiArgs = append(abiArgs, a)
+	}
+	abi = abiArgs
+
 	if tronClient == nil {
 		return nil, fmt.Errorf("%w: tron client cannot be nil", types.ErrInvalidParameter)
 	}
@@ -84,26 +138,23 @@ func NewInstance(tronClient contractClient, contractAddress *types.Address, abi
 	// Process ABI parameter
 	if len(abi) == 0 {
 		// No ABI provided - retrieve from network
-		ctx, cancel := context.WithTimeout(context.Background(), tronClient.GetTimeout())
-		defer cancel()
-		contractInfo, err := getContractFromNetwork(ctx, tronClient, contractAddress)
+		contractABI, err = fetchABI(tronClient, contractAddress)
 		if err != nil {
-			return nil, fmt.Errorf("%w: failed to retrieve contract from network: %v", types.ErrNetworkError, err)
-		}
-		if contractInfo.GetAbi() == nil {
-			return nil, fmt.Errorf("%w: contract has no ABI available on network", types.ErrNotFound)
+			return nil, err
 		}
-		contractABI = contractInfo.GetAbi()
 	} else if len(abi) == 1 {
 		// ABI provided - process based on type
 		switch v := abi[0].(type) {
 		case string:
-			// Handle ABI JSON string
+			// An empty ABI string also retrieves it from the network
 			if v == "" {
-				return nil, fmt.Errorf("%w: empty ABI string", types.ErrInvalidContract)
+				contractABI, err = fetchABI(tronClient, contractAddress)
+				if err != nil {
+					return nil, err
+				}
+				break
 			}
-			processor := utils.NewABIProcessor(nil)
-			contractABI, err = processor.ParseABI(v)
+			contractABI, err = utils.ParseABICached(v)
 			if err != nil {
 				return nil, fmt.Errorf("%w: failed to parse ABI string: %v", types.ErrInvalidContract, err)
 			}
@@ -122,14 +173,43 @@ func NewInstance(tronClient contractClient, contractAddress *types.Address, abi
 		return nil, fmt.Errorf("%w: too many ABI arguments provided, expected 0 or 1", types.ErrInvalidParameter)
 	}
 
-	return &Instance{
+	instance := &Instance{
 		ABI:     contractABI,
 		Address: contractAddress,
 		Client:  tronClient,
 
 		abiProcessor: utils.NewABIProcessor(contractABI),
 		abiCache:     make(map[string]*methodCache),
-	}, nil
+		feeLimit:     defaultFeeLimit,
+	}
+	for _, opt := range opts {
+		opt(instance)
+	}
+	if instance.feeLimit < 0 {
+		return nil, fmt.Errorf("%w: default fee limit cannot be negative, got %d", types.ErrInvalidParameter, instance.feeLimit)
+	}
+	return instance, nil
+}
+
+// DefaultFeeLimit returns the fee limit, in SUN, stamped on transactions
+// built by Invoke and InvokeRaw; zero means none is stamped.
+func (i *Instance) DefaultFeeLimit() int64 {
+	return i.feeLimit
+}
+
+// fetchABI retrieves the ABI stored on chain for contractAddress, failing
+// with ErrNoABIOnChain when the contract has none.
+func fetchABI(tronClient contractClient, contractAddress *types.Address) (*core.SmartContract_ABI, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tronClient.GetTimeout())
+	defer cancel()
+	contractInfo, err := getContractFromNetwork(ctx, tronClient, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to retrieve contract from network: %v", types.ErrNetworkError, err)
+	}
+	if len(contractInfo.GetAbi().GetEntrys()) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoABIOnChain, contractAddress)
+	}
+	return contractInfo.GetAbi(), nil
 }
 
 // getContractFromNetwork retrieves smart contract information from the network
@@ -182,6 +262,28 @@ func (i *Instance) Invoke(ctx context.Context, owner *types.Address, callValue i
 		return nil, fmt.Errorf("%w: failed to encode input for method %s: %v", types.ErrInvalidContract, method, err)
 	}
 
+	return i.InvokeRaw(ctx, owner, callValue, data)
+}
+
+// InvokeRaw builds a transaction that calls the contract with pre-encoded
+// call data, bypassing the ABI. Use it to replay calldata produced elsewhere
+// or to call methods missing from the local ABI; empty data targets the
+// contract's receive or fallback function. The result should be signed and
+// broadcasted by the caller. Like Invoke, it stamps the instance's default fee
+// limit (see WithDefaultFeeLimit) when the node left the fee limit unset.
+//
+// Example:
+//
+//	data, _ := hex.DecodeString("a9059cbb...")
+//	txExt, err := instance.InvokeRaw(ctx, owner, 0, data)
+func (i *Instance) InvokeRaw(ctx context.Context, owner *types.Address, callValue int64, data []byte) (*api.TransactionExtention, error) {
+	if owner == nil {
+		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
+	}
+	if callValue < 0 {
+		return nil, fmt.Errorf("%w: call value cannot be negative, got %d", types.ErrInvalidParameter, callValue)
+	}
+
 	// Create trigger smart contract request
 	req := &core.TriggerSmartContract{
 		OwnerAddress:    owner.Bytes(),
@@ -192,10 +294,22 @@ func (i *Instance) Invoke(ctx context.Context, owner *types.Address, callValue i
 		TokenId:         0,
 	}
 
-	return lowlevel.TxCall(i.Client, ctx, "trigger contract", func(cl api.WalletClient, ctx context.Context) (*api.TransactionExtention, error) {
+	txExt, err := lowlevel.TxCall(i.Client, ctx, "trigger contract", func(cl api.WalletClient, ctx context.Context) (*api.TransactionExtention, error) {
 		return cl.TriggerContract(ctx, req)
 	})
-
+	if err != nil {
+		return nil, err
+	}
+	if raw := txExt.GetTransaction().GetRawData(); i.feeLimit > 0 && raw != nil && raw.GetFeeLimit() == 0 {
+		raw.FeeLimit = i.feeLimit
+		// The fee limit is part of the raw data, so the txid changes
+		txid, err := types.TransactionID(txExt.GetTransaction())
+		if err != nil {
+			return nil, err
+		}
+		txExt.Txid = txid
+	}
+	return txExt, nil
 }
 
 // Call performs a constant (read-only) method call and returns the decoded
@@ -224,7 +338,65 @@ func (i *Instance) Invoke(ctx context.Context, owner *types.Address, callValue i
 //	}
 //	fmt.Printf("Value: %d\n", value)
 func (i *Instance) Call(ctx context.Context, owner *types.Address, method string, params ...interface{}) (interface{}, error) {
+	data, err := i.constantCall(ctx, owner, method, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := i.DecodeResult(method, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode result for method %s: %v", types.ErrInvalidContract, method, err)
+	}
+
+	return decoded, nil
+}
 
+// CallValues performs a constant (read-only) method call like Call, but
+// always returns one decoded value per ABI output, in order, so callers need
+// not special-case single-output methods. Values use native Go types:
+// address -> *types.Address, uintN/intN above 64 bits -> *big.Int, smaller
+// widths -> the matching Go integer, bytes -> []byte, T[] -> []interface{}.
+//
+// Example:
+//
+//	values, err := instance.CallValues(ctx, caller, "getReserves")
+//	if err != nil {
+//	    // handle error
+//	}
+//	reserve0 := values[0].(*big.Int)
+func (i *Instance) CallValues(ctx context.Context, caller *types.Address, method string, args ...interface{}) ([]interface{}, error) {
+	_, outputTypes, err := i.getMethodTypes(method)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get method types: %v", types.ErrInvalidContract, err)
+	}
+
+	data, err := i.constantCall(ctx, caller, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := i.DecodeResult(method, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode result for method %s: %v", types.ErrInvalidContract, method, err)
+	}
+
+	switch len(outputTypes) {
+	case 0:
+		return []interface{}{}, nil
+	case 1:
+		return []interface{}{decoded}, nil
+	default:
+		values, ok := decoded.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected decoded type %T for method %s", types.ErrInvalidContract, decoded, method)
+		}
+		return values, nil
+	}
+}
+
+// constantCall runs method through TriggerConstantContract and returns the
+// concatenated constant result bytes.
+func (i *Instance) constantCall(ctx context.Context, owner *types.Address, method string, params ...interface{}) ([]byte, error) {
 	if owner == nil {
 		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
 	}
@@ -235,6 +407,22 @@ func (i *Instance) Call(ctx context.Context, owner *types.Address, method string
 		return nil, fmt.Errorf("%w: failed to encode input for method %s: %v", types.ErrInvalidContract, method, err)
 	}
 
+	return i.CallRaw(ctx, owner, data)
+}
+
+// CallRaw performs a constant (read-only) call with pre-encoded call data,
+// bypassing the ABI, and returns the raw return bytes. Decode them with
+// DecodeResult when the method is in the ABI.
+//
+// Example:
+//
+//	data, _ := hex.DecodeString("70a08231...")
+//	ret, err := instance.CallRaw(ctx, caller, data)
+func (i *Instance) CallRaw(ctx context.Context, owner *types.Address, data []byte) ([]byte, error) {
+	if owner == nil {
+		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
+	}
+
 	// Create trigger smart contract request
 	req := &core.TriggerSmartContract{
 		OwnerAddress:    owner.Bytes(),
@@ -261,37 +449,66 @@ func (i *Instance) Call(ctx context.Context, owner *types.Address, method string
 		return nil, fmt.Errorf("%w: empty constant result", types.ErrInvalidContract)
 	}
 
-	// Decode the result using the contract's DecodeResult method
-	// The constant result is typically a single byte slice, but it's returned as a slice of byte slices
-	// We concatenate all the byte slices to form a single byte slice for decoding
-	// This handles cases where the result might be split across multiple slices
+	// The constant result is returned as a slice of byte slices; concatenate
+	// them to handle results split across multiple slices
 	var concatenatedResult []byte
 	for _, result := range constantResult {
 		concatenatedResult = append(concatenatedResult, result...)
 	}
-
-	decoded, err := i.DecodeResult(method, concatenatedResult)
-	if err != nil {
-		return nil, fmt.Errorf("%w: failed to decode result for method %s: %v", types.ErrInvalidContract, method, err)
-	}
-
-	return decoded, nil
+	return concatenatedResult, nil
 }
 
-// SimulateResult captures details from a constant-call simulation.
-type SimulateResult struct {
-	Energy    int64
+// SimulationResult is the outcome of Instance.Simulate.
+type SimulationResult struct {
+	// Success reports whether the call executed without reverting.
+	Success bool
+	// Message is the node's result message, e.g. the revert reason.
+	Message string
+	// EnergyUsage is the energy the call consumed.
+	EnergyUsage int64
+	// Logs are the raw event logs emitted by the call.
+	Logs []*core.TransactionInfo_Log
+	// DecodedEvents are Logs decoded with the instance ABI and the global
+	// event registry, in the same order.
+	DecodedEvents []*eventdecoder.DecodedEvent
+	// Extention is the raw TriggerConstantContract response.
+	Extention *api.TransactionExtention
+
+	// Deprecated: use EnergyUsage.
+	Energy int64
+	// Deprecated: use Success and Message, or Extention.GetResult().
 	APIResult *api.Return
-	Logs      []*core.TransactionInfo_Log
 }
 
+// SimulateResult is the former name of SimulationResult.
+//
+// Deprecated: use SimulationResult.
+type SimulateResult = SimulationResult
+
 // Simulate performs a read-only execution of the specified method and returns
-// energy usage, raw API result, and logs without decoding the return value.
-func (i *Instance) Simulate(ctx context.Context, owner *types.Address, callValue int64, method string, params ...interface{}) (*SimulateResult, error) {
+// whether it would succeed, its energy usage and its decoded events, without
+// decoding the return value.
+//
+// Example:
+//
+//	sim, err := instance.Simulate(ctx, owner, 0, "transfer", to, amount)
+//	if err != nil {
+//	    // handle error
+//	}
+//	if !sim.Success {
+//	    fmt.Println("would revert:", sim.Message)
+//	}
+//	for _, ev := range sim.DecodedEvents {
+//	    fmt.Println(ev.EventName)
+//	}
+func (i *Instance) Simulate(ctx context.Context, owner *types.Address, callValue int64, method string, params ...interface{}) (*SimulationResult, error) {
 
 	if owner == nil {
 		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
 	}
+	if callValue < 0 {
+		return nil, fmt.Errorf("%w: call value cannot be negative, got %d", types.ErrInvalidParameter, callValue)
+	}
 
 	// Encode method call data
 	data, err := i.Encode(method, params...)
@@ -304,7 +521,7 @@ func (i *Instance) Simulate(ctx context.Context, owner *types.Address, callValue
 		OwnerAddress:    owner.Bytes(),
 		ContractAddress: i.Address.Bytes(),
 		Data:            data,
-		CallValue:       0,
+		CallValue:       callValue,
 	}
 
 	// Call the constant contract
@@ -318,49 +535,122 @@ func (i *Instance) Simulate(ctx context.Context, owner *types.Address, callValue
 	if result == nil {
 		return nil, fmt.Errorf("%w: nil result from constant contract call", types.ErrInvalidContract)
 	}
-	return &SimulateResult{
+
+	success := result.GetResult().GetResult()
+	if ret := result.GetTransaction().GetRet(); len(ret) > 0 {
+		success = success && ret[0].GetRet() == core.Transaction_Result_SUCESS
+	}
+	return &SimulationResult{
+		Success:       success,
+		Message:       string(result.GetResult().GetMessage()),
+		EnergyUsage:   result.GetEnergyUsed(),
+		Logs:          result.GetLogs(),
+		DecodedEvents: i.decodeLogs(result.GetLogs()),
+		Extention:     result,
+
 		Energy:    result.GetEnergyUsed(),
 		APIResult: result.GetResult(),
-		Logs:      result.GetLogs(),
 	}, nil
 
 }
 
-// getMethodTypes retrieves method types from cache or ABI
-func (i *Instance) getMethodTypes(methodName string) ([]string, []string, error) {
+// decodeLogs decodes logs emitted by this contract with DecodeEventLog and
+// any other logs, e.g. from contracts it calls, with the global registry.
+func (i *Instance) decodeLogs(logs []*core.TransactionInfo_Log) []*eventdecoder.DecodedEvent {
+	events := make([]*eventdecoder.DecodedEvent, 0, len(logs))
+	for _, lg := range logs {
+		if lg == nil {
+			continue
+		}
+		if addr, err := types.NewAddressFromBytes(lg.GetAddress()); err == nil && addr.String() == i.Address.String() {
+			if ev, err := i.DecodeEventLog(lg.GetTopics(), lg.GetData()); err == nil {
+				events = append(events, ev)
+				continue
+			}
+		}
+		decoded, _ := eventdecoder.DecodeLogs([]*core.TransactionInfo_Log{lg})
+		events = append(events, decoded...)
+	}
+	return events
+}
+
+// getMethodTypes retrieves method types from cache or ABI. method is either
+// a bare name, which must identify a single function, or a full signature
+// such as "foo(address)" selecting one overload.
+func (i *Instance) getMethodTypes(method string) ([]string, []string, error) {
 	// Try to get from cache first
 	i.abiCacheLock.RLock()
-	if cache, ok := i.abiCache[methodName]; ok {
+	if cache, ok := i.abiCache[method]; ok {
 		i.abiCacheLock.RUnlock()
 		return cache.inputTypes, cache.outputTypes, nil
 	}
 	i.abiCacheLock.RUnlock()
 
 	// Not in cache, parse from ABI
-	for _, entry := range i.ABI.Entrys {
-		if entry.Name == methodName && entry.Type == core.SmartContract_ABI_Entry_Function {
-			inputTypes := make([]string, len(entry.Inputs))
-			for i, input := range entry.Inputs {
-				inputTypes[i] = input.Type
-			}
+	entry, err := i.findMethod(method)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			outputTypes := make([]string, len(entry.Outputs))
-			for i, output := range entry.Outputs {
-				outputTypes[i] = output.Type
-			}
+	inputTypes := make([]string, len(entry.Inputs))
+	for i, input := range entry.Inputs {
+		inputTypes[i] = input.Type
+	}
 
-			// Cache the result
-			i.abiCacheLock.Lock()
-			i.abiCache[methodName] = &methodCache{
-				inputTypes:  inputTypes,
-				outputTypes: outputTypes,
-			}
-			i.abiCacheLock.Unlock()
+	outputTypes := make([]string, len(entry.Outputs))
+	for i, output := range entry.Outputs {
+		outputTypes[i] = output.Type
+	}
+
+	// Cache the result
+	i.abiCacheLock.Lock()
+	i.abiCache[method] = &methodCache{
+		inputTypes:  inputTypes,
+		outputTypes: outputTypes,
+	}
+	i.abiCacheLock.Unlock()
+
+	return inputTypes, outputTypes, nil
+}
+
+// findMethod returns the function entry matching method. A full signature
+// matches exactly; a bare name must match a single function, otherwise the
+// error lists the overloads to choose from.
+func (i *Instance) findMethod(method string) (*core.SmartContract_ABI_Entry, error) {
+	name := methodName(method)
+	signature := strings.ReplaceAll(method, " ", "")
+
+	var candidates []*core.SmartContract_ABI_Entry
+	for _, entry := range i.ABI.Entrys {
+		if entry.Name != name || entry.Type != core.SmartContract_ABI_Entry_Function {
+			continue
+		}
+		if name != method && entrySignature(entry) != signature {
+			continue
+		}
+		candidates = append(candidates, entry)
+	}
 
-			return inputTypes, outputTypes, nil
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("method %s not found", method)
+	case 1:
+		return candidates[0], nil
+	default:
+		sigs := make([]string, len(candidates))
+		for j, entry := range candidates {
+			sigs[j] = entrySignature(entry)
 		}
+		return nil, fmt.Errorf("method %s is ambiguous, use a full signature: %s", method, strings.Join(sigs, ", "))
+	}
+}
+
+// methodName strips the parameter list from a full method signature.
+func methodName(method string) string {
+	if idx := strings.IndexByte(method, '('); idx >= 0 {
+		return strings.TrimSpace(method[:idx])
 	}
-	return nil, nil, fmt.Errorf("method %s not found", methodName)
+	return method
 }
 
 // getConstructorTypes retrieves constructor types from cache or ABI
@@ -383,7 +673,8 @@ func (i *Instance) getConstructorTypes() ([]string, error) {
 }
 
 // Encode encodes a method invocation into call data. For constructors, pass an
-// empty method name and only parameters.
+// empty method name and only parameters. Overloaded methods are selected by
+// full signature, e.g. "foo(address)".
 func (i *Instance) Encode(method string, params ...interface{}) ([]byte, error) {
 	// Special handling for constructors (empty method name)
 	if method == "" {
@@ -404,7 +695,29 @@ func (i *Instance) Encode(method string, params ...interface{}) ([]byte, error)
 		return nil, fmt.Errorf("failed to get method types: %v", err)
 	}
 
-	return i.abiProcessor.EncodeMethod(method, inputTypes, params)
+	return i.abiProcessor.EncodeMethod(methodName(method), inputTypes, params)
+}
+
+// EncodeInput returns the call data Invoke and Call would send for method
+// and args, so it can be inspected or passed to InvokeRaw later. Unlike
+// Encode it does not accept the empty constructor name.
+//
+// Example:
+//
+//	data, err := instance.EncodeInput("transfer", to, big.NewInt(1_000_000))
+//	if err != nil {
+//	    // handle error
+//	}
+//	fmt.Printf("calldata: %x\n", data)
+func (i *Instance) EncodeInput(method string, args ...interface{}) ([]byte, error) {
+	if method == "" {
+		return nil, fmt.Errorf("%w: method name cannot be empty", types.ErrInvalidParameter)
+	}
+	data, err := i.Encode(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode input for method %s: %v", types.ErrInvalidContract, method, err)
+	}
+	return data, nil
 }
 
 // DecodeResult decodes a method's return bytes into a Go value. Single-output
@@ -434,41 +747,77 @@ func (i *Instance) DecodeResult(method string, data []byte) (interface{}, error)
 	return decoded, nil
 }
 
-// DecodeInput decodes input call data to a typed representation.
+// DecodeInput decodes input call data to a typed representation. A selector
+// that matches no ABI method is reported as Method "unknown(0x...)" without
+// an error; use DecodeInputArgs to treat it as a failure.
 func (i *Instance) DecodeInput(data []byte) (*utils.DecodedInput, error) {
 	return i.abiProcessor.DecodeInputData(data, i.ABI)
 }
 
-// DecodeEventLog decodes a single event log using the contract ABI.
-// func (i *Instance) DecodeEventLog(topics [][]byte, data []byte) (*utils.DecodedEvent, error) {
-// 	// Convert ABI entries to eventdecoder format
-// 	if err := eventdecoder.RegisterABIEntries(i.ABI.Entrys); err != nil {
-// 		return nil, fmt.Errorf("failed to register ABI entries: %v", err)
-// 	}
-
-// 	// Use eventdecoder for decoding
-// 	event, err := eventdecoder.DecodeLog(topics, data)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	// Convert eventdecoder.DecodedEvent to utils.DecodedEvent for backward compatibility
-// 	parameters := make([]utils.DecodedEventParameter, len(event.Parameters))
-// 	for i, param := range event.Parameters {
-// 		parameters[i] = utils.DecodedEventParameter{
-// 			Name:    param.Name,
-// 			Type:    param.Type,
-// 			Value:   param.Value,
-// 			Indexed: param.Indexed,
-// 		}
-// 	}
-
-// 	return &utils.DecodedEvent{
-// 		EventName:  event.EventName,
-// 		Parameters: parameters,
-// 		Contract:   event.Contract,
-// 	}, nil
-// }
+// DecodeInputArgs decodes call data, e.g. TriggerSmartContract.Data, into the
+// canonical method signature and its arguments keyed by parameter name.
+// Unnamed parameters are keyed "arg0", "arg1" and so on by position. A
+// selector that matches no ABI method fails with an error wrapping
+// types.ErrNotFound.
+//
+// Example:
+//
+//	method, args, err := instance.DecodeInputArgs(trigger.GetData())
+//	if err != nil {
+//	    // handle error
+//	}
+//	fmt.Println(method, args["_to"], args["_value"])
+func (i *Instance) DecodeInputArgs(data []byte) (string, map[string]interface{}, error) {
+	if len(data) < 4 {
+		return "", nil, fmt.Errorf("%w: call data must be at least 4 bytes, got %d", types.ErrInvalidParameter, len(data))
+	}
+	known := false
+	for _, m := range i.Methods() {
+		if bytes.Equal(m.Selector[:], data[:4]) {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return "", nil, fmt.Errorf("%w: no ABI method with selector 0x%x", types.ErrNotFound, data[:4])
+	}
+	decoded, err := i.DecodeInput(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", types.ErrInvalidContract, err)
+	}
+	args := make(map[string]interface{}, len(decoded.Parameters))
+	for n, p := range decoded.Parameters {
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", n)
+		}
+		args[name] = p.Value
+	}
+	return decoded.Method, args, nil
+}
+
+// DecodeEventLog decodes a single event log strictly against the instance
+// ABI, so a same-signature event registered globally by another contract
+// cannot shadow it. Events not in the ABI fail with an error wrapping
+// types.ErrNotFound; use eventdecoder.DecodeLog for logs of other contracts.
+//
+// Example:
+//
+//	for _, lg := range receipt.Logs {
+//	    ev, err := instance.DecodeEventLog(lg.GetTopics(), lg.GetData())
+//	    if err != nil {
+//	        continue
+//	    }
+//	    fmt.Println(ev.EventName, ev.Parameters)
+//	}
+func (i *Instance) DecodeEventLog(topics [][]byte, data []byte) (*eventdecoder.DecodedEvent, error) {
+	ev, err := eventdecoder.DecodeLogWithABI(i.ABI, topics, data)
+	if err != nil {
+		return nil, err
+	}
+	ev.Contract = i.Address.String()
+	return ev, nil
+}
 
 // // DecodeEventSignature decodes 4- or 32-byte event signature to the canonical
 // // signature string if known.
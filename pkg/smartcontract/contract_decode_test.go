@@ -2,9 +2,12 @@ package smartcontract
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/kslamph/tronlib/pkg/types"
 )
 
@@ -154,3 +157,77 @@ func TestDecodeInput(t *testing.T) {
 		t.Errorf("Expected amount %v, got '%v'", expectedAmount, decodedTransfer.Parameters[1].Value)
 	}
 }
+
+func TestDecodeEventLog(t *testing.T) {
+	const vaultABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"user","type":"address"},{"indexed":false,"name":"shares","type":"uint256"}],"name":"VaultDeposit","type":"event"}]`
+	contract, err := NewInstance(createMockClient(), createMockAddress(), vaultABI)
+	if err != nil {
+		t.Fatalf("Failed to create contract: %v", err)
+	}
+
+	topic0 := crypto.Keccak256([]byte("VaultDeposit(address,uint256)"))
+	user := make([]byte, 32)
+	user[31] = 1
+	shares := make([]byte, 32)
+	shares[31] = 7
+
+	ev, err := contract.DecodeEventLog([][]byte{topic0, user}, shares)
+	if err != nil {
+		t.Fatalf("DecodeEventLog: %v", err)
+	}
+	if ev.EventName != "VaultDeposit" || ev.Signature != "VaultDeposit(address,uint256)" {
+		t.Errorf("unexpected event %s (%s)", ev.EventName, ev.Signature)
+	}
+	if ev.Contract != createMockAddress().String() {
+		t.Errorf("expected contract %s, got %s", createMockAddress(), ev.Contract)
+	}
+	if len(ev.Parameters) != 2 || ev.Parameters[1].Value != "7" {
+		t.Errorf("unexpected parameters %+v", ev.Parameters)
+	}
+
+	// Transfer is registered globally but is not part of this ABI
+	transfer := crypto.Keccak256([]byte("Transfer(address,address,uint256)"))
+	if _, err := contract.DecodeEventLog([][]byte{transfer, user, user}, shares); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for event outside the ABI, got %v", err)
+	}
+}
+
+func TestDecodeInputArgs(t *testing.T) {
+	contract, err := NewInstance(createMockClient(), createMockAddress(), testERC20ABI)
+	if err != nil {
+		t.Fatalf("Failed to create contract: %v", err)
+	}
+
+	transferInput, _ := hex.DecodeString("a9059cbb00000000000000000000004144f9bddf503f9920a1a21f0810e57a27de970f1a00000000000000000000000000000000000000000000000000000000bf91bf80")
+	method, args, err := contract.DecodeInputArgs(transferInput)
+	if err != nil {
+		t.Fatalf("DecodeInputArgs: %v", err)
+	}
+	if method != "transfer(address,uint256)" {
+		t.Errorf("unexpected method %s", method)
+	}
+	if len(args) != 2 {
+		t.Fatalf("expected 2 args, got %v", args)
+	}
+	for _, p := range contract.Methods() {
+		if p.Signature != method {
+			continue
+		}
+		for n, in := range p.Inputs {
+			key := in.Name
+			if key == "" {
+				key = fmt.Sprintf("arg%d", n)
+			}
+			if _, ok := args[key]; !ok {
+				t.Errorf("missing argument %q in %v", key, args)
+			}
+		}
+	}
+
+	if _, _, err := contract.DecodeInputArgs([]byte{0xde, 0xad, 0xbe, 0xef}); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown selector, got %v", err)
+	}
+	if _, _, err := contract.DecodeInputArgs([]byte{0x01}); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Errorf("expected ErrInvalidParameter for short data, got %v", err)
+	}
+}
@@ -1,10 +1,14 @@
 package smartcontract
 
 import (
+	"bytes"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
 )
 
 // Test ERC20 ABI for testing
@@ -179,12 +183,6 @@ func TestInvalidInputs(t *testing.T) {
 		t.Error("Expected error for nil address")
 	}
 
-	// Test empty ABI string
-	_, err3 := NewInstance(mockClient, mockAddress, "")
-	if err3 == nil {
-		t.Error("Expected error for empty ABI")
-	}
-
 	// Test invalid ABI string
 	_, err4 := NewInstance(mockClient, mockAddress, "invalid json")
 	if err4 == nil {
@@ -209,3 +207,87 @@ func TestInvalidInputs(t *testing.T) {
 		t.Error("Expected error with invalid ABI type")
 	}
 }
+
+func TestInstanceMethodsAndEvents(t *testing.T) {
+	contract, err := NewInstance(createMockClient(), createMockAddress(), testERC20ABI)
+	if err != nil {
+		t.Fatalf("failed to create instance: %v", err)
+	}
+
+	methods := contract.Methods()
+	if len(methods) != 3 {
+		t.Fatalf("expected 3 methods, got %d", len(methods))
+	}
+	transfer := methods[2]
+	if transfer.Signature != "transfer(address,uint256)" {
+		t.Errorf("unexpected signature %q", transfer.Signature)
+	}
+	if transfer.Selector != [4]byte{0xa9, 0x05, 0x9c, 0xbb} {
+		t.Errorf("unexpected selector %x", transfer.Selector)
+	}
+	if transfer.StateMutability != "nonpayable" {
+		t.Errorf("unexpected mutability %q", transfer.StateMutability)
+	}
+	if len(transfer.Inputs) != 2 || transfer.Inputs[0].Type != "address" || len(transfer.Outputs) != 1 {
+		t.Errorf("unexpected params: %+v / %+v", transfer.Inputs, transfer.Outputs)
+	}
+	if methods[0].StateMutability != "view" {
+		t.Errorf("expected name() to be view, got %q", methods[0].StateMutability)
+	}
+
+	events := contract.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Signature != "Transfer(address,address,uint256)" {
+		t.Errorf("unexpected event signature %q", events[0].Signature)
+	}
+	if events[0].Topic[0] != 0xdd || events[0].Topic[1] != 0xf2 {
+		t.Errorf("unexpected event topic %x", events[0].Topic)
+	}
+	if !events[0].Inputs[0].Indexed || events[0].Inputs[2].Indexed {
+		t.Errorf("unexpected indexed flags: %+v", events[0].Inputs)
+	}
+}
+
+func TestEncodeOverloadedMethod(t *testing.T) {
+	const overloadABI = `[
+		{"type":"function","name":"foo","inputs":[{"name":"v","type":"uint256"}],"outputs":[]},
+		{"type":"function","name":"foo","inputs":[{"name":"a","type":"address"}],"outputs":[]},
+		{"type":"function","name":"bar","inputs":[],"outputs":[]}
+	]`
+	address := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	contract, err := NewInstance(createMockClient(), createMockAddress(), overloadABI)
+	if err != nil {
+		t.Fatalf("NewInstance: %v", err)
+	}
+
+	data, err := contract.Encode("foo(uint256)", big.NewInt(1))
+	if err != nil {
+		t.Fatalf("encode foo(uint256): %v", err)
+	}
+	if !bytes.Equal(data[:4], crypto.Keccak256([]byte("foo(uint256)"))[:4]) {
+		t.Errorf("wrong selector for foo(uint256): %x", data[:4])
+	}
+
+	data, err = contract.Encode("foo( address )", address)
+	if err != nil {
+		t.Fatalf("encode foo(address): %v", err)
+	}
+	if !bytes.Equal(data[:4], crypto.Keccak256([]byte("foo(address)"))[:4]) {
+		t.Errorf("wrong selector for foo(address): %x", data[:4])
+	}
+
+	// Unique names still resolve without a signature.
+	if _, err := contract.Encode("bar"); err != nil {
+		t.Errorf("encode bar: %v", err)
+	}
+
+	_, err = contract.Encode("foo", big.NewInt(1))
+	if err == nil || !strings.Contains(err.Error(), "foo(uint256)") || !strings.Contains(err.Error(), "foo(address)") {
+		t.Errorf("expected ambiguity error listing candidates, got %v", err)
+	}
+	if _, err := contract.Encode("foo(bool)", true); err == nil {
+		t.Errorf("expected error for unknown signature")
+	}
+}
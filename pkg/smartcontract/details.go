@@ -0,0 +1,89 @@
+package smartcontract
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// ContractDetails is a typed view of the on-chain SmartContract record.
+type ContractDetails struct {
+	Address       *types.Address
+	Name          string
+	OriginAddress *types.Address
+	// ABI is the contract ABI as stored on chain; Methods and Events are
+	// derived from it. All three are empty when the ABI has been cleared.
+	ABI     *core.SmartContract_ABI
+	Methods []MethodInfo
+	Events  []EventInfo
+	// CodeHash is the hash of the runtime code as reported by the node; it is
+	// empty when the node does not report one. Use Manager.GetCodeHash to
+	// compute it from the runtime code.
+	CodeHash                   []byte
+	ConsumeUserResourcePercent int64
+	OriginEnergyLimit          int64
+	Version                    int32
+}
+
+// GetContractDetails fetches the contract at contractAddress and returns its
+// metadata with the ABI already parsed. It returns types.ErrNotFound when no
+// contract is deployed at the address.
+func (m *Manager) GetContractDetails(ctx context.Context, contractAddress *types.Address) (*ContractDetails, error) {
+	sc, err := m.GetContract(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(sc.GetContractAddress()) == 0 && len(sc.GetBytecode()) == 0 {
+		return nil, fmt.Errorf("%w: no contract at %s", types.ErrNotFound, contractAddress)
+	}
+	return NewContractDetails(contractAddress, sc), nil
+}
+
+// NewContractDetails builds ContractDetails from a SmartContract message.
+func NewContractDetails(contractAddress *types.Address, sc *core.SmartContract) *ContractDetails {
+	d := &ContractDetails{
+		Address:                    contractAddress,
+		Name:                       sc.GetName(),
+		ABI:                        sc.GetAbi(),
+		Methods:                    methodInfos(sc.GetAbi()),
+		Events:                     eventInfos(sc.GetAbi()),
+		CodeHash:                   sc.GetCodeHash(),
+		ConsumeUserResourcePercent: sc.GetConsumeUserResourcePercent(),
+		OriginEnergyLimit:          sc.GetOriginEnergyLimit(),
+		Version:                    sc.GetVersion(),
+	}
+	if addr, err := types.NewAddressFromBytes(sc.GetOriginAddress()); err == nil {
+		d.OriginAddress = addr
+	}
+	return d
+}
+
+// GetRuntimeCode returns the runtime (deployed) bytecode of the contract at
+// contractAddress, as opposed to the creation bytecode in GetContract. It
+// returns types.ErrNotFound when no code is deployed at the address.
+func (m *Manager) GetRuntimeCode(ctx context.Context, contractAddress *types.Address) ([]byte, error) {
+	info, err := m.GetContractInfo(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.GetRuntimecode()) == 0 {
+		return nil, fmt.Errorf("%w: no runtime code at %s", types.ErrNotFound, contractAddress)
+	}
+	return info.GetRuntimecode(), nil
+}
+
+// GetCodeHash returns keccak256 of the contract's runtime bytecode, the value
+// EXTCODEHASH yields on chain. Compare it with the hash of a compiled
+// artifact's deployedBytecode to verify a deployment.
+func (m *Manager) GetCodeHash(ctx context.Context, contractAddress *types.Address) ([32]byte, error) {
+	var hash [32]byte
+	code, err := m.GetRuntimeCode(ctx, contractAddress)
+	if err != nil {
+		return hash, err
+	}
+	copy(hash[:], crypto.Keccak256(code))
+	return hash, nil
+}
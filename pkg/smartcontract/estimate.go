@@ -0,0 +1,110 @@
+package smartcontract
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+)
+
+// EstimateMethod names the node RPC that produced an EnergyEstimate.
+type EstimateMethod string
+
+const (
+	// EstimateMethodRPC is the dedicated EstimateEnergy RPC. It accounts for
+	// energy that is refunded during execution, so its figure is the one to
+	// size a fee limit with.
+	EstimateMethodRPC EstimateMethod = "EstimateEnergy"
+	// EstimateMethodConstantCall is TriggerConstantContract's energy_used,
+	// used when the node has the EstimateEnergy RPC disabled. It can
+	// underestimate calls that rely on refunds.
+	EstimateMethodConstantCall EstimateMethod = "TriggerConstantContract"
+)
+
+// EnergyEstimate is the result of Manager.EstimateEnergy.
+type EnergyEstimate struct {
+	// EnergyRequired is the estimated energy for the call.
+	EnergyRequired int64
+	// Method is the RPC the estimate came from.
+	Method EstimateMethod
+}
+
+// EstimateEnergy estimates the energy required to execute a contract call.
+//
+// The node's EstimateEnergy RPC is tried first. Many public nodes run with
+// it disabled; in that case the call is simulated with
+// TriggerConstantContract and its energy_used is returned instead. Method
+// reports which one answered. A call that reverts fails with
+// types.ErrContractExecutionFailed either way.
+//
+// Example:
+//
+//	est, err := cli.SmartContract().EstimateEnergy(ctx, owner, contract, data, 0)
+//	if err != nil {
+//	    // handle error
+//	}
+//	fmt.Printf("%d energy (via %s)\n", est.EnergyRequired, est.Method)
+func (m *Manager) EstimateEnergy(ctx context.Context, ownerAddress, contractAddress *types.Address, data []byte, callValue int64) (*EnergyEstimate, error) {
+	// Validate inputs
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: contract data cannot be empty", types.ErrInvalidParameter)
+	}
+	if callValue < 0 {
+		return nil, fmt.Errorf("%w: call value cannot be negative", types.ErrInvalidParameter)
+	}
+	if ownerAddress == nil {
+		return nil, fmt.Errorf("%w: invalid owner address: nil", types.ErrInvalidAddress)
+	}
+	if contractAddress == nil {
+		return nil, fmt.Errorf("%w: invalid contract address: nil", types.ErrInvalidAddress)
+	}
+
+	if err := utils.ValidateContractData(data); err != nil {
+		return nil, fmt.Errorf("%w: invalid contract data: %w", types.ErrInvalidParameter, err)
+	}
+
+	req := &core.TriggerSmartContract{OwnerAddress: ownerAddress.Bytes(), ContractAddress: contractAddress.Bytes(), Data: data, CallValue: callValue}
+	msg, err := lowlevel.EstimateEnergy(m.conn, ctx, req)
+	switch {
+	case err != nil && !isEstimateDisabled(err, nil):
+		return nil, err
+	case err == nil && !isEstimateDisabled(nil, msg.GetResult()):
+		if !msg.GetResult().GetResult() {
+			return nil, fmt.Errorf("%w: %s", types.ErrContractExecutionFailed, msg.GetResult().GetMessage())
+		}
+		return &EnergyEstimate{EnergyRequired: msg.GetEnergyRequired(), Method: EstimateMethodRPC}, nil
+	}
+
+	ext, err := lowlevel.Call(m.conn, ctx, "trigger constant contract", func(cl api.WalletClient, ctx context.Context) (*api.TransactionExtention, error) {
+		return cl.TriggerConstantContract(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ext.GetResult().GetResult() {
+		return nil, fmt.Errorf("%w: %s", types.ErrContractExecutionFailed, ext.GetResult().GetMessage())
+	}
+	return &EnergyEstimate{EnergyRequired: ext.GetEnergyUsed(), Method: EstimateMethodConstantCall}, nil
+}
+
+// isEstimateDisabled reports whether the node refused EstimateEnergy because
+// the RPC is turned off, either as a gRPC error or as a failed Return.
+func isEstimateDisabled(err error, ret *api.Return) bool {
+	if err != nil {
+		return status.Code(err) == codes.Unimplemented || isDisabledMessage(err.Error())
+	}
+	return ret != nil && !ret.GetResult() && isDisabledMessage(string(ret.GetMessage()))
+}
+
+func isDisabledMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "feature is disabled") || strings.Contains(msg, "does not support estimate energy")
+}
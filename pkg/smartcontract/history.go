@@ -0,0 +1,191 @@
+package smartcontract
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// ErrArchivalNotSupported is returned when a call is requested against a
+// historical block and no archive endpoint is configured, or the configured
+// endpoint cannot serve that block.
+var ErrArchivalNotSupported = errors.New("archival state not supported: node cannot execute calls against historical blocks")
+
+// HistoricalCaller executes a read-only contract call against the state as
+// of a given block. It is implemented by JSONRPCArchive; set one on an
+// Instance with WithArchive.
+type HistoricalCaller interface {
+	CallAtBlock(ctx context.Context, caller, contract *types.Address, data []byte, blockNum int64) ([]byte, error)
+}
+
+// WithArchive makes CallAtBlock read historical state through h. Without it
+// CallAtBlock fails with ErrArchivalNotSupported, since TRON's gRPC
+// TriggerConstantContract only executes against the current state.
+//
+// Example:
+//
+//	archive := smartcontract.NewJSONRPCArchive("https://archive.example.com/jsonrpc")
+//	instance, err := smartcontract.NewInstance(cli, contractAddr, abiJSON, smartcontract.WithArchive(archive))
+func WithArchive(h HistoricalCaller) InstanceOption {
+	return func(i *Instance) {
+		i.archive = h
+	}
+}
+
+// CallAtBlock performs a constant call as of block blockNum and decodes the
+// result like Call.
+//
+// The call is executed by the archive endpoint set with WithArchive; without
+// one, ErrArchivalNotSupported is returned. The endpoint also reports
+// ErrArchivalNotSupported when it cannot serve the requested block.
+//
+// Example:
+//
+//	value, err := instance.CallAtBlock(ctx, caller, 60000000, "totalSupply")
+//	if errors.Is(err, smartcontract.ErrArchivalNotSupported) {
+//	    // fall back to an indexer or archive service
+//	}
+func (i *Instance) CallAtBlock(ctx context.Context, caller *types.Address, blockNum int64, method string, params ...interface{}) (interface{}, error) {
+	if caller == nil {
+		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
+	}
+	if blockNum < 0 {
+		return nil, fmt.Errorf("%w: block number cannot be negative, got %d", types.ErrInvalidParameter, blockNum)
+	}
+	if i.archive == nil {
+		return nil, fmt.Errorf("%w: no archive endpoint configured (see WithArchive)", ErrArchivalNotSupported)
+	}
+
+	data, err := i.Encode(method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode input for method %s: %v", types.ErrInvalidContract, method, err)
+	}
+	out, err := i.archive.CallAtBlock(ctx, caller, i.Address, data, blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := i.DecodeResult(method, out)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode result for method %s: %v", types.ErrInvalidContract, method, err)
+	}
+	return decoded, nil
+}
+
+// jsonRPCInvalidParams is the JSON-RPC error code java-tron returns when
+// eth_call is given a block number it cannot serve.
+const jsonRPCInvalidParams = -32602
+
+// JSONRPCArchive reads historical contract state through the eth_call method
+// of a TRON JSON-RPC endpoint (the /jsonrpc path of a full node), passing the
+// block number as the call's block parameter. Regular full nodes only accept
+// "latest" there, so Endpoint should point at an archive node or service that
+// keeps historical state.
+type JSONRPCArchive struct {
+	// Endpoint is the JSON-RPC URL, e.g. "https://archive.example.com/jsonrpc".
+	Endpoint string
+	// HTTPClient sends the requests; http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// NewJSONRPCArchive returns a JSONRPCArchive for endpoint using
+// http.DefaultClient.
+func NewJSONRPCArchive(endpoint string) *JSONRPCArchive {
+	return &JSONRPCArchive{Endpoint: endpoint}
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result *string `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CallAtBlock runs eth_call for data on contract as of blockNum and returns
+// the raw return bytes. A JSON-RPC invalid-params error, which is how nodes
+// without historical state reject a block number, wraps
+// ErrArchivalNotSupported; other JSON-RPC errors, such as reverts, wrap
+// types.ErrContractExecutionFailed.
+func (a *JSONRPCArchive) CallAtBlock(ctx context.Context, caller, contract *types.Address, data []byte, blockNum int64) ([]byte, error) {
+	if caller == nil || contract == nil {
+		return nil, fmt.Errorf("%w: caller and contract addresses cannot be nil", types.ErrInvalidAddress)
+	}
+	if blockNum < 0 {
+		return nil, fmt.Errorf("%w: block number cannot be negative, got %d", types.ErrInvalidParameter, blockNum)
+	}
+
+	body, err := json.Marshal(jsonRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_call",
+		Params: []interface{}{
+			map[string]string{
+				"from": caller.HexEVM(),
+				"to":   contract.HexEVM(),
+				"data": "0x" + hex.EncodeToString(data),
+			},
+			"0x" + strconv.FormatInt(blockNum, 16),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid archive endpoint: %v", types.ErrInvalidParameter, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := a.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("archive eth_call failed: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("archive eth_call failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("archive eth_call failed: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	var out jsonRPCResponse
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("archive eth_call returned invalid JSON: %w", err)
+	}
+	if out.Error != nil {
+		if out.Error.Code == jsonRPCInvalidParams {
+			return nil, fmt.Errorf("%w: block %d: %s", ErrArchivalNotSupported, blockNum, out.Error.Message)
+		}
+		return nil, fmt.Errorf("%w: %s", types.ErrContractExecutionFailed, out.Error.Message)
+	}
+	if out.Result == nil {
+		return nil, fmt.Errorf("%w: archive eth_call returned no result", types.ErrInvalidContract)
+	}
+	result, err := hex.DecodeString(strings.TrimPrefix(*out.Result, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: archive eth_call returned invalid hex: %v", types.ErrInvalidContract, err)
+	}
+	return result, nil
+}
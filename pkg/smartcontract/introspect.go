@@ -0,0 +1,128 @@
+package smartcontract
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kslamph/tronlib/pb/core"
+)
+
+// ParamInfo describes a single ABI parameter.
+type ParamInfo struct {
+	Name    string
+	Type    string
+	Indexed bool
+}
+
+// MethodInfo describes a function declared in a contract ABI.
+type MethodInfo struct {
+	Name string
+	// Signature is the canonical signature, e.g. "transfer(address,uint256)".
+	Signature string
+	// Selector is the first 4 bytes of keccak256(Signature).
+	Selector [4]byte
+	Inputs   []ParamInfo
+	Outputs  []ParamInfo
+	// StateMutability is one of "pure", "view", "nonpayable" or "payable".
+	StateMutability string
+}
+
+// EventInfo describes an event declared in a contract ABI.
+type EventInfo struct {
+	Name string
+	// Signature is the canonical signature, e.g. "Transfer(address,address,uint256)".
+	Signature string
+	// Topic is keccak256(Signature), the first topic of non-anonymous logs.
+	Topic     [32]byte
+	Inputs    []ParamInfo
+	Anonymous bool
+}
+
+// Methods returns the functions declared in the instance ABI, in ABI order.
+func (i *Instance) Methods() []MethodInfo {
+	return methodInfos(i.ABI)
+}
+
+// Events returns the events declared in the instance ABI, in ABI order.
+func (i *Instance) Events() []EventInfo {
+	return eventInfos(i.ABI)
+}
+
+func methodInfos(abi *core.SmartContract_ABI) []MethodInfo {
+	var methods []MethodInfo
+	for _, entry := range abi.GetEntrys() {
+		if entry.GetType() != core.SmartContract_ABI_Entry_Function {
+			continue
+		}
+		sig := entrySignature(entry)
+		info := MethodInfo{
+			Name:            entry.GetName(),
+			Signature:       sig,
+			Inputs:          paramInfos(entry.GetInputs()),
+			Outputs:         paramInfos(entry.GetOutputs()),
+			StateMutability: stateMutability(entry),
+		}
+		copy(info.Selector[:], crypto.Keccak256([]byte(sig))[:4])
+		methods = append(methods, info)
+	}
+	return methods
+}
+
+func eventInfos(abi *core.SmartContract_ABI) []EventInfo {
+	var events []EventInfo
+	for _, entry := range abi.GetEntrys() {
+		if entry.GetType() != core.SmartContract_ABI_Entry_Event {
+			continue
+		}
+		sig := entrySignature(entry)
+		info := EventInfo{
+			Name:      entry.GetName(),
+			Signature: sig,
+			Inputs:    paramInfos(entry.GetInputs()),
+			Anonymous: entry.GetAnonymous(),
+		}
+		copy(info.Topic[:], crypto.Keccak256([]byte(sig)))
+		events = append(events, info)
+	}
+	return events
+}
+
+// entrySignature builds the canonical "name(type1,type2)" signature.
+func entrySignature(entry *core.SmartContract_ABI_Entry) string {
+	inputTypes := make([]string, len(entry.GetInputs()))
+	for i, input := range entry.GetInputs() {
+		inputTypes[i] = input.GetType()
+	}
+	return entry.GetName() + "(" + strings.Join(inputTypes, ",") + ")"
+}
+
+func paramInfos(params []*core.SmartContract_ABI_Entry_Param) []ParamInfo {
+	infos := make([]ParamInfo, len(params))
+	for i, p := range params {
+		infos[i] = ParamInfo{Name: p.GetName(), Type: p.GetType(), Indexed: p.GetIndexed()}
+	}
+	return infos
+}
+
+// stateMutability maps the ABI mutability to its Solidity keyword, falling
+// back to the legacy constant/payable flags for older ABIs.
+func stateMutability(entry *core.SmartContract_ABI_Entry) string {
+	switch entry.GetStateMutability() {
+	case core.SmartContract_ABI_Entry_Pure:
+		return "pure"
+	case core.SmartContract_ABI_Entry_View:
+		return "view"
+	case core.SmartContract_ABI_Entry_Nonpayable:
+		return "nonpayable"
+	case core.SmartContract_ABI_Entry_Payable:
+		return "payable"
+	}
+	switch {
+	case entry.GetConstant():
+		return "view"
+	case entry.GetPayable():
+		return "payable"
+	default:
+		return "nonpayable"
+	}
+}
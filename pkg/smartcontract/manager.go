@@ -71,21 +71,39 @@ func (m *Manager) Instance(contractAddress *types.Address, abi ...any) (*Instanc
 //	opts := client.DefaultBroadcastOptions()
 //	result, err := cli.SignAndBroadcast(ctx, txExt, opts, signer)
 func (m *Manager) Deploy(ctx context.Context, ownerAddress *types.Address, contractName string, abi any, bytecode []byte, callValue, consumeUserResourcePercent, originEnergyLimit int64, constructorParams ...interface{}) (*api.TransactionExtention, error) {
+	opts := DeployOptions{
+		Name:                       contractName,
+		CallValue:                  callValue,
+		ConsumeUserResourcePercent: consumeUserResourcePercent,
+		OriginEnergyLimit:          originEnergyLimit,
+	}
+	return m.deploy(ctx, ownerAddress, abi, bytecode, opts, constructorParams)
+}
+
+// deploy validates the deployment settings, encodes constructor parameters
+// and builds the CreateSmartContract transaction.
+func (m *Manager) deploy(ctx context.Context, ownerAddress *types.Address, abi any, bytecode []byte, opts DeployOptions, constructorParams []interface{}) (*api.TransactionExtention, error) {
 	// Validate inputs
-	if err := utils.ValidateContractName(contractName); err != nil {
+	if err := utils.ValidateContractName(opts.Name); err != nil {
 		return nil, fmt.Errorf("%w: invalid contract name: %w", types.ErrInvalidParameter, err)
 	}
 	if len(bytecode) == 0 {
 		return nil, fmt.Errorf("%w: bytecode cannot be empty", types.ErrInvalidParameter)
 	}
-	if callValue < 0 {
+	if opts.CallValue < 0 {
 		return nil, fmt.Errorf("%w: call value cannot be negative", types.ErrInvalidParameter)
 	}
-	if err := utils.ValidateConsumeUserResourcePercent(consumeUserResourcePercent); err != nil {
+	if opts.TokenValue < 0 {
+		return nil, fmt.Errorf("%w: token value cannot be negative", types.ErrInvalidParameter)
+	}
+	if opts.TokenValue > 0 && opts.TokenID <= 0 {
+		return nil, fmt.Errorf("%w: token ID is required when token value is set", types.ErrInvalidParameter)
+	}
+	if err := utils.ValidateConsumeUserResourcePercent(opts.ConsumeUserResourcePercent); err != nil {
 		// preserve precise error if already sentinel-like, else wrap under invalid parameter
 		return nil, fmt.Errorf("%w: %w", types.ErrInvalidParameter, err)
 	}
-	if originEnergyLimit < 0 {
+	if opts.OriginEnergyLimit < 0 {
 		return nil, fmt.Errorf("%w: origin energy limit cannot be negative", types.ErrInvalidParameter)
 	}
 	if ownerAddress == nil {
@@ -102,8 +120,7 @@ func (m *Manager) Deploy(ctx context.Context, ownerAddress *types.Address, contr
 			if v == "" {
 				return nil, fmt.Errorf("%w: empty ABI string", types.ErrInvalidParameter)
 			}
-			processor := utils.NewABIProcessor(nil)
-			contractABI, err = processor.ParseABI(v)
+			contractABI, err = utils.ParseABICached(v)
 			if err != nil {
 				return nil, fmt.Errorf("%w: failed to parse ABI string: %w", types.ErrInvalidParameter, err)
 			}
@@ -118,6 +135,11 @@ func (m *Manager) Deploy(ctx context.Context, ownerAddress *types.Address, contr
 		}
 	}
 
+	// A non-payable (or implicit) constructor reverts when TRX is sent
+	if opts.CallValue > 0 && contractABI != nil && !isConstructorPayable(contractABI) {
+		return nil, fmt.Errorf("%w: call value %d sent to non-payable constructor", types.ErrInvalidParameter, opts.CallValue)
+	}
+
 	// Encode constructor parameters if provided
 	finalBytecode := bytecode
 	if len(constructorParams) > 0 {
@@ -141,18 +163,28 @@ func (m *Manager) Deploy(ctx context.Context, ownerAddress *types.Address, contr
 		ContractAddress:            nil, // Will be generated
 		Abi:                        contractABI,
 		Bytecode:                   finalBytecode,
-		CallValue:                  callValue,
-		ConsumeUserResourcePercent: consumeUserResourcePercent,
-		Name:                       contractName,
-		OriginEnergyLimit:          originEnergyLimit,
+		CallValue:                  opts.CallValue,
+		ConsumeUserResourcePercent: opts.ConsumeUserResourcePercent,
+		Name:                       opts.Name,
+		OriginEnergyLimit:          opts.OriginEnergyLimit,
 	}
 
-	req := &core.CreateSmartContract{OwnerAddress: ownerAddress.Bytes(), NewContract: newContract, CallTokenValue: 0, TokenId: 0}
+	req := &core.CreateSmartContract{OwnerAddress: ownerAddress.Bytes(), NewContract: newContract, CallTokenValue: opts.TokenValue, TokenId: opts.TokenID}
 	return lowlevel.TxCall(m.conn, ctx, "deploy contract", func(cl api.WalletClient, ctx context.Context) (*api.TransactionExtention, error) {
 		return cl.DeployContract(ctx, req)
 	})
 }
 
+// isConstructorPayable reports whether the ABI declares a payable constructor.
+func isConstructorPayable(abi *core.SmartContract_ABI) bool {
+	for _, entry := range abi.GetEntrys() {
+		if entry.GetType() == core.SmartContract_ABI_Entry_Constructor {
+			return entry.GetPayable() || entry.GetStateMutability() == core.SmartContract_ABI_Entry_Payable
+		}
+	}
+	return false
+}
+
 // encodeConstructor encodes constructor parameters for contract deployment
 func (m *Manager) encodeConstructor(abi *core.SmartContract_ABI, constructorParams []interface{}) ([]byte, error) {
 	if abi == nil {
@@ -197,33 +229,6 @@ func (m *Manager) encodeConstructor(abi *core.SmartContract_ABI, constructorPara
 	return encoded, nil
 }
 
-// EstimateEnergy estimates energy required for smart contract execution
-// Use client.Simulate to know energy required for a transaction
-func (m *Manager) EstimateEnergy(ctx context.Context, ownerAddress, contractAddress *types.Address, data []byte, callValue int64) (*api.EstimateEnergyMessage, error) {
-	// Validate inputs
-	if len(data) == 0 {
-		return nil, fmt.Errorf("%w: contract data cannot be empty", types.ErrInvalidParameter)
-	}
-	if callValue < 0 {
-		return nil, fmt.Errorf("%w: call value cannot be negative", types.ErrInvalidParameter)
-	}
-	if ownerAddress == nil {
-		return nil, fmt.Errorf("%w: invalid owner address: nil", types.ErrInvalidAddress)
-	}
-	if contractAddress == nil {
-		return nil, fmt.Errorf("%w: invalid contract address: nil", types.ErrInvalidAddress)
-	}
-
-	if err := utils.ValidateContractData(data); err != nil {
-		return nil, fmt.Errorf("%w: invalid contract data: %w", types.ErrInvalidParameter, err)
-	}
-
-	req := &core.TriggerSmartContract{OwnerAddress: ownerAddress.Bytes(), ContractAddress: contractAddress.Bytes(), Data: data, CallValue: callValue}
-	return lowlevel.Call(m.conn, ctx, "estimate energy", func(cl api.WalletClient, ctx context.Context) (*api.EstimateEnergyMessage, error) {
-		return cl.EstimateEnergy(ctx, req)
-	})
-}
-
 // GetContract gets smart contract information
 func (m *Manager) GetContract(ctx context.Context, contractAddress *types.Address) (*core.SmartContract, error) {
 	if contractAddress == nil {
@@ -248,7 +253,9 @@ func (m *Manager) GetContractInfo(ctx context.Context, contractAddress *types.Ad
 	})
 }
 
-// UpdateSetting updates smart contract settings
+// UpdateSetting updates smart contract settings. consumeUserResourcePercent
+// is the share of energy paid by callers and must be between 0 and 100; use
+// GetConsumeUserResourcePercent to read the current value.
 func (m *Manager) UpdateSetting(ctx context.Context, ownerAddress, contractAddress *types.Address, consumeUserResourcePercent int64) (*api.TransactionExtention, error) {
 	if consumeUserResourcePercent < 0 || consumeUserResourcePercent > 100 {
 		return nil, fmt.Errorf("%w: consume user resource percent must be between 0 and 100", types.ErrInvalidParameter)
@@ -266,10 +273,12 @@ func (m *Manager) UpdateSetting(ctx context.Context, ownerAddress, contractAddre
 	})
 }
 
-// UpdateEnergyLimit updates smart contract energy limit
+// UpdateEnergyLimit updates the maximum energy the contract origin pays per
+// call. originEnergyLimit must be positive; use GetOriginEnergyLimit to read
+// the current value.
 func (m *Manager) UpdateEnergyLimit(ctx context.Context, ownerAddress, contractAddress *types.Address, originEnergyLimit int64) (*api.TransactionExtention, error) {
-	if originEnergyLimit < 0 {
-		return nil, fmt.Errorf("%w: origin energy limit cannot be negative", types.ErrInvalidParameter)
+	if originEnergyLimit <= 0 {
+		return nil, fmt.Errorf("%w: origin energy limit must be positive", types.ErrInvalidParameter)
 	}
 	if ownerAddress == nil {
 		return nil, fmt.Errorf("%w: invalid owner address: nil", types.ErrInvalidAddress)
@@ -284,6 +293,25 @@ func (m *Manager) UpdateEnergyLimit(ctx context.Context, ownerAddress, contractA
 	})
 }
 
+// GetOriginEnergyLimit returns the contract's current origin energy limit.
+func (m *Manager) GetOriginEnergyLimit(ctx context.Context, contractAddress *types.Address) (int64, error) {
+	sc, err := m.GetContract(ctx, contractAddress)
+	if err != nil {
+		return 0, err
+	}
+	return sc.GetOriginEnergyLimit(), nil
+}
+
+// GetConsumeUserResourcePercent returns the contract's current consume user
+// resource percent.
+func (m *Manager) GetConsumeUserResourcePercent(ctx context.Context, contractAddress *types.Address) (int64, error) {
+	sc, err := m.GetContract(ctx, contractAddress)
+	if err != nil {
+		return 0, err
+	}
+	return sc.GetConsumeUserResourcePercent(), nil
+}
+
 // ClearContractABI clears smart contract ABI
 func (m *Manager) ClearContractABI(ctx context.Context, ownerAddress, contractAddress *types.Address) (*api.TransactionExtention, error) {
 	if ownerAddress == nil {
@@ -1,16 +1,28 @@
 package smartcontract
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"math/big"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/kslamph/tronlib/pb/api"
 	"github.com/kslamph/tronlib/pb/core"
 	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -52,7 +64,7 @@ func (s *fakeSCWalletServer) EstimateEnergy(ctx context.Context, in *core.Trigge
 	if s.EstimateEnergyFunc != nil {
 		return s.EstimateEnergyFunc(ctx, in)
 	}
-	return &api.EstimateEnergyMessage{}, nil
+	return &api.EstimateEnergyMessage{Result: &api.Return{Result: true}, EnergyRequired: 7000}, nil
 }
 
 func (s *fakeSCWalletServer) GetContract(ctx context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
@@ -159,6 +171,15 @@ func TestManagerSimulate(t *testing.T) {
 			return &api.TransactionExtention{
 				Result:     &api.Return{Result: true},
 				EnergyUsed: 25000,
+				Logs: []*core.TransactionInfo_Log{{
+					Address: scTestAddr.Bytes()[1:],
+					Topics: [][]byte{
+						crypto.Keccak256([]byte("Transfer(address,address,uint256)")),
+						make([]byte, 32),
+						make([]byte, 32),
+					},
+					Data: make([]byte, 32),
+				}},
 			}, nil
 		},
 	}
@@ -174,8 +195,166 @@ func TestManagerSimulate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if result.Energy != 25000 {
-		t.Fatalf("expected energy 25000, got %d", result.Energy)
+	if !result.Success || result.EnergyUsage != 25000 || result.Energy != 25000 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if len(result.DecodedEvents) != 1 || result.DecodedEvents[0].EventName != "Transfer" {
+		t.Fatalf("unexpected decoded events %+v", result.DecodedEvents)
+	}
+	if result.Extention == nil || len(result.Logs) != 1 {
+		t.Fatal("expected raw extention and logs")
+	}
+}
+
+func TestNewInstanceFetchesABI(t *testing.T) {
+	parsed, err := utils.NewABIProcessor(nil).ParseABI(testERC20ABI)
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+	stored := parsed
+	mgr, cleanup := setupSCTestServer(t, &fakeSCWalletServer{
+		GetContractFunc: func(ctx context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
+			return &core.SmartContract{Abi: stored}, nil
+		},
+	})
+	defer cleanup()
+
+	for _, abi := range [][]any{nil, {""}} {
+		inst, err := NewInstance(mgr.conn, scTestAddr, abi...)
+		if err != nil {
+			t.Fatalf("NewInstance(%v): %v", abi, err)
+		}
+		if len(inst.ABI.GetEntrys()) != len(parsed.GetEntrys()) {
+			t.Fatalf("expected fetched ABI, got %d entries", len(inst.ABI.GetEntrys()))
+		}
+	}
+
+	stored = nil
+	_, err = NewInstance(mgr.conn, scTestAddr, "")
+	if !errors.Is(err, ErrNoABIOnChain) || !errors.Is(err, types.ErrNotFound) {
+		t.Fatalf("expected ErrNoABIOnChain, got %v", err)
+	}
+}
+
+func TestInstanceRawCalls(t *testing.T) {
+	var invoked, called []byte
+	fake := &fakeSCWalletServer{
+		TriggerContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			invoked = in.GetData()
+			return &api.TransactionExtention{Result: &api.Return{Result: true}, Txid: make([]byte, 32)}, nil
+		},
+	}
+	fake.TriggerConstantContractFunc = func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+		called = in.GetData()
+		return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{{0x01}, {0x02}}}, nil
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+
+	inst, err := mgr.Instance(scTestAddr, testERC20ABI)
+	if err != nil {
+		t.Fatalf("failed to create instance: %v", err)
+	}
+	data, err := inst.EncodeInput("balanceOf", scTestAddr2)
+	if err != nil {
+		t.Fatalf("EncodeInput: %v", err)
+	}
+	if len(data) != 36 || !bytes.Equal(data[:4], crypto.Keccak256([]byte("balanceOf(address)"))[:4]) {
+		t.Fatalf("unexpected calldata %x", data)
+	}
+	if _, err := inst.EncodeInput(""); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for empty method, got %v", err)
+	}
+
+	if _, err := inst.InvokeRaw(context.Background(), scTestAddr, 0, data); err != nil {
+		t.Fatalf("InvokeRaw: %v", err)
+	}
+	if !bytes.Equal(invoked, data) {
+		t.Fatalf("InvokeRaw sent %x, want %x", invoked, data)
+	}
+
+	ret, err := inst.CallRaw(context.Background(), scTestAddr, data)
+	if err != nil {
+		t.Fatalf("CallRaw: %v", err)
+	}
+	if !bytes.Equal(called, data) || !bytes.Equal(ret, []byte{0x01, 0x02}) {
+		t.Fatalf("CallRaw sent %x and returned %x", called, ret)
+	}
+
+	if _, err := inst.InvokeRaw(context.Background(), scTestAddr, -1, data); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for negative call value, got %v", err)
+	}
+}
+
+func TestInstanceDefaultFeeLimit(t *testing.T) {
+	fake := &fakeSCWalletServer{
+		TriggerContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{
+				Result:      &api.Return{Result: true},
+				Transaction: &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1}},
+				Txid:        make([]byte, 32),
+			}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+
+	cases := []struct {
+		name string
+		opts []any
+		want int64
+	}{
+		{"default", nil, defaultFeeLimit},
+		{"option", []any{WithDefaultFeeLimit(30_000_000)}, 30_000_000},
+		{"disabled", []any{WithDefaultFeeLimit(0)}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			inst, err := mgr.Instance(scTestAddr, append([]any{testERC20ABI}, tc.opts...)...)
+			if err != nil {
+				t.Fatalf("failed to create instance: %v", err)
+			}
+			txExt, err := inst.Invoke(context.Background(), scTestAddr, 0, "transfer", scTestAddr2, big.NewInt(1))
+			if err != nil {
+				t.Fatalf("Invoke: %v", err)
+			}
+			if got := txExt.GetTransaction().GetRawData().GetFeeLimit(); got != tc.want {
+				t.Fatalf("fee limit = %d, want %d", got, tc.want)
+			}
+			if want, _ := types.TransactionID(txExt.GetTransaction()); tc.want != 0 && !bytes.Equal(txExt.GetTxid(), want) {
+				t.Fatalf("txid not updated after stamping the fee limit")
+			}
+		})
+	}
+
+	if _, err := mgr.Instance(scTestAddr, testERC20ABI, WithDefaultFeeLimit(-1)); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for negative fee limit, got %v", err)
+	}
+}
+
+func TestManagerSimulateRevert(t *testing.T) {
+	fake := &fakeSCWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{
+				Result:      &api.Return{Result: true},
+				Transaction: &core.Transaction{Ret: []*core.Transaction_Result{{Ret: core.Transaction_Result_FAILED}}},
+				EnergyUsed:  900,
+			}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+
+	inst, err := mgr.Instance(scTestAddr, testERC20ABI)
+	if err != nil {
+		t.Fatalf("failed to create instance: %v", err)
+	}
+	result, err := inst.Simulate(context.Background(), scTestAddr, 0, "name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Success || result.EnergyUsage != 900 || len(result.DecodedEvents) != 0 {
+		t.Fatalf("unexpected result %+v", result)
 	}
 }
 
@@ -294,6 +473,55 @@ func TestManagerCall(t *testing.T) {
 	})
 }
 
+func TestInstanceCallValues(t *testing.T) {
+	const pairABI = `[{"type":"function","name":"info","stateMutability":"view","inputs":[],"outputs":[{"name":"owner","type":"address"},{"name":"reserve","type":"uint256"}]},{"type":"function","name":"total","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}]`
+	addrType, _ := eABI.NewType("address", "", nil)
+	uintType, _ := eABI.NewType("uint256", "", nil)
+	packed, err := eABI.Arguments{{Type: addrType}, {Type: uintType}}.Pack(scTestAddr2.EVMAddress(), big.NewInt(777))
+	if err != nil {
+		t.Fatalf("pack: %v", err)
+	}
+	srv := &fakeSCWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			out := packed
+			if !bytes.Equal(in.Data[:4], crypto.Keccak256([]byte("info()"))[:4]) {
+				out, _ = eABI.Arguments{{Type: uintType}}.Pack(big.NewInt(42))
+			}
+			return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{out}}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, srv)
+	defer cleanup()
+
+	inst, err := mgr.Instance(scTestAddr, pairABI)
+	if err != nil {
+		t.Fatalf("failed to create instance: %v", err)
+	}
+
+	values, err := inst.CallValues(context.Background(), scTestAddr, "info")
+	if err != nil {
+		t.Fatalf("CallValues: %v", err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("expected 2 values, got %d", len(values))
+	}
+	owner, ok := values[0].(*types.Address)
+	if !ok || owner.String() != scTestAddr2.String() {
+		t.Fatalf("unexpected owner %#v", values[0])
+	}
+	if reserve, ok := values[1].(*big.Int); !ok || reserve.Int64() != 777 {
+		t.Fatalf("unexpected reserve %#v", values[1])
+	}
+
+	values, err = inst.CallValues(context.Background(), scTestAddr, "total")
+	if err != nil {
+		t.Fatalf("CallValues: %v", err)
+	}
+	if len(values) != 1 || values[0].(*big.Int).Int64() != 42 {
+		t.Fatalf("unexpected single-output values %#v", values)
+	}
+}
+
 func TestManagerEstimateEnergy(t *testing.T) {
 	mgr, cleanup := setupSCTestServer(t, &fakeSCWalletServer{})
 	defer cleanup()
@@ -304,8 +532,44 @@ func TestManagerEstimateEnergy(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if result == nil {
-			t.Fatal("expected non-nil result")
+		if result.EnergyRequired != 7000 || result.Method != EstimateMethodRPC {
+			t.Fatalf("unexpected estimate %+v", result)
+		}
+	})
+
+	t.Run("falls back when disabled", func(t *testing.T) {
+		for name, fn := range map[string]func(context.Context, *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error){
+			"result": func(ctx context.Context, in *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+				return &api.EstimateEnergyMessage{Result: &api.Return{Message: []byte("this feature is disabled")}}, nil
+			},
+			"unimplemented": func(ctx context.Context, in *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+				return nil, status.Error(codes.Unimplemented, "not served")
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				mgr, cleanup := setupSCTestServer(t, &fakeSCWalletServer{EstimateEnergyFunc: fn})
+				defer cleanup()
+				result, err := mgr.EstimateEnergy(ctx, scTestAddr, scTestAddr2, []byte{0, 0, 0, 0}, 0)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if result.EnergyRequired != 5000 || result.Method != EstimateMethodConstantCall {
+					t.Fatalf("unexpected estimate %+v", result)
+				}
+			})
+		}
+	})
+
+	t.Run("revert", func(t *testing.T) {
+		mgr, cleanup := setupSCTestServer(t, &fakeSCWalletServer{
+			EstimateEnergyFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+				return &api.EstimateEnergyMessage{Result: &api.Return{Message: []byte("REVERT opcode executed")}}, nil
+			},
+		})
+		defer cleanup()
+		_, err := mgr.EstimateEnergy(ctx, scTestAddr, scTestAddr2, []byte{0, 0, 0, 0}, 0)
+		if !errors.Is(err, types.ErrContractExecutionFailed) {
+			t.Fatalf("expected ErrContractExecutionFailed, got %v", err)
 		}
 	})
 
@@ -384,6 +648,103 @@ func TestManagerGetContractInfo(t *testing.T) {
 	})
 }
 
+func TestManagerGetContractDetails(t *testing.T) {
+	abi, err := DecodeABI(`[{"name":"transfer","type":"function","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},{"name":"Transfer","type":"event","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]}]`)
+	if err != nil {
+		t.Fatalf("decode abi: %v", err)
+	}
+	bytecode := []byte{0x60, 0x80, 0x60, 0x40}
+
+	fake := &fakeSCWalletServer{
+		GetContractFunc: func(ctx context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
+			if len(in.GetValue()) == 0 || in.GetValue()[1] == 0 {
+				return &core.SmartContract{}, nil
+			}
+			return &core.SmartContract{
+				OriginAddress:              scTestAddr.Bytes(),
+				ContractAddress:            in.GetValue(),
+				Abi:                        abi,
+				Bytecode:                   bytecode,
+				Name:                       "Token",
+				ConsumeUserResourcePercent: 30,
+				OriginEnergyLimit:          10_000_000,
+			}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		d, err := mgr.GetContractDetails(ctx, scTestAddr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if d.Name != "Token" || d.ConsumeUserResourcePercent != 30 || d.OriginEnergyLimit != 10_000_000 {
+			t.Fatalf("unexpected details: %+v", d)
+		}
+		if d.OriginAddress == nil || d.OriginAddress.String() != scTestAddr.String() {
+			t.Fatalf("unexpected origin address: %v", d.OriginAddress)
+		}
+		if len(d.Methods) != 1 || d.Methods[0].Signature != "transfer(address,uint256)" {
+			t.Fatalf("unexpected methods: %+v", d.Methods)
+		}
+		if len(d.Events) != 1 || d.Events[0].Signature != "Transfer(address,address,uint256)" {
+			t.Fatalf("unexpected events: %+v", d.Events)
+		}
+		// Bytecode is creation code, so it must not stand in for the
+		// runtime code hash the node omitted.
+		if len(d.CodeHash) != 0 {
+			t.Fatalf("expected no code hash when the node reports none, got %x", d.CodeHash)
+		}
+		reported := crypto.Keccak256([]byte{0x60, 0x80})
+		if d := NewContractDetails(scTestAddr, &core.SmartContract{Bytecode: bytecode, CodeHash: reported}); !bytes.Equal(d.CodeHash, reported) {
+			t.Fatalf("expected the reported code hash, got %x", d.CodeHash)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		empty := types.MustNewAddressFromHex("410000000000000000000000000000000000000000")
+		_, err := mgr.GetContractDetails(ctx, empty)
+		if !errors.Is(err, types.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("nil address", func(t *testing.T) {
+		if _, err := mgr.GetContractDetails(ctx, nil); err == nil {
+			t.Fatal("expected error for nil address")
+		}
+	})
+}
+
+func TestManagerGetRuntimeCode(t *testing.T) {
+	runtime := []byte{0x60, 0x80, 0x60, 0x40, 0x52}
+	fake := &fakeSCWalletServer{
+		GetContractInfoFunc: func(ctx context.Context, in *api.BytesMessage) (*core.SmartContractDataWrapper, error) {
+			if bytes.Equal(in.GetValue(), scTestAddr.Bytes()) {
+				return &core.SmartContractDataWrapper{Runtimecode: runtime}, nil
+			}
+			return &core.SmartContractDataWrapper{}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+	ctx := context.Background()
+
+	code, err := mgr.GetRuntimeCode(ctx, scTestAddr)
+	if err != nil || !bytes.Equal(code, runtime) {
+		t.Fatalf("GetRuntimeCode = %x, %v", code, err)
+	}
+	hash, err := mgr.GetCodeHash(ctx, scTestAddr)
+	if err != nil || !bytes.Equal(hash[:], crypto.Keccak256(runtime)) {
+		t.Fatalf("GetCodeHash = %x, %v", hash, err)
+	}
+	if _, err := mgr.GetCodeHash(ctx, scTestAddr2); !errors.Is(err, types.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
 func TestManagerUpdateSetting(t *testing.T) {
 	mgr, cleanup := setupSCTestServer(t, &fakeSCWalletServer{})
 	defer cleanup()
@@ -453,6 +814,36 @@ func TestManagerUpdateEnergyLimit(t *testing.T) {
 			t.Fatal("expected error for negative limit")
 		}
 	})
+
+	t.Run("zero limit", func(t *testing.T) {
+		_, err := mgr.UpdateEnergyLimit(ctx, scTestAddr, scTestAddr2, 0)
+		if !errors.Is(err, types.ErrInvalidParameter) {
+			t.Fatalf("expected ErrInvalidParameter, got %v", err)
+		}
+	})
+}
+
+func TestManagerGetContractSettings(t *testing.T) {
+	fake := &fakeSCWalletServer{
+		GetContractFunc: func(ctx context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
+			return &core.SmartContract{ConsumeUserResourcePercent: 40, OriginEnergyLimit: 5_000_000}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+	ctx := context.Background()
+
+	limit, err := mgr.GetOriginEnergyLimit(ctx, scTestAddr2)
+	if err != nil || limit != 5_000_000 {
+		t.Fatalf("GetOriginEnergyLimit = %d, %v", limit, err)
+	}
+	percent, err := mgr.GetConsumeUserResourcePercent(ctx, scTestAddr2)
+	if err != nil || percent != 40 {
+		t.Fatalf("GetConsumeUserResourcePercent = %d, %v", percent, err)
+	}
+	if _, err := mgr.GetOriginEnergyLimit(ctx, nil); !errors.Is(err, types.ErrInvalidAddress) {
+		t.Fatalf("expected ErrInvalidAddress, got %v", err)
+	}
 }
 
 func TestManagerClearContractABI(t *testing.T) {
@@ -581,3 +972,313 @@ func TestManagerDeploy(t *testing.T) {
 		}
 	})
 }
+
+func TestParseArtifact(t *testing.T) {
+	abi := `[{"inputs":[],"stateMutability":"nonpayable","type":"constructor"}]`
+	tests := []struct {
+		name     string
+		artifact string
+		wantCode []byte
+		wantErr  bool
+	}{
+		{"hardhat", `{"contractName":"Token","abi":` + abi + `,"bytecode":"0x6080"}`, []byte{0x60, 0x80}, false},
+		{"no prefix", `{"abi":` + abi + `,"bytecode":"6080"}`, []byte{0x60, 0x80}, false},
+		{"foundry", `{"abi":` + abi + `,"bytecode":{"object":"0x6080"}}`, []byte{0x60, 0x80}, false},
+		{"missing abi", `{"bytecode":"0x6080"}`, nil, true},
+		{"missing bytecode", `{"abi":` + abi + `}`, nil, true},
+		{"empty bytecode", `{"abi":` + abi + `,"bytecode":"0x"}`, nil, true},
+		{"unlinked library", `{"abi":` + abi + `,"bytecode":"0x60__$abc$__"}`, nil, true},
+		{"invalid json", `{`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseArtifact([]byte(tt.artifact))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArtifact() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !bytes.Equal(got.Bytecode, tt.wantCode) {
+				t.Errorf("bytecode = %x, want %x", got.Bytecode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestManagerDeployFromArtifact(t *testing.T) {
+	var captured *core.CreateSmartContract
+	fake := &fakeSCWalletServer{
+		DeployContractFunc: func(ctx context.Context, in *core.CreateSmartContract) (*api.TransactionExtention, error) {
+			captured = in
+			return &api.TransactionExtention{
+				Result:      &api.Return{Result: true},
+				Transaction: &core.Transaction{RawData: &core.TransactionRaw{}},
+			}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+
+	artifact := []byte(`{"contractName":"Token","abi":[{"inputs":[{"name":"supply","type":"uint256"}],"stateMutability":"nonpayable","type":"constructor"}],"bytecode":"0x6080"}`)
+	opts := DefaultDeployOptions()
+	opts.FeeLimit = 1_000_000_000
+
+	txExt, err := mgr.DeployFromArtifact(context.Background(), scTestAddr, artifact, opts, big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if txExt.GetTransaction().GetRawData().GetFeeLimit() != 1_000_000_000 {
+		t.Errorf("expected fee limit to be set, got %d", txExt.GetTransaction().GetRawData().GetFeeLimit())
+	}
+	contract := captured.GetNewContract()
+	if contract.GetName() != "Token" {
+		t.Errorf("expected name Token, got %q", contract.GetName())
+	}
+	if len(contract.GetBytecode()) != 2+32 {
+		t.Errorf("expected bytecode with encoded constructor args, got %d bytes", len(contract.GetBytecode()))
+	}
+	if contract.GetConsumeUserResourcePercent() != 100 || contract.GetOriginEnergyLimit() != 10_000_000 {
+		t.Errorf("unexpected resource settings: %d/%d", contract.GetConsumeUserResourcePercent(), contract.GetOriginEnergyLimit())
+	}
+
+	if _, err := mgr.DeployFromArtifact(context.Background(), scTestAddr, []byte(`{}`), opts); err == nil {
+		t.Fatal("expected error for empty artifact")
+	}
+}
+
+func TestManagerDeployCallValueAndToken(t *testing.T) {
+	var captured *core.CreateSmartContract
+	fake := &fakeSCWalletServer{
+		DeployContractFunc: func(ctx context.Context, in *core.CreateSmartContract) (*api.TransactionExtention, error) {
+			captured = in
+			return &api.TransactionExtention{
+				Result:      &api.Return{Result: true},
+				Transaction: &core.Transaction{RawData: &core.TransactionRaw{}},
+			}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+	ctx := context.Background()
+
+	payable := []byte(`{"contractName":"Vault","abi":[{"inputs":[],"stateMutability":"payable","type":"constructor"}],"bytecode":"0x6080"}`)
+	nonPayable := []byte(`{"contractName":"Vault","abi":[{"inputs":[],"stateMutability":"nonpayable","type":"constructor"}],"bytecode":"0x6080"}`)
+
+	t.Run("payable constructor", func(t *testing.T) {
+		opts := DefaultDeployOptions()
+		opts.CallValue = 1_000_000
+		opts.TokenID = 1000001
+		opts.TokenValue = 5
+		if _, err := mgr.DeployFromArtifact(ctx, scTestAddr, payable, opts); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if captured.GetNewContract().GetCallValue() != 1_000_000 {
+			t.Errorf("expected call value 1000000, got %d", captured.GetNewContract().GetCallValue())
+		}
+		if captured.GetTokenId() != 1000001 || captured.GetCallTokenValue() != 5 {
+			t.Errorf("unexpected token transfer: id=%d value=%d", captured.GetTokenId(), captured.GetCallTokenValue())
+		}
+	})
+
+	t.Run("non-payable constructor", func(t *testing.T) {
+		opts := DefaultDeployOptions()
+		opts.CallValue = 1
+		_, err := mgr.DeployFromArtifact(ctx, scTestAddr, nonPayable, opts)
+		if !errors.Is(err, types.ErrInvalidParameter) {
+			t.Fatalf("expected ErrInvalidParameter, got %v", err)
+		}
+	})
+
+	t.Run("token value without id", func(t *testing.T) {
+		opts := DefaultDeployOptions()
+		opts.TokenValue = 5
+		if _, err := mgr.DeployFromArtifact(ctx, scTestAddr, payable, opts); err == nil {
+			t.Fatal("expected error for token value without token ID")
+		}
+	})
+}
+
+func TestManagerMulticall(t *testing.T) {
+	fake := &fakeSCWalletServer{
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			args, err := parsedMulticall3ABI.Methods["aggregate3"].Inputs.Unpack(in.Data[4:])
+			if err != nil {
+				return nil, err
+			}
+			calls := *eABI.ConvertType(args[0], new([]multicall3Call)).(*[]multicall3Call)
+
+			balance := make([]byte, 32)
+			balance[31] = 42
+			results := make([]multicall3Result, len(calls))
+			results[0] = multicall3Result{Success: true, ReturnData: balance}
+			results[1] = multicall3Result{Success: false}
+			out, err := parsedMulticall3ABI.Methods["aggregate3"].Outputs.Pack(results)
+			if err != nil {
+				return nil, err
+			}
+			return &api.TransactionExtention{
+				Result:         &api.Return{Result: true},
+				ConstantResult: [][]byte{out},
+			}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+	ctx := context.Background()
+
+	calls := []Call{
+		{Target: scTestAddr, ABI: testERC20ABI, Method: "balanceOf", Args: []interface{}{scTestAddr2.String()}},
+		{Target: scTestAddr2, ABI: testERC20ABI, Method: "name"},
+	}
+
+	results, err := mgr.Multicall(ctx, scTestAddr, scTestAddr, calls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if !results[0].Success || results[0].Err != nil {
+		t.Fatalf("expected first call to succeed, got %+v", results[0])
+	}
+	if v, ok := results[0].Value.(*big.Int); !ok || v.Int64() != 42 {
+		t.Fatalf("expected balance 42, got %v", results[0].Value)
+	}
+	if results[1].Success || results[1].Err == nil {
+		t.Fatalf("expected second call to fail, got %+v", results[1])
+	}
+
+	if _, err := mgr.Multicall(ctx, nil, scTestAddr, calls); err == nil {
+		t.Fatal("expected error for nil multicall address")
+	}
+	if _, err := mgr.Multicall(ctx, scTestAddr, scTestAddr, nil); err == nil {
+		t.Fatal("expected error for empty calls")
+	}
+}
+
+func TestInstanceCallAtBlock(t *testing.T) {
+	var gotParams []json.RawMessage
+	archiveSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_call" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		gotParams = req.Params
+		switch string(req.Params[1]) {
+		case `"0x63"`: // block 99
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x000000000000000000000000000000000000000000000000000000000000002a"}`))
+		case `"0x62"`: // block 98
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"REVERT opcode executed"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"QUANTITY not supported, just support TAG as latest"}}`))
+		}
+	}))
+	defer archiveSrv.Close()
+
+	mgr, cleanup := setupSCTestServer(t, &fakeSCWalletServer{})
+	defer cleanup()
+	ctx := context.Background()
+
+	inst, err := mgr.Instance(scTestAddr, testERC20ABI, WithArchive(NewJSONRPCArchive(archiveSrv.URL)))
+	if err != nil {
+		t.Fatalf("failed to create instance: %v", err)
+	}
+
+	t.Run("historical block", func(t *testing.T) {
+		result, err := inst.CallAtBlock(ctx, scTestAddr, 99, "balanceOf", scTestAddr.String())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v, ok := result.(*big.Int); !ok || v.Int64() != 42 {
+			t.Fatalf("expected balance 42, got %v", result)
+		}
+		var call map[string]string
+		if err := json.Unmarshal(gotParams[0], &call); err != nil {
+			t.Fatalf("invalid call object: %v", err)
+		}
+		if call["to"] != scTestAddr.HexEVM() || call["from"] != scTestAddr.HexEVM() || !strings.HasPrefix(call["data"], "0x70a08231") {
+			t.Fatalf("unexpected call object %v", call)
+		}
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		_, err := inst.CallAtBlock(ctx, scTestAddr, 98, "balanceOf", scTestAddr.String())
+		if !errors.Is(err, types.ErrContractExecutionFailed) {
+			t.Fatalf("expected ErrContractExecutionFailed, got %v", err)
+		}
+	})
+
+	t.Run("block not served", func(t *testing.T) {
+		_, err := inst.CallAtBlock(ctx, scTestAddr, 1, "balanceOf", scTestAddr.String())
+		if !errors.Is(err, ErrArchivalNotSupported) {
+			t.Fatalf("expected ErrArchivalNotSupported, got %v", err)
+		}
+	})
+
+	t.Run("no archive", func(t *testing.T) {
+		plain, err := mgr.Instance(scTestAddr, testERC20ABI)
+		if err != nil {
+			t.Fatalf("failed to create instance: %v", err)
+		}
+		_, err = plain.CallAtBlock(ctx, scTestAddr, 99, "balanceOf", scTestAddr.String())
+		if !errors.Is(err, ErrArchivalNotSupported) {
+			t.Fatalf("expected ErrArchivalNotSupported, got %v", err)
+		}
+	})
+
+	t.Run("negative block", func(t *testing.T) {
+		_, err := inst.CallAtBlock(ctx, scTestAddr, -1, "balanceOf", scTestAddr.String())
+		if !errors.Is(err, types.ErrInvalidParameter) {
+			t.Fatalf("expected ErrInvalidParameter, got %v", err)
+		}
+	})
+}
+
+func TestManagerMulticall_FetchesABIOncePerTarget(t *testing.T) {
+	stored, err := utils.NewABIProcessor(nil).ParseABI(testERC20ABI)
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+	var fetches atomic.Int32
+	fake := &fakeSCWalletServer{
+		GetContractFunc: func(ctx context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
+			fetches.Add(1)
+			return &core.SmartContract{Abi: stored}, nil
+		},
+		TriggerConstantContractFunc: func(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			args, err := parsedMulticall3ABI.Methods["aggregate3"].Inputs.Unpack(in.Data[4:])
+			if err != nil {
+				return nil, err
+			}
+			calls := *eABI.ConvertType(args[0], new([]multicall3Call)).(*[]multicall3Call)
+			out, err := parsedMulticall3ABI.Methods["aggregate3"].Outputs.Pack(make([]multicall3Result, len(calls)))
+			if err != nil {
+				return nil, err
+			}
+			return &api.TransactionExtention{
+				Result:         &api.Return{Result: true},
+				ConstantResult: [][]byte{out},
+			}, nil
+		},
+	}
+	mgr, cleanup := setupSCTestServer(t, fake)
+	defer cleanup()
+
+	calls := []Call{
+		{Target: scTestAddr, Method: "name"},
+		{Target: scTestAddr, Method: "balanceOf", Args: []interface{}{scTestAddr2.String()}},
+		{Target: scTestAddr2, Method: "name"},
+		{Target: scTestAddr, Method: "name"},
+	}
+	if _, err := mgr.Multicall(context.Background(), scTestAddr, scTestAddr, calls); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Fatalf("expected 2 ABI fetches (one per target), got %d", got)
+	}
+}
@@ -0,0 +1,182 @@
+package smartcontract
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// multicall3ABI is the subset of the Multicall3 ABI used by Multicall.
+const multicall3ABI = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+var parsedMulticall3ABI = func() eABI.ABI {
+	parsed, err := eABI.JSON(strings.NewReader(multicall3ABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid multicall3 ABI: %v", err))
+	}
+	return parsed
+}()
+
+// multicall3Call mirrors the Multicall3.Call3 struct for ABI packing.
+type multicall3Call struct {
+	Target       eCommon.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// multicall3Result mirrors the Multicall3.Result struct for ABI unpacking.
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Call describes a single read-only method call to be batched by Multicall.
+type Call struct {
+	// Target is the contract to call.
+	Target *types.Address
+	// ABI of the target contract, as a JSON string or *core.SmartContract_ABI.
+	// If nil, the ABI is fetched from the network, once per target per batch.
+	ABI any
+	// Method is the name of the method to call.
+	Method string
+	// Args are the method arguments.
+	Args []interface{}
+}
+
+// CallResult is the outcome of a single call in a Multicall batch.
+type CallResult struct {
+	// Success reports whether the call executed without reverting.
+	Success bool
+	// ReturnData holds the raw return bytes (or revert data on failure).
+	ReturnData []byte
+	// Value is the decoded return value, shaped like Instance.Call's result.
+	Value interface{}
+	// Err is set when the call reverted or its result could not be decoded.
+	Err error
+}
+
+// Multicall executes several read-only calls in one round trip through a
+// deployed Multicall3 contract at multicallAddress, using aggregate3 with
+// allowFailure set for every call.
+//
+// A reverting call does not fail the batch; its CallResult has Success set to
+// false and Err describing the failure. An error is returned only if the
+// calls cannot be encoded or the aggregate call itself fails.
+//
+// Example:
+//
+//	results, err := contractMgr.Multicall(ctx, multicallAddr, owner, []smartcontract.Call{
+//	    {Target: usdt, ABI: erc20ABI, Method: "balanceOf", Args: []interface{}{holder}},
+//	    {Target: usdt, ABI: erc20ABI, Method: "decimals"},
+//	})
+func (m *Manager) Multicall(ctx context.Context, multicallAddress, owner *types.Address, calls []Call) ([]CallResult, error) {
+	if multicallAddress == nil {
+		return nil, fmt.Errorf("%w: invalid multicall address: nil", types.ErrInvalidAddress)
+	}
+	if owner == nil {
+		return nil, fmt.Errorf("%w: invalid owner address: nil", types.ErrInvalidAddress)
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("%w: calls cannot be empty", types.ErrInvalidParameter)
+	}
+
+	instances := make([]*Instance, len(calls))
+	packed := make([]multicall3Call, len(calls))
+	// fetched holds instances whose ABI came from the node, so each target's
+	// ABI is fetched once per batch.
+	fetched := make(map[string]*Instance)
+	for i, call := range calls {
+		if call.Target == nil {
+			return nil, fmt.Errorf("%w: call %d: target address cannot be nil", types.ErrInvalidAddress, i)
+		}
+
+		var inst *Instance
+		var err error
+		if call.ABI != nil {
+			inst, err = NewInstance(m.conn, call.Target, call.ABI)
+		} else if cached, ok := fetched[call.Target.String()]; ok {
+			inst = cached
+		} else {
+			inst, err = NewInstance(m.conn, call.Target)
+			if err == nil {
+				fetched[call.Target.String()] = inst
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		data, err := inst.Encode(call.Method, call.Args...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: call %d: failed to encode input for method %s: %v", types.ErrInvalidContract, i, call.Method, err)
+		}
+
+		instances[i] = inst
+		packed[i] = multicall3Call{
+			Target:       call.Target.EVMAddress(),
+			AllowFailure: true,
+			CallData:     data,
+		}
+	}
+
+	data, err := parsedMulticall3ABI.Pack("aggregate3", packed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode aggregate3 call: %v", types.ErrInvalidContract, err)
+	}
+
+	req := &core.TriggerSmartContract{
+		OwnerAddress:    owner.Bytes(),
+		ContractAddress: multicallAddress.Bytes(),
+		Data:            data,
+	}
+	result, err := lowlevel.Call(m.conn, ctx, "multicall", func(cl api.WalletClient, ctx context.Context) (*api.TransactionExtention, error) {
+		return cl.TriggerConstantContract(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ret := result.GetResult(); ret != nil && !ret.GetResult() {
+		return nil, fmt.Errorf("%w: aggregate3 call failed: %s", types.ErrInvalidContract, string(ret.GetMessage()))
+	}
+
+	var raw []byte
+	for _, chunk := range result.GetConstantResult() {
+		raw = append(raw, chunk...)
+	}
+	unpacked, err := parsedMulticall3ABI.Unpack("aggregate3", raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode aggregate3 result: %v", types.ErrInvalidContract, err)
+	}
+	if len(unpacked) != 1 {
+		return nil, fmt.Errorf("%w: unexpected aggregate3 result shape", types.ErrInvalidContract)
+	}
+	results, ok := eABI.ConvertType(unpacked[0], new([]multicall3Result)).(*[]multicall3Result)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected aggregate3 result type", types.ErrInvalidContract)
+	}
+	if len(*results) != len(calls) {
+		return nil, fmt.Errorf("%w: aggregate3 returned %d results for %d calls", types.ErrInvalidContract, len(*results), len(calls))
+	}
+
+	out := make([]CallResult, len(calls))
+	for i, r := range *results {
+		out[i] = CallResult{Success: r.Success, ReturnData: r.ReturnData}
+		if !r.Success {
+			out[i].Err = fmt.Errorf("%w: call %d to %s reverted", types.ErrInvalidContract, i, calls[i].Method)
+			continue
+		}
+		value, err := instances[i].DecodeResult(calls[i].Method, r.ReturnData)
+		if err != nil {
+			out[i].Err = fmt.Errorf("%w: call %d: failed to decode result for method %s: %v", types.ErrInvalidContract, i, calls[i].Method, err)
+			continue
+		}
+		out[i].Value = value
+	}
+	return out, nil
+}
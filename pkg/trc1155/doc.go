@@ -0,0 +1,27 @@
+// Package trc1155 provides a typed interface for TRC1155 (multi-token)
+// contracts.
+//
+// TRC1155Manager wraps a generic smart contract instance with methods for
+// single and batched balance queries, safe single and batch transfers,
+// operator approvals and the metadata URI extension. Write methods return
+// unsigned transactions to be signed and broadcast with
+// client.SignAndBroadcast.
+//
+// # Quick Start
+//
+//	items, err := trc1155.NewManager(cli, gameItemsAddr)
+//	if err != nil { /* handle */ }
+//
+//	bal, _ := items.BalanceOf(ctx, player, big.NewInt(1))
+//	tmpl, _ := items.URI(ctx, big.NewInt(1))
+//	uri := trc1155.ExpandURI(tmpl, big.NewInt(1))
+//
+//	txExt, err := items.SafeTransferFrom(ctx, player, friend, big.NewInt(1), big.NewInt(5), nil)
+//	if err != nil { /* handle */ }
+//	res, err := cli.SignAndBroadcast(ctx, txExt, client.DefaultBroadcastOptions(), signer)
+//
+// # Events
+//
+// DecodeTransferLog decodes TransferSingle and TransferBatch logs into a
+// TransferEvent, representing a single transfer as a batch of one.
+package trc1155
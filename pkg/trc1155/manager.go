@@ -0,0 +1,341 @@
+package trc1155
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/smartcontract"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+)
+
+var (
+	transferSingleTopic = crypto.Keccak256([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	transferBatchTopic  = crypto.Keccak256([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+)
+
+// TRC1155Manager provides a typed interface for a TRC1155 multi-token
+// contract.
+type TRC1155Manager struct {
+	contract *smartcontract.Instance
+}
+
+// NewManager constructs a TRC1155 manager bound to the given contract address
+// using the provided TRON connection provider.
+//
+// Example:
+//
+//	items, err := trc1155.NewManager(cli, gameItemsAddr)
+//	if err != nil {
+//	    // handle error
+//	}
+//	balances, err := items.BalanceOfBatch(ctx, []*types.Address{player, player}, []*big.Int{swordID, shieldID})
+func NewManager(tronClient lowlevel.ConnProvider, contractAddress *types.Address) (*TRC1155Manager, error) {
+	contract, err := smartcontract.NewInstance(tronClient, contractAddress, TRC1155ABI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create smart contract instance for TRC1155: %w", err)
+	}
+	return &TRC1155Manager{contract: contract}, nil
+}
+
+// Address returns the contract address the manager is bound to.
+func (m *TRC1155Manager) Address() *types.Address {
+	return m.contract.Address
+}
+
+// URI returns the metadata URI of token id as stored by the contract. It may
+// be a template containing "{id}"; see ExpandURI.
+func (m *TRC1155Manager) URI(ctx context.Context, id *big.Int) (string, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", id); err != nil {
+		return "", err
+	}
+	result, err := m.contract.Call(ctx, m.contract.Address, "uri", id)
+	if err != nil {
+		return "", fmt.Errorf("failed to call uri method: %w", err)
+	}
+	uri, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected type for uri value: %T", result)
+	}
+	return uri, nil
+}
+
+// ExpandURI substitutes the token id into a TRC1155 URI template: every
+// "{id}" becomes the id as 64 lowercase hex digits, as the standard
+// specifies.
+func ExpandURI(template string, id *big.Int) string {
+	return strings.ReplaceAll(template, "{id}", fmt.Sprintf("%064x", id))
+}
+
+// BalanceOf returns account's balance of token id.
+func (m *TRC1155Manager) BalanceOf(ctx context.Context, account *types.Address, id *big.Int) (*big.Int, error) {
+	if account == nil {
+		return nil, fmt.Errorf("%w: account address cannot be nil", types.ErrInvalidAddress)
+	}
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", id); err != nil {
+		return nil, err
+	}
+	result, err := m.contract.Call(ctx, account, "balanceOf", account, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call balanceOf method: %w", err)
+	}
+	balance, ok := result.(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for balanceOf value: %T", result)
+	}
+	return balance, nil
+}
+
+// BalanceOfBatch returns the balances of many (account, id) pairs in one
+// constant call; balances[i] belongs to accounts[i] and ids[i]. accounts and
+// ids must have the same length.
+//
+// Example:
+//
+//	ids := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+//	owners := []*types.Address{player, player, player}
+//	balances, err := items.BalanceOfBatch(ctx, owners, ids)
+func (m *TRC1155Manager) BalanceOfBatch(ctx context.Context, accounts []*types.Address, ids []*big.Int) ([]*big.Int, error) {
+	if len(accounts) != len(ids) {
+		return nil, fmt.Errorf("%w: %d accounts but %d ids", types.ErrInvalidParameter, len(accounts), len(ids))
+	}
+	if len(accounts) == 0 {
+		return []*big.Int{}, nil
+	}
+	for i, account := range accounts {
+		if account == nil {
+			return nil, fmt.Errorf("%w: account %d cannot be nil", types.ErrInvalidAddress, i)
+		}
+	}
+	if err := validateUint256s("token id", ids); err != nil {
+		return nil, err
+	}
+
+	result, err := m.contract.Call(ctx, accounts[0], "balanceOfBatch", accounts, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call balanceOfBatch method: %w", err)
+	}
+	balances, err := toBigInts(result)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected balanceOfBatch value: %w", err)
+	}
+	if len(balances) != len(ids) {
+		return nil, fmt.Errorf("balanceOfBatch returned %d balances for %d ids", len(balances), len(ids))
+	}
+	return balances, nil
+}
+
+// IsApprovedForAll reports whether operator may transfer all of account's
+// tokens.
+func (m *TRC1155Manager) IsApprovedForAll(ctx context.Context, account, operator *types.Address) (bool, error) {
+	if account == nil || operator == nil {
+		return false, fmt.Errorf("%w: account and operator addresses cannot be nil", types.ErrInvalidAddress)
+	}
+	result, err := m.contract.Call(ctx, account, "isApprovedForAll", account, operator)
+	if err != nil {
+		return false, fmt.Errorf("failed to call isApprovedForAll method: %w", err)
+	}
+	approved, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected type for isApprovedForAll value: %T", result)
+	}
+	return approved, nil
+}
+
+// SafeTransferFrom creates an unsigned transfer of amount of token id from
+// from to to, sent by from. A contract recipient must implement
+// onTRC1155Received or the transfer reverts; data is passed to it.
+func (m *TRC1155Manager) SafeTransferFrom(ctx context.Context, from, to *types.Address, id, amount *big.Int, data []byte) (*api.TransactionExtention, error) {
+	if to == nil {
+		return nil, fmt.Errorf("%w: recipient address cannot be nil", types.ErrInvalidAddress)
+	}
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", id); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "amount", amount); err != nil {
+		return nil, err
+	}
+	txExt, err := m.contract.Invoke(ctx, from, 0, "safeTransferFrom", from, to, id, amount, nonNil(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call safeTransferFrom method: %w", err)
+	}
+	return txExt, nil
+}
+
+// SafeBatchTransferFrom creates an unsigned transfer of amounts[i] of each
+// ids[i] from from to to in one transaction, sent by from.
+//
+// Example:
+//
+//	txExt, err := items.SafeBatchTransferFrom(ctx, player, market,
+//	    []*big.Int{swordID, shieldID}, []*big.Int{big.NewInt(1), big.NewInt(2)}, nil)
+func (m *TRC1155Manager) SafeBatchTransferFrom(ctx context.Context, from, to *types.Address, ids, amounts []*big.Int, data []byte) (*api.TransactionExtention, error) {
+	if to == nil {
+		return nil, fmt.Errorf("%w: recipient address cannot be nil", types.ErrInvalidAddress)
+	}
+	if len(ids) != len(amounts) {
+		return nil, fmt.Errorf("%w: %d ids but %d amounts", types.ErrInvalidParameter, len(ids), len(amounts))
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: batch transfer needs at least one id", types.ErrInvalidParameter)
+	}
+	if err := validateUint256s("token id", ids); err != nil {
+		return nil, err
+	}
+	if err := validateUint256s("amount", amounts); err != nil {
+		return nil, err
+	}
+	txExt, err := m.contract.Invoke(ctx, from, 0, "safeBatchTransferFrom", from, to, ids, amounts, nonNil(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call safeBatchTransferFrom method: %w", err)
+	}
+	return txExt, nil
+}
+
+// SetApprovalForAll creates an unsigned transaction, sent by account, that
+// grants or revokes operator's right to transfer all of account's tokens.
+func (m *TRC1155Manager) SetApprovalForAll(ctx context.Context, account, operator *types.Address, approved bool) (*api.TransactionExtention, error) {
+	if operator == nil {
+		return nil, fmt.Errorf("%w: operator address cannot be nil", types.ErrInvalidAddress)
+	}
+	txExt, err := m.contract.Invoke(ctx, account, 0, "setApprovalForAll", operator, approved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call setApprovalForAll method: %w", err)
+	}
+	return txExt, nil
+}
+
+// nonNil returns data, or an empty slice for nil so it encodes as bytes.
+func nonNil(data []byte) []byte {
+	if data == nil {
+		return []byte{}
+	}
+	return data
+}
+
+// validateUint256s checks every element of vs with utils.ValidateUint256.
+func validateUint256s(what string, vs []*big.Int) error {
+	for i, v := range vs {
+		if err := utils.ValidateUint256(types.ErrInvalidParameter, fmt.Sprintf("%s %d", what, i), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toBigInts converts a decoded uint256[] value.
+func toBigInts(v interface{}) ([]*big.Int, error) {
+	switch vs := v.(type) {
+	case []*big.Int:
+		return vs, nil
+	case []interface{}:
+		out := make([]*big.Int, len(vs))
+		for i, e := range vs {
+			b, ok := e.(*big.Int)
+			if !ok {
+				return nil, fmt.Errorf("element %d has type %T", i, e)
+			}
+			out[i] = b
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unexpected type %T", v)
+	}
+}
+
+// TransferEvent is a decoded TransferSingle or TransferBatch log. A single
+// transfer has one entry in IDs and Values. From is the zero address for
+// mints and To is the zero address for burns.
+type TransferEvent struct {
+	Contract *types.Address
+	Operator *types.Address
+	From     *types.Address
+	To       *types.Address
+	IDs      []*big.Int
+	Values   []*big.Int
+	// Batch reports whether the log was a TransferBatch.
+	Batch bool
+}
+
+// DecodeTransferLog decodes a TRC1155 TransferSingle or TransferBatch log.
+// Other logs are rejected with an error wrapping types.ErrInvalidParameter.
+//
+// Example:
+//
+//	for _, lg := range receipt.Logs {
+//	    ev, err := trc1155.DecodeTransferLog(lg)
+//	    if err != nil {
+//	        continue
+//	    }
+//	    for i := range ev.IDs {
+//	        fmt.Printf("%s x%s: %s -> %s\n", ev.IDs[i], ev.Values[i], ev.From, ev.To)
+//	    }
+//	}
+func DecodeTransferLog(lg *core.TransactionInfo_Log) (*TransferEvent, error) {
+	topics := lg.GetTopics()
+	if len(topics) != 4 {
+		return nil, fmt.Errorf("%w: not a TRC1155 transfer log", types.ErrInvalidParameter)
+	}
+
+	var argTypes []string
+	ev := &TransferEvent{}
+	switch {
+	case bytes.Equal(topics[0], transferSingleTopic):
+		argTypes = []string{"uint256", "uint256"}
+	case bytes.Equal(topics[0], transferBatchTopic):
+		argTypes = []string{"uint256[]", "uint256[]"}
+		ev.Batch = true
+	default:
+		return nil, fmt.Errorf("%w: not a TRC1155 transfer log", types.ErrInvalidParameter)
+	}
+
+	addrs := make([]*types.Address, 3)
+	for i, topic := range topics[1:] {
+		if len(topic) != 32 {
+			return nil, fmt.Errorf("%w: transfer topic must be 32 bytes, got %d", types.ErrInvalidParameter, len(topic))
+		}
+		addr, err := types.NewAddressFromBytes(topic[12:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid address topic: %v", types.ErrInvalidParameter, err)
+		}
+		addrs[i] = addr
+	}
+	ev.Operator, ev.From, ev.To = addrs[0], addrs[1], addrs[2]
+
+	args := make(eABI.Arguments, len(argTypes))
+	for i, t := range argTypes {
+		typ, err := eABI.NewType(t, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = eABI.Argument{Type: typ}
+	}
+	values, err := args.Unpack(lg.GetData())
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode transfer data: %v", types.ErrInvalidParameter, err)
+	}
+	if ev.Batch {
+		ev.IDs, _ = values[0].([]*big.Int)
+		ev.Values, _ = values[1].([]*big.Int)
+		if len(ev.IDs) != len(ev.Values) {
+			return nil, fmt.Errorf("%w: batch has %d ids but %d values", types.ErrInvalidParameter, len(ev.IDs), len(ev.Values))
+		}
+	} else {
+		id, _ := values[0].(*big.Int)
+		value, _ := values[1].(*big.Int)
+		ev.IDs, ev.Values = []*big.Int{id}, []*big.Int{value}
+	}
+
+	if len(lg.GetAddress()) > 0 {
+		ev.Contract, _ = types.NewAddressFromBytes(lg.GetAddress())
+	}
+	return ev, nil
+}
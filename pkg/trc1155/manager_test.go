@@ -0,0 +1,155 @@
+package trc1155_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client"
+	"github.com/kslamph/tronlib/pkg/client/clienttest"
+	"github.com/kslamph/tronlib/pkg/trc1155"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+var (
+	items     = types.MustNewAddressFromBase58("TKCTfkQ8L9beavNu9iaGtCHFxrwNHUxfr2")
+	holder    = types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	recipient = types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+)
+
+type itemServer struct {
+	clienttest.TriggerRecorder
+	t *testing.T
+}
+
+func (s *itemServer) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	var out []byte
+	sel := in.Data[:4]
+	switch {
+	case bytes.Equal(sel, clienttest.Selector("uri(uint256)")):
+		out = clienttest.Pack(s.t, []string{"string"}, "ipfs://items/{id}.json")
+	case bytes.Equal(sel, clienttest.Selector("balanceOf(address,uint256)")):
+		out = clienttest.Pack(s.t, []string{"uint256"}, big.NewInt(5))
+	case bytes.Equal(sel, clienttest.Selector("balanceOfBatch(address[],uint256[])")):
+		out = clienttest.Pack(s.t, []string{"uint256[]"}, []*big.Int{big.NewInt(5), big.NewInt(0)})
+	case bytes.Equal(sel, clienttest.Selector("isApprovedForAll(address,address)")):
+		out = clienttest.Pack(s.t, []string{"bool"}, true)
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{out}}, nil
+}
+
+func newManager(t *testing.T) (*trc1155.TRC1155Manager, *itemServer) {
+	t.Helper()
+	impl := &itemServer{t: t}
+	c := clienttest.NewClient(t, impl, client.WithTimeout(time.Second), client.WithPool(1, 1))
+
+	m, err := trc1155.NewManager(c, items)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return m, impl
+}
+
+func TestTRC1155Manager_Reads(t *testing.T) {
+	m, _ := newManager(t)
+	ctx := context.Background()
+	id := big.NewInt(10)
+
+	uri, err := m.URI(ctx, id)
+	if err != nil || uri != "ipfs://items/{id}.json" {
+		t.Fatalf("URI = %q, %v", uri, err)
+	}
+	want := "ipfs://items/000000000000000000000000000000000000000000000000000000000000000a.json"
+	if got := trc1155.ExpandURI(uri, id); got != want {
+		t.Fatalf("ExpandURI = %q, want %q", got, want)
+	}
+	if bal, err := m.BalanceOf(ctx, holder, id); err != nil || bal.Int64() != 5 {
+		t.Fatalf("BalanceOf = %v, %v", bal, err)
+	}
+	bals, err := m.BalanceOfBatch(ctx, []*types.Address{holder, recipient}, []*big.Int{id, id})
+	if err != nil || len(bals) != 2 || bals[0].Int64() != 5 || bals[1].Sign() != 0 {
+		t.Fatalf("BalanceOfBatch = %v, %v", bals, err)
+	}
+	if all, err := m.IsApprovedForAll(ctx, holder, recipient); err != nil || !all {
+		t.Fatalf("IsApprovedForAll = %v, %v", all, err)
+	}
+
+	if _, err := m.BalanceOfBatch(ctx, []*types.Address{holder}, []*big.Int{id, id}); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for length mismatch, got %v", err)
+	}
+	if _, err := m.BalanceOf(ctx, holder, big.NewInt(-1)); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for negative id, got %v", err)
+	}
+}
+
+func TestTRC1155Manager_Writes(t *testing.T) {
+	m, srv := newManager(t)
+	ctx := context.Background()
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	amounts := []*big.Int{big.NewInt(3), big.NewInt(4)}
+
+	clienttest.RunWriteCases(t, &srv.TriggerRecorder, []clienttest.WriteCase{
+		{Name: "SafeTransferFrom", Call: func() (*api.TransactionExtention, error) {
+			return m.SafeTransferFrom(ctx, holder, recipient, ids[0], amounts[0], nil)
+		}, Sig: "safeTransferFrom(address,address,uint256,uint256,bytes)"},
+		{Name: "SafeBatchTransferFrom", Call: func() (*api.TransactionExtention, error) {
+			return m.SafeBatchTransferFrom(ctx, holder, recipient, ids, amounts, []byte("hi"))
+		}, Sig: "safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)"},
+		{Name: "SetApprovalForAll", Call: func() (*api.TransactionExtention, error) { return m.SetApprovalForAll(ctx, holder, recipient, true) }, Sig: "setApprovalForAll(address,bool)"},
+	})
+
+	if _, err := m.SafeBatchTransferFrom(ctx, holder, recipient, ids, amounts[:1], nil); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for length mismatch, got %v", err)
+	}
+	if _, err := m.SafeTransferFrom(ctx, holder, nil, ids[0], amounts[0], nil); !errors.Is(err, types.ErrInvalidAddress) {
+		t.Fatalf("expected ErrInvalidAddress for nil recipient, got %v", err)
+	}
+}
+
+func TestDecodeTransferLog(t *testing.T) {
+	word := func(b []byte) []byte { return eCommon.LeftPadBytes(b, 32) }
+	addrTopics := [][]byte{word(holder.BytesEVM()), word(holder.BytesEVM()), word(recipient.BytesEVM())}
+
+	single := crypto.Keccak256([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	ev, err := trc1155.DecodeTransferLog(&core.TransactionInfo_Log{
+		Address: items.BytesEVM(),
+		Topics:  append([][]byte{single}, addrTopics...),
+		Data:    clienttest.Pack(t, []string{"uint256", "uint256"}, big.NewInt(7), big.NewInt(2)),
+	})
+	if err != nil {
+		t.Fatalf("DecodeTransferLog single: %v", err)
+	}
+	if ev.Batch || ev.From.String() != holder.String() || ev.To.String() != recipient.String() ||
+		len(ev.IDs) != 1 || ev.IDs[0].Int64() != 7 || ev.Values[0].Int64() != 2 {
+		t.Fatalf("unexpected single event %+v", ev)
+	}
+	if ev.Contract.String() != items.String() {
+		t.Fatalf("unexpected contract %s", ev.Contract)
+	}
+
+	batch := crypto.Keccak256([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+	ev, err = trc1155.DecodeTransferLog(&core.TransactionInfo_Log{
+		Topics: append([][]byte{batch}, addrTopics...),
+		Data:   clienttest.Pack(t, []string{"uint256[]", "uint256[]"}, []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(3), big.NewInt(4)}),
+	})
+	if err != nil {
+		t.Fatalf("DecodeTransferLog batch: %v", err)
+	}
+	if !ev.Batch || len(ev.IDs) != 2 || ev.IDs[1].Int64() != 2 || ev.Values[1].Int64() != 4 {
+		t.Fatalf("unexpected batch event %+v", ev)
+	}
+
+	other := crypto.Keccak256([]byte("Transfer(address,address,uint256)"))
+	_, err = trc1155.DecodeTransferLog(&core.TransactionInfo_Log{Topics: append([][]byte{other}, addrTopics...)})
+	if !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for non-TRC1155 log, got %v", err)
+	}
+}
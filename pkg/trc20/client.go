@@ -4,14 +4,14 @@ import (
 	"context"
 	"fmt"
 	"math/big"
-	"strings"
 	"sync"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
 	"github.com/kslamph/tronlib/pkg/client/lowlevel"
 	"github.com/kslamph/tronlib/pkg/smartcontract"
 	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
 	"github.com/shopspring/decimal"
 )
 
@@ -34,8 +34,44 @@ type TRC20Manager struct {
 	decimalsCached bool         // Flag to track if decimals have been cached
 	mu             sync.RWMutex // Mutex for thread-safe access to cached properties
 
-	// Pre-parsed ABI for common TRC20 methods
-	trc20ABI *abi.ABI
+	// ref builds transactions locally when set (see NewManagerWithRefProvider)
+	ref types.RefBlockProvider
+
+	// rounding applies to decimal amounts of Transfer and Approve
+	rounding RoundingMode
+}
+
+// ManagerOption configures a TRC20Manager at construction.
+type ManagerOption func(*managerOptions)
+
+type managerOptions struct {
+	instanceOpts []any
+}
+
+// WithDefaultFeeLimit sets the fee limit, in SUN, stamped on the Transfer and
+// Approve transactions the manager builds; zero leaves it unset. The default
+// is 150 TRX.
+//
+// Precedence at signing: a non-zero BroadcastOptions.FeeLimit (or
+// AutoFeeLimit) passed to client.SignAndBroadcast replaces the stamped value,
+// while a zero FeeLimit, as in client.DefaultBroadcastOptions, keeps it.
+func WithDefaultFeeLimit(sun int64) ManagerOption {
+	return func(o *managerOptions) {
+		o.instanceOpts = append(o.instanceOpts, smartcontract.WithDefaultFeeLimit(sun))
+	}
+}
+
+// newContract creates the TRC20 contract instance with opts applied.
+func newContract(tronClient lowlevel.ConnProvider, contractAddress *types.Address, opts []ManagerOption) (*smartcontract.Instance, error) {
+	var o managerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	contract, err := smartcontract.NewInstance(tronClient, contractAddress, append([]any{ERC20ABI}, o.instanceOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create smart contract instance for TRC20: %w", err)
+	}
+	return contract, nil
 }
 
 // NewManager constructs a TRC20 manager bound to the given token contract
@@ -58,25 +94,19 @@ type TRC20Manager struct {
 //	    // handle error
 //	}
 //
-//	trc20Mgr, err := trc20.NewManager(cli, tokenAddr)
+//	trc20Mgr, err := trc20.NewManager(cli, tokenAddr, trc20.WithDefaultFeeLimit(30_000_000))
 //	if err != nil {
 //	    // handle error
 //	}
-func NewManager(tronClient lowlevel.ConnProvider, contractAddress *types.Address) (*TRC20Manager, error) {
+func NewManager(tronClient lowlevel.ConnProvider, contractAddress *types.Address, opts ...ManagerOption) (*TRC20Manager, error) {
 	// Create a generic smart contract instance
-	contract, err := smartcontract.NewInstance(tronClient, contractAddress, ERC20ABI)
+	contract, err := newContract(tronClient, contractAddress, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create smart contract instance for TRC20: %w", err)
-	}
-
-	parsedABI, err := abi.JSON(strings.NewReader(ERC20ABI))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse TRC20 ABI: %w", err)
+		return nil, err
 	}
 
 	c := &TRC20Manager{
 		contract: contract,
-		trc20ABI: &parsedABI,
 	}
 
 	// Pre-fetch immutable properties using a non-cancellable context
@@ -99,6 +129,65 @@ func NewManager(tronClient lowlevel.ConnProvider, contractAddress *types.Address
 	return c, nil
 }
 
+// NewManagerWithRefProvider constructs a TRC20 manager that builds Transfer
+// and Approve transactions locally against refProvider instead of on a node.
+// decimals must be supplied since it cannot be fetched; the manager has no
+// connection, so calls that read contract state fail with lowlevel.ErrOffline.
+//
+// Locally built calls skip the node's pre-execution, so reverts are only
+// detected after broadcast. They carry the default fee limit (see
+// WithDefaultFeeLimit).
+func NewManagerWithRefProvider(refProvider types.RefBlockProvider, contractAddress *types.Address, decimals uint8, opts ...ManagerOption) (*TRC20Manager, error) {
+	if refProvider == nil {
+		return nil, fmt.Errorf("%w: reference block provider cannot be nil", types.ErrInvalidParameter)
+	}
+	contract, err := newContract(lowlevel.Offline, contractAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TRC20Manager{
+		contract:       contract,
+		cachedDecimals: decimals,
+		decimalsCached: true,
+		ref:            refProvider,
+	}, nil
+}
+
+// invoke creates an unsigned call to method, on the node or locally when a
+// reference block provider is configured.
+func (t *TRC20Manager) invoke(ctx context.Context, owner *types.Address, method string, params ...interface{}) (*api.TransactionExtention, error) {
+	if t.ref == nil {
+		return t.contract.Invoke(ctx, owner, 0, method, params...)
+	}
+	if owner == nil {
+		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
+	}
+	data, err := t.contract.Encode(method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode input for method %s: %v", types.ErrInvalidContract, method, err)
+	}
+	return types.BuildTransaction(ctx, t.ref, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    owner.Bytes(),
+		ContractAddress: t.contract.Address.Bytes(),
+		Data:            data,
+	}, t.contract.DefaultFeeLimit())
+}
+
+// WithRoundingMode sets how Transfer and Approve convert decimal amounts that
+// have more fractional digits than the token's decimals, and returns t. The
+// default, RoundExact, rejects such amounts. Configure the mode before
+// sharing the manager between goroutines.
+//
+// Example:
+//
+//	trc20Mgr.WithRoundingMode(trc20.RoundTruncate)
+//	txExt, err := trc20Mgr.Transfer(ctx, from, to, decimal.RequireFromString("1.2345678")) // sends 1.234567
+func (t *TRC20Manager) WithRoundingMode(mode RoundingMode) *TRC20Manager {
+	t.rounding = mode
+	return t
+}
+
 // ToWeiWithDecimals converts a user-facing decimal amount into on-chain units
 // using the provided decimals.
 func ToWeiWithDecimals(amount decimal.Decimal, decimals uint8) (*big.Int, error) {
@@ -137,14 +226,9 @@ func (t *TRC20Manager) Name(ctx context.Context) (string, error) {
 		return t.cachedName, nil
 	}
 
-	result, err := t.contract.Call(ctx, t.contract.Address, "name")
+	name, err := t.fetchString(ctx, "name")
 	if err != nil {
-		return "", fmt.Errorf("failed to call name method: %w", err)
-	}
-
-	name, ok := result.(string)
-	if !ok {
-		return "", fmt.Errorf("unexpected type for name value: %T", result)
+		return "", err
 	}
 	t.cachedName = name
 	return name, nil
@@ -176,14 +260,9 @@ func (t *TRC20Manager) Symbol(ctx context.Context) (string, error) {
 		return t.cachedSymbol, nil
 	}
 
-	result, err := t.contract.Call(ctx, t.contract.Address, "symbol")
+	symbol, err := t.fetchString(ctx, "symbol")
 	if err != nil {
-		return "", fmt.Errorf("failed to call symbol method: %w", err)
-	}
-
-	symbol, ok := result.(string)
-	if !ok {
-		return "", fmt.Errorf("unexpected type for symbol value: %T", result)
+		return "", err
 	}
 	t.cachedSymbol = symbol
 	return symbol, nil
@@ -216,20 +295,80 @@ func (t *TRC20Manager) Decimals(ctx context.Context) (uint8, error) {
 		return t.cachedDecimals, nil
 	}
 
+	decimalsResult, err := t.fetchDecimals(ctx)
+	if err != nil {
+		return 0, err
+	}
+	t.cachedDecimals = decimalsResult
+	t.decimalsCached = true
+	return decimalsResult, nil
+}
+
+// fetchString calls a string-returning metadata method without caching.
+func (t *TRC20Manager) fetchString(ctx context.Context, method string) (string, error) {
+	result, err := t.contract.Call(ctx, t.contract.Address, method)
+	if err != nil {
+		return "", fmt.Errorf("failed to call %s method: %w", method, err)
+	}
+
+	value, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected type for %s value: %T", method, result)
+	}
+	return value, nil
+}
+
+// fetchDecimals calls decimals() without caching.
+func (t *TRC20Manager) fetchDecimals(ctx context.Context) (uint8, error) {
 	result, err := t.contract.Call(ctx, t.contract.Address, "decimals")
 	if err != nil {
 		return 0, fmt.Errorf("failed to call decimals method: %w", err)
 	}
 
-	// The `go-ethereum/abi` library decodes `uint8` from `uint256` as `*big.Int`
-	// so we need to convert it.
-	decimalsResult, ok := result.(uint8)
+	decimals, ok := result.(uint8)
 	if !ok {
 		return 0, fmt.Errorf("unexpected type for uint8 result: %T", result)
 	}
-	t.cachedDecimals = decimalsResult
+	return decimals, nil
+}
+
+// RefreshMetadata re-reads name, symbol and decimals from the contract and
+// replaces the cached values, for tokens upgraded behind a proxy. The cache
+// is only updated if all three reads succeed.
+func (t *TRC20Manager) RefreshMetadata(ctx context.Context) error {
+	name, err := t.fetchString(ctx, "name")
+	if err != nil {
+		return err
+	}
+	symbol, err := t.fetchString(ctx, "symbol")
+	if err != nil {
+		return err
+	}
+	decimals, err := t.fetchDecimals(ctx)
+	if err != nil {
+		return err
+	}
+	t.SetMetadata(name, symbol, decimals)
+	return nil
+}
+
+// SetMetadata replaces the cached name, symbol and decimals without calling
+// the contract, for offline use or tokens whose metadata is already known.
+// Decimals above 18 are rejected later by amount conversions.
+func (t *TRC20Manager) SetMetadata(name, symbol string, decimals uint8) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cachedName = name
+	t.cachedSymbol = symbol
+	t.cachedDecimals = decimals
 	t.decimalsCached = true
-	return decimalsResult, nil
+}
+
+// MetadataLoaded reports whether name, symbol and decimals are all cached.
+func (t *TRC20Manager) MetadataLoaded() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.cachedName != "" && t.cachedSymbol != "" && t.decimalsCached
 }
 
 func (t *TRC20Manager) TotalSupply(ctx context.Context) (decimal.Decimal, error) {
@@ -297,7 +436,8 @@ func (t *TRC20Manager) BalanceOf(ctx context.Context, ownerAddress *types.Addres
 // This method creates a TRC20 token transfer transaction from one address to another.
 // The transaction is not signed or broadcast - use client.SignAndBroadcast to complete
 // the transfer. The amount should be specified as a decimal value (not in the smallest
-// token units).
+// token units). Amounts with more fractional digits than the token's decimals
+// are rejected unless a rounding mode is set with WithRoundingMode.
 //
 // Example:
 //
@@ -317,12 +457,12 @@ func (t *TRC20Manager) Transfer(ctx context.Context, fromAddress *types.Address,
 		return nil, fmt.Errorf("failed to get decimals for Transfer: %w", err)
 	}
 
-	rawAmount, err := toWei(amount, decimals)
+	rawAmount, err := t.scaleAmount(amount, decimals)
 	if err != nil {
-		return nil, fmt.Errorf("invalid amount: %w", err)
+		return nil, err
 	}
 
-	txExt, err := t.contract.Invoke(ctx, fromAddress, 0, "transfer", toAddress, rawAmount)
+	txExt, err := t.invoke(ctx, fromAddress, "transfer", toAddress, rawAmount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call transfer method: %w", err)
 	}
@@ -353,12 +493,49 @@ func (t *TRC20Manager) Approve(ctx context.Context, ownerAddress *types.Address,
 		return nil, fmt.Errorf("failed to get decimals for Approve: %w", err)
 	}
 
-	rawAmount, err := toWei(amount, decimals)
+	rawAmount, err := t.scaleAmount(amount, decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	txExt, err := t.invoke(ctx, ownerAddress, "approve", spenderAddress, rawAmount)
 	if err != nil {
-		return nil, fmt.Errorf("invalid amount: %w", err)
+		return nil, fmt.Errorf("failed to call approve method: %w", err)
 	}
 
-	txExt, err := t.contract.Invoke(ctx, ownerAddress, 0, "approve", spenderAddress, rawAmount)
+	return txExt, nil
+}
+
+// TransferRaw creates an unsigned transfer of amount in the token's smallest
+// on-chain unit, sent as-is. Unlike Transfer it performs no decimal scaling
+// and never reads the decimals cache, so exact integers from other systems
+// cannot be affected by rounding.
+//
+// Example:
+//
+//	txExt, err := trc20Mgr.TransferRaw(ctx, from, to, big.NewInt(1_500_000)) // 1.5 USDT
+func (t *TRC20Manager) TransferRaw(ctx context.Context, fromAddress *types.Address, toAddress *types.Address, amount *big.Int) (*api.TransactionExtention, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidAmount, "amount", amount); err != nil {
+		return nil, err
+	}
+
+	txExt, err := t.invoke(ctx, fromAddress, "transfer", toAddress, new(big.Int).Set(amount))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call transfer method: %w", err)
+	}
+
+	return txExt, nil
+}
+
+// ApproveRaw creates an unsigned approve of amount in the token's smallest
+// on-chain unit, sent as-is. Like TransferRaw it skips decimal scaling and
+// the decimals cache. A zero amount revokes the allowance.
+func (t *TRC20Manager) ApproveRaw(ctx context.Context, ownerAddress *types.Address, spenderAddress *types.Address, amount *big.Int) (*api.TransactionExtention, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidAmount, "amount", amount); err != nil {
+		return nil, err
+	}
+
+	txExt, err := t.invoke(ctx, ownerAddress, "approve", spenderAddress, new(big.Int).Set(amount))
 	if err != nil {
 		return nil, fmt.Errorf("failed to call approve method: %w", err)
 	}
@@ -366,6 +543,22 @@ func (t *TRC20Manager) Approve(ctx context.Context, ownerAddress *types.Address,
 	return txExt, nil
 }
 
+// scaleAmount converts amount to on-chain units using the manager's rounding
+// mode and checks that the result is a valid uint256 value.
+func (t *TRC20Manager) scaleAmount(amount decimal.Decimal, decimals uint8) (*big.Int, error) {
+	if amount.IsNegative() {
+		return nil, fmt.Errorf("%w: amount cannot be negative, got %s", types.ErrInvalidAmount, amount)
+	}
+	raw, err := ToWeiRounded(amount, decimals, t.rounding)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrInvalidAmount, err)
+	}
+	if raw.BitLen() > 256 {
+		return nil, fmt.Errorf("%w: %s scaled by %d decimals exceeds uint256", types.ErrInvalidAmount, amount, decimals)
+	}
+	return raw, nil
+}
+
 // Allowance retrieves the spender's allowance over the owner's tokens as a
 // decimal.Decimal.
 //
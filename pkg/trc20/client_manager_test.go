@@ -2,6 +2,7 @@ package trc20_test
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"net"
 	"testing"
@@ -174,3 +175,171 @@ func TestTRC20Manager_BalanceAllowanceTransferApprove(t *testing.T) {
 		t.Fatalf("Approve failed: %v", err)
 	}
 }
+
+var (
+	testToken = types.MustNewAddressFromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	testFrom  = types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	testTo    = types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+)
+
+// newOfflineManager returns a manager for testToken that builds transactions
+// locally against a fixed reference block.
+func newOfflineManager(t *testing.T, decimals uint8, opts ...trc20.ManagerOption) *trc20.TRC20Manager {
+	t.Helper()
+	ref := types.StaticRefBlock{
+		RefBlockBytes: []byte{0x12, 0x34},
+		RefBlockHash:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Expiration:    1700000060000,
+	}
+	mgr, err := trc20.NewManagerWithRefProvider(ref, testToken, decimals, opts...)
+	if err != nil {
+		t.Fatalf("NewManagerWithRefProvider: %v", err)
+	}
+	return mgr
+}
+
+// decodeTrigger returns the contract call of a built transaction and the
+// amount argument of its transfer or approve call.
+func decodeTrigger(t *testing.T, txExt *api.TransactionExtention) (*core.TriggerSmartContract, *big.Int) {
+	t.Helper()
+	var trigger core.TriggerSmartContract
+	if err := txExt.GetTransaction().GetRawData().GetContract()[0].GetParameter().UnmarshalTo(&trigger); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return &trigger, new(big.Int).SetBytes(trigger.GetData()[4+32:])
+}
+
+func TestTRC20Manager_WithRefProvider(t *testing.T) {
+	mgr := newOfflineManager(t, 6)
+	txExt, err := mgr.Transfer(context.Background(), testFrom, testTo, decimal.NewFromFloat(1.5))
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	trigger, amount := decodeTrigger(t, txExt)
+	if string(trigger.GetContractAddress()) != string(testToken.Bytes()) {
+		t.Fatalf("unexpected contract address")
+	}
+	if amount.Int64() != 1_500_000 {
+		t.Fatalf("unexpected amount %s", amount)
+	}
+	if got := txExt.GetTransaction().GetRawData().GetFeeLimit(); got != 150_000_000 {
+		t.Fatalf("unexpected default fee limit %d", got)
+	}
+
+	mgr = newOfflineManager(t, 6, trc20.WithDefaultFeeLimit(30_000_000))
+	txExt, err = mgr.Transfer(context.Background(), testFrom, testTo, decimal.NewFromFloat(1.5))
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if got := txExt.GetTransaction().GetRawData().GetFeeLimit(); got != 30_000_000 {
+		t.Fatalf("fee limit option not applied, got %d", got)
+	}
+}
+
+func TestTRC20Manager_WithRoundingMode(t *testing.T) {
+	mgr := newOfflineManager(t, 6)
+	amt := decimal.RequireFromString("1.2345678")
+	if _, err := mgr.Transfer(context.Background(), testFrom, testTo, amt); err == nil {
+		t.Fatalf("expected excess precision to be rejected by default")
+	}
+
+	txExt, err := mgr.WithRoundingMode(trc20.RoundTruncate).Transfer(context.Background(), testFrom, testTo, amt)
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if _, got := decodeTrigger(t, txExt); got.Int64() != 1_234_567 {
+		t.Fatalf("unexpected amount %s", got)
+	}
+}
+
+func TestTRC20Manager_TransferAmountBounds(t *testing.T) {
+	mgr := newOfflineManager(t, 18)
+	// 2^256 / 10^18 is about 1.16e59, so 1e60 tokens overflows once scaled.
+	huge := decimal.New(1, 60)
+	for _, amt := range []decimal.Decimal{huge, decimal.NewFromInt(-1), decimal.RequireFromString("0.0000000000000000001")} {
+		if _, err := mgr.Transfer(context.Background(), testFrom, testTo, amt); !errors.Is(err, types.ErrInvalidAmount) {
+			t.Fatalf("Transfer(%s): expected ErrInvalidAmount, got %v", amt, err)
+		}
+		if _, err := mgr.Approve(context.Background(), testFrom, testTo, amt); !errors.Is(err, types.ErrInvalidAmount) {
+			t.Fatalf("Approve(%s): expected ErrInvalidAmount, got %v", amt, err)
+		}
+	}
+}
+
+func TestTRC20Manager_TransferRaw(t *testing.T) {
+	// Decimals are irrelevant to the raw variants.
+	mgr := newOfflineManager(t, 18)
+	want, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	for _, build := range []func() (*api.TransactionExtention, error){
+		func() (*api.TransactionExtention, error) {
+			return mgr.TransferRaw(context.Background(), testFrom, testTo, want)
+		},
+		func() (*api.TransactionExtention, error) {
+			return mgr.ApproveRaw(context.Background(), testFrom, testTo, want)
+		},
+	} {
+		txExt, err := build()
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		if _, got := decodeTrigger(t, txExt); got.Cmp(want) != 0 {
+			t.Fatalf("amount %s, want %s", got, want)
+		}
+	}
+
+	if _, err := mgr.TransferRaw(context.Background(), testFrom, testTo, big.NewInt(-1)); err == nil {
+		t.Fatalf("expected error for negative amount")
+	}
+	if _, err := mgr.TransferRaw(context.Background(), testFrom, testTo, new(big.Int).Lsh(big.NewInt(1), 256)); err == nil {
+		t.Fatalf("expected error for amount above uint256")
+	}
+}
+
+func TestTRC20Manager_Metadata(t *testing.T) {
+	lis, _, cleanup := newTRC20BufServer(t, &trc20Server{})
+	t.Cleanup(cleanup)
+
+	c, err := client.NewClientWithDialer("passthrough:///bufnet", func(ctx context.Context, s string) (net.Conn, error) { return lis.DialContext(ctx) }, client.WithTimeout(500*time.Millisecond), client.WithPool(1, 1))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	defer c.Close()
+
+	m, err := trc20.NewManager(c, types.MustNewAddressFromBase58("TKCTfkQ8L9beavNu9iaGtCHFxrwNHUxfr2"))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	if !m.MetadataLoaded() {
+		t.Fatalf("expected metadata loaded after NewManager")
+	}
+
+	ctx := context.Background()
+	m.SetMetadata("Old", "OLD", 18)
+	if sym, _ := m.Symbol(ctx); sym != "OLD" {
+		t.Fatalf("SetMetadata not applied, symbol %q", sym)
+	}
+	if err := m.RefreshMetadata(ctx); err != nil {
+		t.Fatalf("RefreshMetadata: %v", err)
+	}
+	name, _ := m.Name(ctx)
+	sym, _ := m.Symbol(ctx)
+	dec, _ := m.Decimals(ctx)
+	if name != "TRONUSD" || sym != "USDT" || dec != 6 {
+		t.Fatalf("unexpected refreshed metadata %q %q %d", name, sym, dec)
+	}
+
+	offline, err := trc20.NewManagerWithRefProvider(types.StaticRefBlock{}, types.MustNewAddressFromBase58("TKCTfkQ8L9beavNu9iaGtCHFxrwNHUxfr2"), 6)
+	if err != nil {
+		t.Fatalf("NewManagerWithRefProvider: %v", err)
+	}
+	if offline.MetadataLoaded() {
+		t.Fatalf("expected metadata not loaded for offline manager")
+	}
+	if err := offline.RefreshMetadata(ctx); err == nil {
+		t.Fatalf("expected RefreshMetadata to fail offline")
+	}
+	offline.SetMetadata("Tether USD", "USDT", 6)
+	if !offline.MetadataLoaded() {
+		t.Fatalf("expected metadata loaded after SetMetadata")
+	}
+}
@@ -67,3 +67,35 @@ func TestFromWei_InvalidDecimals(t *testing.T) {
 		t.Fatalf("expected error for decimals > 18")
 	}
 }
+
+func TestToWeiRounded(t *testing.T) {
+	cases := []struct {
+		amount string
+		mode   RoundingMode
+		want   int64
+	}{
+		{"1.2345675", RoundTruncate, 1234567},
+		{"-1.2345679", RoundTruncate, -1234567},
+		{"1.2345675", RoundHalfUp, 1234568},
+		{"1.2345665", RoundHalfUp, 1234567},
+		{"1.2345665", RoundHalfEven, 1234566},
+		{"1.2345675", RoundHalfEven, 1234568},
+		{"1.5", RoundExact, 1500000},
+	}
+	for _, tc := range cases {
+		got, err := ToWeiRounded(decimal.RequireFromString(tc.amount), 6, tc.mode)
+		if err != nil {
+			t.Fatalf("%s %v: %v", tc.amount, tc.mode, err)
+		}
+		if got.Int64() != tc.want {
+			t.Fatalf("%s %v: got %s, want %d", tc.amount, tc.mode, got, tc.want)
+		}
+	}
+
+	if _, err := ToWeiRounded(decimal.RequireFromString("1.2345675"), 6, RoundExact); err == nil {
+		t.Fatalf("expected RoundExact to reject excess precision")
+	}
+	if _, err := ToWeiRounded(decimal.NewFromInt(1), 6, RoundingMode(99)); err == nil {
+		t.Fatalf("expected error for unknown rounding mode")
+	}
+}
@@ -25,6 +25,66 @@ func ToWei(amount decimal.Decimal, decimals uint8) (*big.Int, error) {
 	return amount.Shift(int32(decimals)).BigInt(), nil
 }
 
+// RoundingMode selects how amounts with more fractional digits than a
+// token's decimals are mapped to on-chain integer units.
+type RoundingMode int
+
+const (
+	// RoundExact rejects amounts that cannot be represented exactly. It is
+	// the default.
+	RoundExact RoundingMode = iota
+	// RoundTruncate drops excess digits, rounding toward zero.
+	RoundTruncate
+	// RoundHalfUp rounds to the nearest unit, halves away from zero.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest unit, halves to the even unit
+	// (banker's rounding).
+	RoundHalfEven
+)
+
+// String returns the name of the rounding mode.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundExact:
+		return "exact"
+	case RoundTruncate:
+		return "truncate"
+	case RoundHalfUp:
+		return "half-up"
+	case RoundHalfEven:
+		return "half-even"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+// ToWeiRounded converts amount to on-chain integer units like ToWei, but
+// fits amounts with too many fractional digits to decimals using mode.
+// With RoundExact it behaves exactly like ToWei.
+//
+// Example:
+//
+//	raw, _ := trc20.ToWeiRounded(decimal.RequireFromString("1.2345675"), 6, trc20.RoundHalfEven) // 1234568
+func ToWeiRounded(amount decimal.Decimal, decimals uint8, mode RoundingMode) (*big.Int, error) {
+	if decimals > 18 {
+		return nil, fmt.Errorf("unsupported decimals value: %d", decimals)
+	}
+	places := int32(decimals)
+	switch mode {
+	case RoundExact:
+		return ToWei(amount, decimals)
+	case RoundTruncate:
+		amount = amount.Truncate(places)
+	case RoundHalfUp:
+		amount = amount.Round(places)
+	case RoundHalfEven:
+		amount = amount.RoundBank(places)
+	default:
+		return nil, fmt.Errorf("unsupported rounding mode: %v", mode)
+	}
+	return amount.Shift(places).BigInt(), nil
+}
+
 // FromWei converts a raw on-chain integer value to a user-facing decimal using
 // the provided decimals. Decimals must be ≤ 18.
 func FromWei(value *big.Int, decimals uint8) (decimal.Decimal, error) {
@@ -37,12 +97,6 @@ func FromWei(value *big.Int, decimals uint8) (decimal.Decimal, error) {
 	return decimal.NewFromBigInt(value, -int32(decimals)), nil
 }
 
-// toWei is the internal helper kept for backward-compatibility.
-// It now defers to ToWei for validation and conversion.
-func toWei(amount decimal.Decimal, decimals uint8) (*big.Int, error) {
-	return ToWei(amount, decimals)
-}
-
 // fromWei is the internal helper kept for backward-compatibility.
 // It now defers to FromWei for validation and conversion.
 func fromWei(rawAmount *big.Int, decimals uint8) (decimal.Decimal, error) {
@@ -0,0 +1,27 @@
+// Package trc721 provides a typed interface for TRC721 (non-fungible token)
+// contracts.
+//
+// TRC721Manager wraps a generic smart contract instance with methods for the
+// core TRC721 functions (ownership, balances, approvals and transfers) and
+// the metadata extension (name, symbol, tokenURI). Name and symbol are
+// cached after the first read; write methods return unsigned transactions
+// to be signed and broadcast with client.SignAndBroadcast.
+//
+// # Quick Start
+//
+//	nft, err := trc721.NewManager(cli, collectionAddr)
+//	if err != nil { /* handle */ }
+//
+//	owner, _ := nft.OwnerOf(ctx, big.NewInt(1))
+//	uri, _ := nft.TokenURI(ctx, big.NewInt(1))
+//
+//	txExt, err := nft.SafeTransferFrom(ctx, from, to, big.NewInt(1), nil)
+//	if err != nil { /* handle */ }
+//	res, err := cli.SignAndBroadcast(ctx, txExt, client.DefaultBroadcastOptions(), signer)
+//
+// # Events
+//
+// TRC721 and TRC20 Transfer events share a signature, so the global
+// eventdecoder registry decodes them as TRC20 transfers. DecodeTransferLog
+// decodes the TRC721 form, whose token id is an indexed topic.
+package trc721
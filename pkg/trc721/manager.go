@@ -0,0 +1,323 @@
+package trc721
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client/lowlevel"
+	"github.com/kslamph/tronlib/pkg/smartcontract"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/kslamph/tronlib/pkg/utils"
+)
+
+// transferTopic is keccak256("Transfer(address,address,uint256)"), shared
+// with TRC20; TRC721 logs are told apart by their indexed token id.
+var transferTopic = crypto.Keccak256([]byte("Transfer(address,address,uint256)"))
+
+// TRC721Manager provides a typed interface for a TRC721 (NFT) contract.
+//
+// It wraps a smart contract instance with convenience methods for the core
+// TRC721 functions and the metadata extension, and caches the collection
+// name and symbol after the first successful read.
+type TRC721Manager struct {
+	contract *smartcontract.Instance
+
+	// Cached collection metadata
+	cachedName   string
+	cachedSymbol string
+	mu           sync.RWMutex
+}
+
+// NewManager constructs a TRC721 manager bound to the given NFT contract
+// address using the provided TRON connection provider.
+//
+// Unlike trc20.NewManager it does not prefetch metadata, since name and
+// symbol belong to the optional metadata extension; they are read and cached
+// on first use.
+//
+// Example:
+//
+//	nft, err := trc721.NewManager(cli, collectionAddr)
+//	if err != nil {
+//	    // handle error
+//	}
+//	owner, err := nft.OwnerOf(ctx, big.NewInt(1))
+func NewManager(tronClient lowlevel.ConnProvider, contractAddress *types.Address) (*TRC721Manager, error) {
+	contract, err := smartcontract.NewInstance(tronClient, contractAddress, TRC721ABI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create smart contract instance for TRC721: %w", err)
+	}
+	return &TRC721Manager{contract: contract}, nil
+}
+
+// Address returns the contract address the manager is bound to.
+func (m *TRC721Manager) Address() *types.Address {
+	return m.contract.Address
+}
+
+// Name returns the collection name, fetching and caching it on first call.
+func (m *TRC721Manager) Name(ctx context.Context) (string, error) {
+	return m.cachedString(ctx, "name", &m.cachedName)
+}
+
+// Symbol returns the collection symbol, fetching and caching it on first
+// call.
+func (m *TRC721Manager) Symbol(ctx context.Context) (string, error) {
+	return m.cachedString(ctx, "symbol", &m.cachedSymbol)
+}
+
+// cachedString returns *field, filling it from a string-returning method on
+// first use.
+func (m *TRC721Manager) cachedString(ctx context.Context, method string, field *string) (string, error) {
+	m.mu.RLock()
+	if *field != "" {
+		defer m.mu.RUnlock()
+		return *field, nil
+	}
+	m.mu.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if *field != "" { // Double-check locking
+		return *field, nil
+	}
+
+	value, err := m.callString(ctx, method)
+	if err != nil {
+		return "", err
+	}
+	*field = value
+	return value, nil
+}
+
+// TokenURI returns the metadata URI of tokenID. It is not cached, since
+// collections may reveal or update metadata.
+func (m *TRC721Manager) TokenURI(ctx context.Context, tokenID *big.Int) (string, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", tokenID); err != nil {
+		return "", err
+	}
+	return m.callString(ctx, "tokenURI", tokenID)
+}
+
+// BalanceOf returns the number of tokens held by owner.
+func (m *TRC721Manager) BalanceOf(ctx context.Context, owner *types.Address) (*big.Int, error) {
+	if owner == nil {
+		return nil, fmt.Errorf("%w: owner address cannot be nil", types.ErrInvalidAddress)
+	}
+	result, err := m.contract.Call(ctx, owner, "balanceOf", owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call balanceOf method: %w", err)
+	}
+	balance, ok := result.(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for balanceOf value: %T", result)
+	}
+	return balance, nil
+}
+
+// OwnerOf returns the owner of tokenID. The call reverts, and an error is
+// returned, for tokens that do not exist.
+//
+// Example:
+//
+//	owner, err := nft.OwnerOf(ctx, big.NewInt(42))
+//	if err != nil {
+//	    // nonexistent token or network error
+//	}
+func (m *TRC721Manager) OwnerOf(ctx context.Context, tokenID *big.Int) (*types.Address, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", tokenID); err != nil {
+		return nil, err
+	}
+	return m.callAddress(ctx, "ownerOf", tokenID)
+}
+
+// GetApproved returns the address approved to transfer tokenID, or the zero
+// address when none is.
+func (m *TRC721Manager) GetApproved(ctx context.Context, tokenID *big.Int) (*types.Address, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", tokenID); err != nil {
+		return nil, err
+	}
+	return m.callAddress(ctx, "getApproved", tokenID)
+}
+
+// IsApprovedForAll reports whether operator may transfer all of owner's
+// tokens.
+func (m *TRC721Manager) IsApprovedForAll(ctx context.Context, owner, operator *types.Address) (bool, error) {
+	if owner == nil || operator == nil {
+		return false, fmt.Errorf("%w: owner and operator addresses cannot be nil", types.ErrInvalidAddress)
+	}
+	result, err := m.contract.Call(ctx, owner, "isApprovedForAll", owner, operator)
+	if err != nil {
+		return false, fmt.Errorf("failed to call isApprovedForAll method: %w", err)
+	}
+	approved, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected type for isApprovedForAll value: %T", result)
+	}
+	return approved, nil
+}
+
+// Transfer creates an unsigned transferFrom of tokenID from from to to, sent
+// by from. It does not check that a contract recipient can receive TRC721
+// tokens; use SafeTransferFrom for that.
+//
+// Example:
+//
+//	txExt, err := nft.Transfer(ctx, from, to, big.NewInt(42))
+//	if err != nil {
+//	    // handle error
+//	}
+//	result, err := cli.SignAndBroadcast(ctx, txExt, client.DefaultBroadcastOptions(), signer)
+func (m *TRC721Manager) Transfer(ctx context.Context, from, to *types.Address, tokenID *big.Int) (*api.TransactionExtention, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", tokenID); err != nil {
+		return nil, err
+	}
+	if to == nil {
+		return nil, fmt.Errorf("%w: recipient address cannot be nil", types.ErrInvalidAddress)
+	}
+	txExt, err := m.contract.Invoke(ctx, from, 0, "transferFrom", from, to, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call transferFrom method: %w", err)
+	}
+	return txExt, nil
+}
+
+// SafeTransferFrom creates an unsigned safeTransferFrom of tokenID from from
+// to to, sent by from. A contract recipient must implement onTRC721Received
+// or the transfer reverts. data is passed to the recipient; nil selects the
+// three-argument overload.
+func (m *TRC721Manager) SafeTransferFrom(ctx context.Context, from, to *types.Address, tokenID *big.Int, data []byte) (*api.TransactionExtention, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", tokenID); err != nil {
+		return nil, err
+	}
+	if to == nil {
+		return nil, fmt.Errorf("%w: recipient address cannot be nil", types.ErrInvalidAddress)
+	}
+
+	var (
+		txExt *api.TransactionExtention
+		err   error
+	)
+	if data == nil {
+		txExt, err = m.contract.Invoke(ctx, from, 0, "safeTransferFrom(address,address,uint256)", from, to, tokenID)
+	} else {
+		txExt, err = m.contract.Invoke(ctx, from, 0, "safeTransferFrom(address,address,uint256,bytes)", from, to, tokenID, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to call safeTransferFrom method: %w", err)
+	}
+	return txExt, nil
+}
+
+// Approve creates an unsigned approval for to to transfer tokenID, sent by
+// owner. Approving the zero address clears the approval.
+func (m *TRC721Manager) Approve(ctx context.Context, owner, to *types.Address, tokenID *big.Int) (*api.TransactionExtention, error) {
+	if err := utils.ValidateUint256(types.ErrInvalidParameter, "token id", tokenID); err != nil {
+		return nil, err
+	}
+	if to == nil {
+		return nil, fmt.Errorf("%w: approved address cannot be nil", types.ErrInvalidAddress)
+	}
+	txExt, err := m.contract.Invoke(ctx, owner, 0, "approve", to, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call approve method: %w", err)
+	}
+	return txExt, nil
+}
+
+// SetApprovalForAll creates an unsigned transaction, sent by owner, that
+// grants or revokes operator's right to transfer all of owner's tokens.
+func (m *TRC721Manager) SetApprovalForAll(ctx context.Context, owner, operator *types.Address, approved bool) (*api.TransactionExtention, error) {
+	if operator == nil {
+		return nil, fmt.Errorf("%w: operator address cannot be nil", types.ErrInvalidAddress)
+	}
+	txExt, err := m.contract.Invoke(ctx, owner, 0, "setApprovalForAll", operator, approved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call setApprovalForAll method: %w", err)
+	}
+	return txExt, nil
+}
+
+// callString calls a string-returning method.
+func (m *TRC721Manager) callString(ctx context.Context, method string, params ...interface{}) (string, error) {
+	result, err := m.contract.Call(ctx, m.contract.Address, method, params...)
+	if err != nil {
+		return "", fmt.Errorf("failed to call %s method: %w", method, err)
+	}
+	value, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected type for %s value: %T", method, result)
+	}
+	return value, nil
+}
+
+// callAddress calls an address-returning method.
+func (m *TRC721Manager) callAddress(ctx context.Context, method string, params ...interface{}) (*types.Address, error) {
+	result, err := m.contract.Call(ctx, m.contract.Address, method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s method: %w", method, err)
+	}
+	addr, ok := result.(*types.Address)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for %s value: %T", method, result)
+	}
+	return addr, nil
+}
+
+// TransferEvent is a decoded TRC721 Transfer log. From is the zero address
+// for mints and To is the zero address for burns.
+type TransferEvent struct {
+	Contract *types.Address
+	From     *types.Address
+	To       *types.Address
+	TokenID  *big.Int
+}
+
+// DecodeTransferLog decodes a TRC721 Transfer(address,address,uint256) log,
+// whose token id is the third indexed topic. TRC20 Transfer logs share the
+// signature but carry the amount in data, and are rejected with an error
+// wrapping types.ErrInvalidParameter.
+//
+// Example:
+//
+//	for _, lg := range receipt.Logs {
+//	    ev, err := trc721.DecodeTransferLog(lg)
+//	    if err != nil {
+//	        continue // not a TRC721 transfer
+//	    }
+//	    fmt.Printf("token %s: %s -> %s\n", ev.TokenID, ev.From, ev.To)
+//	}
+func DecodeTransferLog(lg *core.TransactionInfo_Log) (*TransferEvent, error) {
+	topics := lg.GetTopics()
+	if len(topics) != 4 || !bytes.Equal(topics[0], transferTopic) {
+		return nil, fmt.Errorf("%w: not a TRC721 Transfer log", types.ErrInvalidParameter)
+	}
+	for _, topic := range topics[1:] {
+		if len(topic) != 32 {
+			return nil, fmt.Errorf("%w: Transfer topic must be 32 bytes, got %d", types.ErrInvalidParameter, len(topic))
+		}
+	}
+	from, err := types.NewAddressFromBytes(topics[1][12:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid from address: %v", types.ErrInvalidParameter, err)
+	}
+	to, err := types.NewAddressFromBytes(topics[2][12:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid to address: %v", types.ErrInvalidParameter, err)
+	}
+	ev := &TransferEvent{
+		From:    from,
+		To:      to,
+		TokenID: new(big.Int).SetBytes(topics[3]),
+	}
+	if len(lg.GetAddress()) > 0 {
+		ev.Contract, _ = types.NewAddressFromBytes(lg.GetAddress())
+	}
+	return ev, nil
+}
@@ -0,0 +1,152 @@
+package trc721_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/client"
+	"github.com/kslamph/tronlib/pkg/client/clienttest"
+	"github.com/kslamph/tronlib/pkg/trc721"
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+var (
+	collection = types.MustNewAddressFromBase58("TKCTfkQ8L9beavNu9iaGtCHFxrwNHUxfr2")
+	holder     = types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	recipient  = types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+)
+
+type nftServer struct {
+	clienttest.TriggerRecorder
+	t     *testing.T
+	calls map[string]int
+}
+
+func (s *nftServer) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	var out []byte
+	sel := in.Data[:4]
+	switch {
+	case bytes.Equal(sel, clienttest.Selector("name()")):
+		s.calls["name"]++
+		out = clienttest.Pack(s.t, []string{"string"}, "Punks")
+	case bytes.Equal(sel, clienttest.Selector("symbol()")):
+		out = clienttest.Pack(s.t, []string{"string"}, "PNK")
+	case bytes.Equal(sel, clienttest.Selector("tokenURI(uint256)")):
+		out = clienttest.Pack(s.t, []string{"string"}, "ipfs://token/7")
+	case bytes.Equal(sel, clienttest.Selector("balanceOf(address)")):
+		out = clienttest.Pack(s.t, []string{"uint256"}, big.NewInt(3))
+	case bytes.Equal(sel, clienttest.Selector("ownerOf(uint256)")), bytes.Equal(sel, clienttest.Selector("getApproved(uint256)")):
+		out = clienttest.Pack(s.t, []string{"address"}, eCommon.BytesToAddress(holder.BytesEVM()))
+	case bytes.Equal(sel, clienttest.Selector("isApprovedForAll(address,address)")):
+		out = clienttest.Pack(s.t, []string{"bool"}, true)
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{out}}, nil
+}
+
+func newManager(t *testing.T) (*trc721.TRC721Manager, *nftServer) {
+	t.Helper()
+	impl := &nftServer{t: t, calls: map[string]int{}}
+	c := clienttest.NewClient(t, impl, client.WithTimeout(time.Second), client.WithPool(1, 1))
+
+	m, err := trc721.NewManager(c, collection)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return m, impl
+}
+
+func TestTRC721Manager_Reads(t *testing.T) {
+	m, srv := newManager(t)
+	ctx := context.Background()
+	token := big.NewInt(7)
+
+	for i := 0; i < 2; i++ {
+		name, err := m.Name(ctx)
+		if err != nil || name != "Punks" {
+			t.Fatalf("Name = %q, %v", name, err)
+		}
+	}
+	if srv.calls["name"] != 1 {
+		t.Fatalf("name fetched %d times, want 1", srv.calls["name"])
+	}
+	if sym, err := m.Symbol(ctx); err != nil || sym != "PNK" {
+		t.Fatalf("Symbol = %q, %v", sym, err)
+	}
+	if uri, err := m.TokenURI(ctx, token); err != nil || uri != "ipfs://token/7" {
+		t.Fatalf("TokenURI = %q, %v", uri, err)
+	}
+	if bal, err := m.BalanceOf(ctx, holder); err != nil || bal.Int64() != 3 {
+		t.Fatalf("BalanceOf = %v, %v", bal, err)
+	}
+	if owner, err := m.OwnerOf(ctx, token); err != nil || owner.String() != holder.String() {
+		t.Fatalf("OwnerOf = %v, %v", owner, err)
+	}
+	if approved, err := m.GetApproved(ctx, token); err != nil || approved.String() != holder.String() {
+		t.Fatalf("GetApproved = %v, %v", approved, err)
+	}
+	if all, err := m.IsApprovedForAll(ctx, holder, recipient); err != nil || !all {
+		t.Fatalf("IsApprovedForAll = %v, %v", all, err)
+	}
+	if _, err := m.OwnerOf(ctx, big.NewInt(-1)); !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for negative token id, got %v", err)
+	}
+}
+
+func TestTRC721Manager_Writes(t *testing.T) {
+	m, srv := newManager(t)
+	ctx := context.Background()
+	token := big.NewInt(7)
+
+	clienttest.RunWriteCases(t, &srv.TriggerRecorder, []clienttest.WriteCase{
+		{Name: "Transfer", Call: func() (*api.TransactionExtention, error) { return m.Transfer(ctx, holder, recipient, token) }, Sig: "transferFrom(address,address,uint256)"},
+		{Name: "SafeTransferFrom", Call: func() (*api.TransactionExtention, error) {
+			return m.SafeTransferFrom(ctx, holder, recipient, token, nil)
+		}, Sig: "safeTransferFrom(address,address,uint256)"},
+		{Name: "SafeTransferFromData", Call: func() (*api.TransactionExtention, error) {
+			return m.SafeTransferFrom(ctx, holder, recipient, token, []byte("hi"))
+		}, Sig: "safeTransferFrom(address,address,uint256,bytes)"},
+		{Name: "Approve", Call: func() (*api.TransactionExtention, error) { return m.Approve(ctx, holder, recipient, token) }, Sig: "approve(address,uint256)"},
+		{Name: "SetApprovalForAll", Call: func() (*api.TransactionExtention, error) { return m.SetApprovalForAll(ctx, holder, recipient, true) }, Sig: "setApprovalForAll(address,bool)"},
+	})
+
+	if _, err := m.Transfer(ctx, holder, nil, token); !errors.Is(err, types.ErrInvalidAddress) {
+		t.Fatalf("expected ErrInvalidAddress for nil recipient, got %v", err)
+	}
+}
+
+func TestDecodeTransferLog(t *testing.T) {
+	word := func(b []byte) []byte { return eCommon.LeftPadBytes(b, 32) }
+	topic0 := crypto.Keccak256([]byte("Transfer(address,address,uint256)"))
+
+	ev, err := trc721.DecodeTransferLog(&core.TransactionInfo_Log{
+		Address: collection.BytesEVM(),
+		Topics:  [][]byte{topic0, word(holder.BytesEVM()), word(recipient.BytesEVM()), word([]byte{0x01, 0x00})},
+	})
+	if err != nil {
+		t.Fatalf("DecodeTransferLog: %v", err)
+	}
+	if ev.From.String() != holder.String() || ev.To.String() != recipient.String() || ev.TokenID.Int64() != 256 {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+	if ev.Contract.String() != collection.String() {
+		t.Fatalf("unexpected contract %s", ev.Contract)
+	}
+
+	// TRC20 Transfer: amount in data, only three topics
+	_, err = trc721.DecodeTransferLog(&core.TransactionInfo_Log{
+		Topics: [][]byte{topic0, word(holder.BytesEVM()), word(recipient.BytesEVM())},
+		Data:   word([]byte{0x01}),
+	})
+	if !errors.Is(err, types.ErrInvalidParameter) {
+		t.Fatalf("expected ErrInvalidParameter for TRC20 log, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AddressBook maps human-readable aliases to addresses, for tools that
+// accept either an alias or an address from the user. It is safe for
+// concurrent use. The zero value is an empty book ready to use.
+//
+// An address book is stored as a JSON object from alias to Base58 address:
+//
+//	{"treasury": "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb"}
+type AddressBook struct {
+	mu      sync.RWMutex
+	entries map[string]*Address
+}
+
+// NewAddressBook returns an empty address book.
+func NewAddressBook() *AddressBook {
+	return &AddressBook{entries: make(map[string]*Address)}
+}
+
+// Register adds alias for addr, replacing any previous address for the same
+// alias. Aliases are trimmed and must not themselves be valid addresses, so
+// Resolve is never ambiguous.
+func (b *AddressBook) Register(alias string, addr *Address) error {
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return fmt.Errorf("%w: alias cannot be empty", ErrInvalidParameter)
+	}
+	if addr == nil {
+		return fmt.Errorf("%w: address for alias %q cannot be nil", ErrInvalidAddress, alias)
+	}
+	if _, err := NewAddress(alias); err == nil {
+		return fmt.Errorf("%w: alias %q is itself an address", ErrInvalidParameter, alias)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.entries == nil {
+		b.entries = make(map[string]*Address)
+	}
+	b.entries[alias] = addr
+	return nil
+}
+
+// Remove deletes alias and reports whether it was registered.
+func (b *AddressBook) Remove(alias string) bool {
+	alias = strings.TrimSpace(alias)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.entries[alias]
+	delete(b.entries, alias)
+	return ok
+}
+
+// Resolve returns the address for aliasOrAddr. Input that parses as a Base58
+// or hex address is returned as that address; anything else is looked up as
+// an alias. Unknown aliases return an error wrapping ErrNotFound.
+//
+// Example:
+//
+//	book, err := types.LoadAddressBook("addresses.json")
+//	if err != nil {
+//	    // handle error
+//	}
+//	to, err := book.Resolve(os.Args[1]) // "treasury" or "T..."
+func (b *AddressBook) Resolve(aliasOrAddr string) (*Address, error) {
+	s := strings.TrimSpace(aliasOrAddr)
+	if addr, err := NewAddress(s); err == nil {
+		return addr, nil
+	}
+	b.mu.RLock()
+	addr, ok := b.entries[s]
+	b.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is neither a valid address nor a known alias", ErrNotFound, s)
+	}
+	return addr, nil
+}
+
+// Aliases returns the registered aliases in sorted order.
+func (b *AddressBook) Aliases() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]string, 0, len(b.entries))
+	for alias := range b.entries {
+		out = append(out, alias)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// MarshalJSON encodes the book as an object from alias to Base58 address.
+func (b *AddressBook) MarshalJSON() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	m := make(map[string]string, len(b.entries))
+	for alias, addr := range b.entries {
+		m[alias] = addr.String()
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON replaces the book's entries with those in data, an object
+// from alias to Base58 or hex address. No entries change if any is invalid.
+func (b *AddressBook) UnmarshalJSON(data []byte) error {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("%w: invalid address book JSON: %v", ErrInvalidParameter, err)
+	}
+	staged := &AddressBook{entries: make(map[string]*Address, len(m))}
+	for alias, s := range m {
+		addr, err := NewAddress(s)
+		if err != nil {
+			return fmt.Errorf("%w: alias %q: %v", ErrInvalidAddress, alias, err)
+		}
+		if err := staged.Register(alias, addr); err != nil {
+			return err
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = staged.entries
+	return nil
+}
+
+// LoadAddressBook reads an address book from a JSON file written by Save.
+func LoadAddressBook(path string) (*AddressBook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+	b := NewAddressBook()
+	if err := b.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Save writes the book to path as indented JSON, replacing the file.
+func (b *AddressBook) Save(path string) error {
+	data, err := b.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write address book: %w", err)
+	}
+	return nil
+}
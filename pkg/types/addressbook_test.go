@@ -0,0 +1,55 @@
+package types
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressBook(t *testing.T) {
+	treasury := MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	hot := MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+
+	var book AddressBook
+	require.NoError(t, book.Register("treasury", treasury))
+	require.NoError(t, book.Register(" hot ", hot))
+
+	addr, err := book.Resolve("treasury")
+	require.NoError(t, err)
+	assert.True(t, addr.Equal(treasury))
+	addr, err = book.Resolve("hot\n")
+	require.NoError(t, err)
+	assert.True(t, addr.Equal(hot))
+
+	// Addresses resolve to themselves, even when not registered
+	other := "TKCTfkQ8L9beavNu9iaGtCHFxrwNHUxfr2"
+	addr, err = book.Resolve(other)
+	require.NoError(t, err)
+	assert.Equal(t, other, addr.String())
+
+	_, err = book.Resolve("cold")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(book.Register("", hot), ErrInvalidParameter))
+	assert.True(t, errors.Is(book.Register("cold", nil), ErrInvalidAddress))
+	assert.True(t, errors.Is(book.Register(other, hot), ErrInvalidParameter))
+	assert.Equal(t, []string{"hot", "treasury"}, book.Aliases())
+
+	path := filepath.Join(t.TempDir(), "book.json")
+	require.NoError(t, book.Save(path))
+	loaded, err := LoadAddressBook(path)
+	require.NoError(t, err)
+	assert.Equal(t, book.Aliases(), loaded.Aliases())
+	addr, err = loaded.Resolve("treasury")
+	require.NoError(t, err)
+	assert.True(t, addr.Equal(treasury))
+
+	assert.True(t, loaded.Remove("hot"))
+	assert.False(t, loaded.Remove("hot"))
+
+	err = loaded.UnmarshalJSON([]byte(`{"bad": "Tnope"}`))
+	assert.True(t, errors.Is(err, ErrInvalidAddress))
+	assert.Equal(t, []string{"treasury"}, loaded.Aliases(), "failed load must not change entries")
+}
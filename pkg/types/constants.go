@@ -21,6 +21,8 @@ const (
 	DefaultFeeLimit           = 1000000 // 1 TRX in SUN
 	DefaultTransactionTimeout = 30 * time.Second
 	DefaultExpiration         = 10 * time.Minute
+	MaxExpiration             = 24 * time.Hour // furthest past its reference block a transaction may expire
+	MaxTransactionSize        = 500 * 1024     // node-enforced limit on a serialized transaction, in bytes
 
 	// Energy constants
 	DefaultEnergyLimit = 10000000
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// NormalizeAddress parses an address pasted by a user. It strips surrounding
+// whitespace, zero-width characters and quotes, then accepts the same Base58
+// and hex forms as NewAddress. A Base58 address with one stray character at
+// either end is also accepted when removing it yields a valid checksum.
+// The returned error wraps ErrInvalidAddress.
+//
+// Example:
+//
+//	addr, err := types.NormalizeAddress("  TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb\n")
+//	if err != nil {
+//	    // still invalid; see SuggestAddress
+//	}
+func NormalizeAddress(s string) (*Address, error) {
+	s = strings.TrimFunc(s, isAddressPadding)
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty address", ErrInvalidAddress)
+	}
+	if ValidateBase58Checksum(s) == nil || IsValidHex(s) {
+		return NewAddress(s)
+	}
+	if len(s) == AddressBase58Length+1 {
+		for _, candidate := range []string{s[1:], s[:len(s)-1]} {
+			if ValidateBase58Checksum(candidate) == nil {
+				return NewAddressFromBase58(candidate)
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %q is not a valid TRON address", ErrInvalidAddress, s)
+}
+
+// isAddressPadding reports whether r commonly surrounds a copied address.
+func isAddressPadding(r rune) bool {
+	switch r {
+	case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff', '"', '\'', '`':
+		return true
+	}
+	return unicode.IsSpace(r)
+}
+
+// SuggestAddress returns the valid Base58 addresses within one typo of s: a
+// single wrong character (including wrong case), two swapped neighbours, or
+// one character missing or added. It returns nil when s is already valid or
+// nothing matches. The 4-byte checksum makes accidental matches unlikely, but
+// more than one candidate is possible, so present them for confirmation
+// rather than using one automatically.
+//
+// Example:
+//
+//	if _, err := types.NormalizeAddress(input); err != nil {
+//	    for _, addr := range types.SuggestAddress(input) {
+//	        fmt.Println("did you mean", addr)
+//	    }
+//	}
+func SuggestAddress(s string) []*Address {
+	s = strings.TrimFunc(s, isAddressPadding)
+	if ValidateBase58Checksum(s) == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var out []*Address
+	try := func(candidate string) {
+		if seen[candidate] || ValidateBase58Checksum(candidate) != nil {
+			return
+		}
+		seen[candidate] = true
+		if addr, err := NewAddressFromBase58(candidate); err == nil {
+			out = append(out, addr)
+		}
+	}
+
+	b := []byte(s)
+	switch len(b) {
+	case AddressBase58Length:
+		// Substitution, which also covers a wrong-case character
+		for i := range b {
+			orig := b[i]
+			for j := 0; j < len(base58Alphabet); j++ {
+				if base58Alphabet[j] == orig {
+					continue
+				}
+				b[i] = base58Alphabet[j]
+				try(string(b))
+			}
+			b[i] = orig
+		}
+		// Transposition of neighbours
+		for i := 0; i+1 < len(b); i++ {
+			if b[i] == b[i+1] {
+				continue
+			}
+			b[i], b[i+1] = b[i+1], b[i]
+			try(string(b))
+			b[i], b[i+1] = b[i+1], b[i]
+		}
+	case AddressBase58Length - 1:
+		// Missing character
+		for i := 0; i <= len(b); i++ {
+			for j := 0; j < len(base58Alphabet); j++ {
+				try(s[:i] + base58Alphabet[j:j+1] + s[i:])
+			}
+		}
+	case AddressBase58Length + 1:
+		// Extra character
+		for i := range b {
+			try(s[:i] + s[i+1:])
+		}
+	}
+	return out
+}
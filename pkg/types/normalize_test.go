@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	const valid = "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb"
+
+	for _, in := range []string{
+		valid,
+		"  " + valid + "\n",
+		"\u200b\"" + valid + "\"",
+		valid + ".",
+		"x" + valid,
+	} {
+		addr, err := NormalizeAddress(in)
+		require.NoError(t, err, "input %q", in)
+		assert.Equal(t, valid, addr.String())
+	}
+
+	addr, err := NormalizeAddress(" 0x41" + MustNewAddressFromBase58(valid).Hex()[2:] + " ")
+	require.NoError(t, err)
+	assert.Equal(t, valid, addr.String())
+
+	for _, in := range []string{"", "   ", "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwc", "hello"} {
+		_, err := NormalizeAddress(in)
+		assert.ErrorIs(t, err, ErrInvalidAddress, "input %q", in)
+	}
+}
+
+func TestSuggestAddress(t *testing.T) {
+	const valid = "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb"
+
+	contains := func(addrs []*Address) bool {
+		for _, a := range addrs {
+			if a.String() == valid {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.Nil(t, SuggestAddress(valid))
+	assert.True(t, contains(SuggestAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwB")), "wrong case")
+	assert.True(t, contains(SuggestAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5wjb")), "transposition")
+	assert.True(t, contains(SuggestAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jw")), "missing character")
+	assert.True(t, contains(SuggestAddress("TWd4WrZ9wn84f5x1hZhhL4DHvk738ns5jwb")), "extra character")
+	assert.Empty(t, SuggestAddress("not an address"))
+}
@@ -0,0 +1,181 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kslamph/tronlib/pb/core"
+)
+
+// OperationMaskSize is the length in bytes of an active permission's
+// operation mask: one bit per contract type, 256 in total.
+const OperationMaskSize = 32
+
+// NewOperationMask builds the operation mask of an active permission that
+// allows exactly contractTypes. Bit n (byte n/8, bit n%8) enables contract
+// type n, matching the layout the node checks. Types outside 0..255 are
+// ignored.
+//
+// Example:
+//
+//	ops := types.NewOperationMask(
+//	    core.Transaction_Contract_TransferContract,
+//	    core.Transaction_Contract_TriggerSmartContract,
+//	)
+func NewOperationMask(contractTypes ...core.Transaction_Contract_ContractType) []byte {
+	mask := make([]byte, OperationMaskSize)
+	for _, ct := range contractTypes {
+		if ct < 0 || int(ct) >= OperationMaskSize*8 {
+			continue
+		}
+		mask[ct/8] |= 1 << (ct % 8)
+	}
+	return mask
+}
+
+// DecodeOperationMask returns the contract types enabled in mask, in
+// ascending order. Bits beyond the first OperationMaskSize bytes are ignored.
+func DecodeOperationMask(mask []byte) []core.Transaction_Contract_ContractType {
+	if len(mask) > OperationMaskSize {
+		mask = mask[:OperationMaskSize]
+	}
+	var out []core.Transaction_Contract_ContractType
+	for i, b := range mask {
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<bit) != 0 {
+				out = append(out, core.Transaction_Contract_ContractType(i*8+bit))
+			}
+		}
+	}
+	return out
+}
+
+// PermissionKey is one weighted key of a permission.
+type PermissionKey struct {
+	Address *Address
+	Weight  int64
+}
+
+// PermissionSummary is a readable view of a core.Permission.
+type PermissionSummary struct {
+	Type      core.Permission_PermissionType
+	ID        int32
+	Name      string
+	Threshold int64
+	Keys      []PermissionKey
+	// Operations is the raw 32-byte operation mask of an active permission;
+	// it is empty for owner and witness permissions, which allow every
+	// operation.
+	Operations []byte
+}
+
+// NewPermissionSummary converts a core.Permission. Keys whose address cannot
+// be decoded are kept with a nil Address so weights still add up.
+func NewPermissionSummary(p *core.Permission) PermissionSummary {
+	s := PermissionSummary{
+		Type:       p.GetType(),
+		ID:         p.GetId(),
+		Name:       p.GetPermissionName(),
+		Threshold:  p.GetThreshold(),
+		Operations: append([]byte(nil), p.GetOperations()...),
+	}
+	for _, k := range p.GetKeys() {
+		s.Keys = append(s.Keys, PermissionKey{Address: optionalAddress(k.GetAddress()), Weight: k.GetWeight()})
+	}
+	return s
+}
+
+// TotalWeight returns the sum of all key weights.
+func (p PermissionSummary) TotalWeight() int64 {
+	var total int64
+	for _, k := range p.Keys {
+		total += k.Weight
+	}
+	return total
+}
+
+// AllowedContracts decodes Operations into the contract types the
+// permission may sign. It is empty for owner and witness permissions.
+func (p PermissionSummary) AllowedContracts() []core.Transaction_Contract_ContractType {
+	return DecodeOperationMask(p.Operations)
+}
+
+// String renders the permission on one line per key, e.g.
+//
+//	Active #2 "transfer" threshold 2 ops [TransferContract TriggerSmartContract]
+//	  TXYZ... weight 1
+func (p PermissionSummary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s #%d %q threshold %d", p.Type, p.ID, p.Name, p.Threshold)
+	if len(p.Operations) > 0 {
+		fmt.Fprintf(&b, " ops %v", p.AllowedContracts())
+	}
+	for _, k := range p.Keys {
+		addr := "<invalid>"
+		if k.Address != nil {
+			addr = k.Address.String()
+		}
+		fmt.Fprintf(&b, "\n  %s weight %d", addr, k.Weight)
+	}
+	return b.String()
+}
+
+// PermissionUpdateSummary is a readable view of an
+// AccountPermissionUpdateContract.
+type PermissionUpdateSummary struct {
+	// Owner is the account whose permissions are replaced.
+	Owner *Address
+	// OwnerPermission is the new owner permission.
+	OwnerPermission PermissionSummary
+	// WitnessPermission is set only for witness accounts.
+	WitnessPermission *PermissionSummary
+	// ActivePermissions are the new active permissions, in contract order.
+	ActivePermissions []PermissionSummary
+}
+
+// String renders the update as one block per permission.
+func (s *PermissionUpdateSummary) String() string {
+	parts := []string{fmt.Sprintf("permission update for %s", s.Owner), s.OwnerPermission.String()}
+	if s.WitnessPermission != nil {
+		parts = append(parts, s.WitnessPermission.String())
+	}
+	for _, p := range s.ActivePermissions {
+		parts = append(parts, p.String())
+	}
+	return strings.Join(parts, "\n")
+}
+
+// DecodeAccountPermissionUpdate decodes a transaction carrying a single
+// AccountPermissionUpdateContract, so the new owner and active permissions
+// can be reviewed before signing.
+//
+// Example:
+//
+//	summary, err := types.DecodeAccountPermissionUpdate(tx)
+//	if err != nil {
+//	    // not a permission update
+//	}
+//	fmt.Println(summary)
+func DecodeAccountPermissionUpdate(tx *core.Transaction) (*PermissionUpdateSummary, error) {
+	decoded, err := DecodeTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := decoded.Contract.(*core.AccountPermissionUpdateContract)
+	if !ok || decoded.Type != core.Transaction_Contract_AccountPermissionUpdateContract {
+		return nil, fmt.Errorf("%w: expected AccountPermissionUpdateContract, got %s", ErrInvalidTransaction, decoded.Type)
+	}
+
+	summary := &PermissionUpdateSummary{
+		Owner:           decoded.From,
+		OwnerPermission: NewPermissionSummary(c.GetOwner()),
+	}
+	if c.GetWitness() != nil {
+		w := NewPermissionSummary(c.GetWitness())
+		summary.WitnessPermission = &w
+	}
+	for _, p := range c.GetActives() {
+		summary.ActivePermissions = append(summary.ActivePermissions, NewPermissionSummary(p))
+	}
+	return summary, nil
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAccountPermissionUpdate(t *testing.T) {
+	owner := MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	cosigner := MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	ops := NewOperationMask(core.Transaction_Contract_TransferContract, core.Transaction_Contract_TransferAssetContract)
+
+	tx := newTestTx(t, core.Transaction_Contract_AccountPermissionUpdateContract, &core.AccountPermissionUpdateContract{
+		OwnerAddress: owner.Bytes(),
+		Owner: &core.Permission{
+			Type: core.Permission_Owner, PermissionName: "owner", Threshold: 1,
+			Keys: []*core.Key{{Address: owner.Bytes(), Weight: 1}},
+		},
+		Actives: []*core.Permission{{
+			Type: core.Permission_Active, Id: 2, PermissionName: "transfer", Threshold: 2, Operations: ops,
+			Keys: []*core.Key{{Address: owner.Bytes(), Weight: 1}, {Address: cosigner.Bytes(), Weight: 1}},
+		}},
+	})
+
+	s, err := DecodeAccountPermissionUpdate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, owner.String(), s.Owner.String())
+	assert.Equal(t, int64(1), s.OwnerPermission.Threshold)
+	assert.Nil(t, s.WitnessPermission)
+	require.Len(t, s.ActivePermissions, 1)
+	active := s.ActivePermissions[0]
+	assert.Equal(t, int32(2), active.ID)
+	assert.Equal(t, "transfer", active.Name)
+	assert.Equal(t, int64(2), active.TotalWeight())
+	assert.Equal(t, ops, active.Operations)
+	assert.Equal(t, []core.Transaction_Contract_ContractType{
+		core.Transaction_Contract_TransferContract,
+		core.Transaction_Contract_TransferAssetContract,
+	}, active.AllowedContracts())
+	require.Len(t, active.Keys, 2)
+	assert.Equal(t, cosigner.String(), active.Keys[1].Address.String())
+	assert.Contains(t, s.String(), cosigner.String()+" weight 1")
+	assert.Contains(t, s.String(), "ops [TransferContract TransferAssetContract]")
+
+	t.Run("wrong contract type", func(t *testing.T) {
+		tx := newTestTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{OwnerAddress: owner.Bytes()})
+		_, err := DecodeAccountPermissionUpdate(tx)
+		assert.ErrorIs(t, err, ErrInvalidTransaction)
+	})
+}
+
+func TestOperationMask(t *testing.T) {
+	mask := NewOperationMask(
+		core.Transaction_Contract_TriggerSmartContract,
+		core.Transaction_Contract_TransferContract,
+		core.Transaction_Contract_TransferContract,
+		core.Transaction_Contract_ContractType(-1),
+	)
+	require.Len(t, mask, OperationMaskSize)
+	assert.Equal(t, byte(0x02), mask[0]) // TransferContract = 1
+	assert.Equal(t, byte(0x80), mask[3]) // TriggerSmartContract = 31
+	assert.Equal(t, []core.Transaction_Contract_ContractType{
+		core.Transaction_Contract_TransferContract,
+		core.Transaction_Contract_TriggerSmartContract,
+	}, DecodeOperationMask(mask))
+
+	assert.Empty(t, DecodeOperationMask(nil))
+	assert.Empty(t, DecodeOperationMask(NewOperationMask()))
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// RefBlock carries the reference block (TaPoS) fields and timing used to
+// build a transaction locally instead of on a node.
+type RefBlock struct {
+	RefBlockBytes []byte // bytes 6..8 of the big-endian block number
+	RefBlockHash  []byte // bytes 8..16 of the block ID
+	Expiration    int64  // expiration in milliseconds
+	Timestamp     int64  // creation time in milliseconds; zero leaves it unset
+}
+
+// RefBlockProvider supplies the RefBlock for each locally built transaction.
+// Implementations backed by a node fetch a recent block; tests can use
+// StaticRefBlock to make the built raw bytes deterministic.
+type RefBlockProvider interface {
+	RefBlock(ctx context.Context) (*RefBlock, error)
+}
+
+// StaticRefBlock is a RefBlockProvider that always returns the same RefBlock.
+type StaticRefBlock RefBlock
+
+// RefBlock returns a copy of s.
+func (s StaticRefBlock) RefBlock(ctx context.Context) (*RefBlock, error) {
+	return &RefBlock{
+		RefBlockBytes: append([]byte(nil), s.RefBlockBytes...),
+		RefBlockHash:  append([]byte(nil), s.RefBlockHash...),
+		Expiration:    s.Expiration,
+		Timestamp:     s.Timestamp,
+	}, nil
+}
+
+// BuildTransaction wraps contract in an unsigned single-contract transaction
+// referencing the block supplied by ref. The result mirrors what a node
+// returns from its transaction builder RPCs, with Txid filled in.
+func BuildTransaction(ctx context.Context, ref RefBlockProvider, contractType core.Transaction_Contract_ContractType, contract proto.Message, feeLimit int64) (*api.TransactionExtention, error) {
+	if ref == nil {
+		return nil, fmt.Errorf("%w: reference block provider cannot be nil", ErrInvalidParameter)
+	}
+	blk, err := ref.RefBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reference block: %w", err)
+	}
+	if len(blk.RefBlockBytes) != 2 || len(blk.RefBlockHash) != 8 {
+		return nil, fmt.Errorf("%w: reference block needs 2 ref bytes and 8 hash bytes, got %d and %d", ErrInvalidParameter, len(blk.RefBlockBytes), len(blk.RefBlockHash))
+	}
+	if blk.Expiration <= 0 {
+		return nil, fmt.Errorf("%w: expiration must be positive", ErrInvalidParameter)
+	}
+	param, err := anypb.New(contract)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode contract parameter: %v", ErrInvalidParameter, err)
+	}
+
+	tx := &core.Transaction{
+		RawData: &core.TransactionRaw{
+			RefBlockBytes: blk.RefBlockBytes,
+			RefBlockHash:  blk.RefBlockHash,
+			Expiration:    blk.Expiration,
+			Timestamp:     blk.Timestamp,
+			FeeLimit:      feeLimit,
+			Contract: []*core.Transaction_Contract{{
+				Type:      contractType,
+				Parameter: param,
+			}},
+		},
+	}
+	txid, err := TransactionID(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TransactionExtention{
+		Transaction: tx,
+		Txid:        txid,
+		Result:      &api.Return{Result: true, Code: api.Return_SUCCESS},
+	}, nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kslamph/tronlib/pb/core"
+)
+
+// RecoverSigners recovers the address behind each signature of tx, in
+// signature order. Duplicate signatures yield duplicate addresses.
+//
+// Every signature covers the whole RawData, including the contract's
+// permission id, so the signers were authorizing under that permission
+// (owner when zero, see TxSummary.PermissionID). Whether they actually
+// belong to it must be checked against the owner account's permissions.
+//
+// Unlike utils.ExtractSigners, which skips malformed signatures, any
+// signature that cannot be recovered is an error, making this suitable for
+// auditing transactions received from other parties.
+//
+// Example:
+//
+//	signers, err := types.RecoverSigners(tx)
+//	if err != nil {
+//	    // handle error
+//	}
+//	for _, s := range signers {
+//	    fmt.Println("signed by", s)
+//	}
+func RecoverSigners(tx *core.Transaction) ([]*Address, error) {
+	hash, err := TransactionID(tx)
+	if err != nil {
+		return nil, err
+	}
+	sigs := tx.GetSignature()
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("%w: transaction has no signatures", ErrInvalidTransaction)
+	}
+
+	signers := make([]*Address, len(sigs))
+	for i, sig := range sigs {
+		if len(sig) != 65 {
+			return nil, fmt.Errorf("%w: signature %d must be 65 bytes, got %d", ErrInvalidTransaction, i, len(sig))
+		}
+		// Accept both the 0/1 and 27/28 recovery id conventions
+		normalized := append([]byte(nil), sig...)
+		if normalized[64] >= 27 {
+			normalized[64] -= 27
+		}
+		pubKey, err := crypto.SigToPub(hash, normalized)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to recover signature %d: %v", ErrInvalidTransaction, i, err)
+		}
+		addr, err := NewAddressFromEVM(crypto.PubkeyToAddress(*pubKey))
+		if err != nil {
+			return nil, err
+		}
+		signers[i] = addr
+	}
+	return signers, nil
+}
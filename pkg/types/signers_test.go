@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverSigners(t *testing.T) {
+	key1, err := crypto.HexToECDSA("1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	key2, err := crypto.HexToECDSA("2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		Timestamp: 1,
+		Contract:  []*core.Transaction_Contract{{PermissionId: 2}},
+	}}
+	hash, err := TransactionID(tx)
+	require.NoError(t, err)
+
+	sig1, err := crypto.Sign(hash, key1)
+	require.NoError(t, err)
+	sig2, err := crypto.Sign(hash, key2)
+	require.NoError(t, err)
+	sig2[64] += 27 // TIP-191 style recovery id
+	tx.Signature = [][]byte{sig1, sig2}
+
+	signers, err := RecoverSigners(tx)
+	require.NoError(t, err)
+	require.Len(t, signers, 2)
+	want1, _ := NewAddressFromEVM(crypto.PubkeyToAddress(key1.PublicKey))
+	want2, _ := NewAddressFromEVM(crypto.PubkeyToAddress(key2.PublicKey))
+	assert.Equal(t, want1.String(), signers[0].String())
+	assert.Equal(t, want2.String(), signers[1].String())
+
+	// Changing the permission id changes the signed hash.
+	tx.RawData.Contract[0].PermissionId = 0
+	signers, err = RecoverSigners(tx)
+	require.NoError(t, err)
+	assert.NotEqual(t, want1.String(), signers[0].String())
+
+	_, err = RecoverSigners(&core.Transaction{RawData: &core.TransactionRaw{}})
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+
+	tx.Signature = [][]byte{sig1[:64]}
+	_, err = RecoverSigners(tx)
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+}
@@ -0,0 +1,416 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TxSummary is a normalized, contract-type independent view of a transaction.
+//
+// Fields that do not apply to the contract type are left at their zero value.
+type TxSummary struct {
+	// Type is the contract type, e.g. TransferContract or TriggerSmartContract.
+	Type core.Transaction_Contract_ContractType
+	// Contract is the decoded contract parameter (e.g. *core.TransferContract).
+	Contract proto.Message
+	// PermissionID is the permission the transaction is signed under.
+	PermissionID int32
+	// FeeLimit is the maximum fee in SUN for smart contract transactions.
+	FeeLimit int64
+
+	// From is the owner address of the contract.
+	From *Address
+	// To is the recipient of a transfer or delegation.
+	To *Address
+	// Amount is the TRX (in SUN) or token amount moved by the transaction.
+	Amount int64
+	// TokenID identifies the TRC10 token for asset transfers and token calls.
+	TokenID string
+
+	// ContractAddress is the smart contract called by TriggerSmartContract.
+	ContractAddress *Address
+	// CallData is the raw call data of TriggerSmartContract.
+	CallData []byte
+	// Selector is the 0x-prefixed 4-byte method selector of CallData.
+	Selector string
+}
+
+// DecodeTransaction unmarshals the single contract parameter of tx and
+// returns a normalized summary.
+//
+// Well-known types (transfers, smart contract calls, staking and delegation)
+// populate the typed fields; for any other contract type only From and
+// Contract are filled in.
+//
+// Example:
+//
+//	summary, err := types.DecodeTransaction(tx)
+//	if err != nil {
+//	    // handle error
+//	}
+//	fmt.Printf("%s: %s -> %s %d\n", summary.Type, summary.From, summary.To, summary.Amount)
+func DecodeTransaction(tx *core.Transaction) (*TxSummary, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) != 1 {
+		return nil, fmt.Errorf("%w: transaction must have exactly one contract, got %d", ErrInvalidTransaction, len(contracts))
+	}
+	contract := contracts[0]
+	if contract.GetParameter() == nil {
+		return nil, fmt.Errorf("%w: contract parameter is nil", ErrInvalidTransaction)
+	}
+
+	msg, err := contract.GetParameter().UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode %s parameter: %v", ErrInvalidTransaction, contract.GetType(), err)
+	}
+
+	summary := &TxSummary{
+		Type:         contract.GetType(),
+		Contract:     msg,
+		PermissionID: contract.GetPermissionId(),
+		FeeLimit:     tx.GetRawData().GetFeeLimit(),
+		From:         ownerAddress(msg),
+	}
+
+	switch c := msg.(type) {
+	case *core.TransferContract:
+		summary.To = optionalAddress(c.GetToAddress())
+		summary.Amount = c.GetAmount()
+	case *core.TransferAssetContract:
+		summary.To = optionalAddress(c.GetToAddress())
+		summary.Amount = c.GetAmount()
+		summary.TokenID = string(c.GetAssetName())
+	case *core.TriggerSmartContract:
+		summary.ContractAddress = optionalAddress(c.GetContractAddress())
+		summary.Amount = c.GetCallValue()
+		summary.CallData = c.GetData()
+		if len(c.GetData()) >= 4 {
+			summary.Selector = "0x" + hex.EncodeToString(c.GetData()[:4])
+		}
+		if c.GetTokenId() != 0 {
+			summary.TokenID = strconv.FormatInt(c.GetTokenId(), 10)
+		}
+	case *core.CreateSmartContract:
+		summary.Amount = c.GetNewContract().GetCallValue()
+	case *core.FreezeBalanceV2Contract:
+		summary.Amount = c.GetFrozenBalance()
+	case *core.UnfreezeBalanceV2Contract:
+		summary.Amount = c.GetUnfreezeBalance()
+	case *core.DelegateResourceContract:
+		summary.To = optionalAddress(c.GetReceiverAddress())
+		summary.Amount = c.GetBalance()
+	case *core.UnDelegateResourceContract:
+		summary.To = optionalAddress(c.GetReceiverAddress())
+		summary.Amount = c.GetBalance()
+	}
+
+	return summary, nil
+}
+
+// ownerAddress reads the owner_address field that every TRON contract
+// parameter carries.
+func ownerAddress(msg proto.Message) *Address {
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("owner_address")
+	if field == nil || field.Kind() != protoreflect.BytesKind {
+		return nil
+	}
+	return optionalAddress(m.Get(field).Bytes())
+}
+
+// optionalAddress converts raw address bytes, returning nil when absent or invalid.
+func optionalAddress(b []byte) *Address {
+	if len(b) == 0 {
+		return nil
+	}
+	addr, err := NewAddressFromBytes(b)
+	if err != nil {
+		return nil
+	}
+	return addr
+}
+
+// SetMemo writes memo into the transaction's raw data field.
+// IMPORTANT: This must be called before signing, as the memo is part of the
+// signed raw data. For *api.TransactionExtention the Txid is recomputed.
+//
+// TRON has no separate memo limit, but the whole serialized transaction must
+// stay below MaxTransactionSize. Every memo byte is paid for as bandwidth, and
+// networks that enable the getMemoFee chain parameter additionally burn a
+// flat fee for any transaction carrying a memo.
+//
+// The function supports both *core.Transaction and *api.TransactionExtention.
+func SetMemo(tx any, memo []byte) error {
+	if len(memo) > MaxTransactionSize {
+		return fmt.Errorf("%w: memo of %d bytes exceeds the %d byte transaction limit", ErrInvalidParameter, len(memo), MaxTransactionSize)
+	}
+
+	switch t := tx.(type) {
+	case *core.Transaction:
+		if t == nil || t.RawData == nil {
+			return fmt.Errorf("%w: transaction raw data cannot be nil", ErrInvalidTransaction)
+		}
+		t.RawData.Data = memo
+		return nil
+
+	case *api.TransactionExtention:
+		if t == nil || t.Transaction == nil || t.Transaction.RawData == nil {
+			return fmt.Errorf("%w: transaction raw data cannot be nil", ErrInvalidTransaction)
+		}
+		t.Transaction.RawData.Data = memo
+		txid, err := TransactionID(t.Transaction)
+		if err != nil {
+			return err
+		}
+		t.Txid = txid
+		return nil
+
+	default:
+		return fmt.Errorf("%w: unsupported transaction type: %T, expected *core.Transaction or *api.TransactionExtention", ErrInvalidParameter, tx)
+	}
+}
+
+// SetExpiration resets the transaction to expire d from now, replacing the
+// node's default of about one minute after it was built. Call it right after
+// building a transaction that will be signed or broadcast much later.
+// IMPORTANT: Like SetMemo this must be called before signing. For
+// *api.TransactionExtention the Txid is recomputed.
+//
+// d must be positive and at most MaxExpiration; the node also rejects
+// expirations more than MaxExpiration past the reference block.
+//
+// The function supports both *core.Transaction and *api.TransactionExtention.
+func SetExpiration(tx any, d time.Duration) error {
+	if d <= 0 || d > MaxExpiration {
+		return fmt.Errorf("%w: expiration must be between 0 and %v, got %v", ErrInvalidParameter, MaxExpiration, d)
+	}
+	expiration := time.Now().Add(d).UnixMilli()
+
+	switch t := tx.(type) {
+	case *core.Transaction:
+		if t == nil || t.RawData == nil {
+			return fmt.Errorf("%w: transaction raw data cannot be nil", ErrInvalidTransaction)
+		}
+		t.RawData.Expiration = expiration
+		return nil
+
+	case *api.TransactionExtention:
+		if t == nil || t.Transaction == nil || t.Transaction.RawData == nil {
+			return fmt.Errorf("%w: transaction raw data cannot be nil", ErrInvalidTransaction)
+		}
+		t.Transaction.RawData.Expiration = expiration
+		txid, err := TransactionID(t.Transaction)
+		if err != nil {
+			return err
+		}
+		t.Txid = txid
+		return nil
+
+	default:
+		return fmt.Errorf("%w: unsupported transaction type: %T, expected *core.Transaction or *api.TransactionExtention", ErrInvalidParameter, tx)
+	}
+}
+
+// GetMemo returns the memo stored in the transaction's raw data field, or nil
+// if there is none. Supports *core.Transaction and *api.TransactionExtention.
+func GetMemo(tx any) []byte {
+	switch t := tx.(type) {
+	case *core.Transaction:
+		return t.GetRawData().GetData()
+	case *api.TransactionExtention:
+		return t.GetTransaction().GetRawData().GetData()
+	default:
+		return nil
+	}
+}
+
+// IsExpired reports whether the transaction's expiration time has passed.
+// Expired transactions are rejected by the node; rebuild them or refresh them
+// with Client.RefreshTransaction and sign again. Transactions without raw data
+// are reported as expired. Supports *core.Transaction and
+// *api.TransactionExtention.
+func IsExpired(tx any) bool {
+	var raw *core.TransactionRaw
+	switch t := tx.(type) {
+	case *core.Transaction:
+		raw = t.GetRawData()
+	case *api.TransactionExtention:
+		raw = t.GetTransaction().GetRawData()
+	}
+	if raw == nil {
+		return true
+	}
+	return raw.GetExpiration() <= time.Now().UnixMilli()
+}
+
+// GetExpiration returns the time after which the node rejects the
+// transaction, or the zero time when it has no raw data or expiration.
+// Supports *core.Transaction and *api.TransactionExtention.
+//
+// Example:
+//
+//	if time.Until(types.GetExpiration(tx)) < time.Minute {
+//	    // not enough time left to collect the remaining signatures
+//	}
+func GetExpiration(tx any) time.Time {
+	var raw *core.TransactionRaw
+	switch t := tx.(type) {
+	case *core.Transaction:
+		raw = t.GetRawData()
+	case *api.TransactionExtention:
+		raw = t.GetTransaction().GetRawData()
+	}
+	if raw.GetExpiration() <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(raw.GetExpiration())
+}
+
+// TransactionID returns the transaction id, which is the sha256 hash of the
+// protobuf-encoded RawData. Signatures are not part of the hash, but any
+// change to RawData after building (memo, expiration, fee limit) changes the
+// id.
+func TransactionID(tx *core.Transaction) ([]byte, error) {
+	if tx.GetRawData() == nil {
+		return nil, fmt.Errorf("%w: transaction raw data cannot be nil", ErrInvalidTransaction)
+	}
+	raw, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to marshal raw data: %v", ErrInvalidTransaction, err)
+	}
+	txid := sha256.Sum256(raw)
+	return txid[:], nil
+}
+
+// TransactionIDHex returns the transaction id as lowercase hex, or "" when the
+// transaction has no raw data.
+func TransactionIDHex(tx *core.Transaction) string {
+	txid, err := TransactionID(tx)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(txid)
+}
+
+// VerifyTransactionID reports whether the id computed from tx's RawData
+// matches expectedHex. The comparison is case-insensitive and accepts an
+// optional 0x prefix.
+func VerifyTransactionID(tx *core.Transaction, expectedHex string) (bool, error) {
+	expected, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(expectedHex, "0x"), "0X"))
+	if err != nil || len(expected) != sha256.Size {
+		return false, fmt.Errorf("%w: invalid transaction id %q", ErrInvalidParameter, expectedHex)
+	}
+	txid, err := TransactionID(tx)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(txid, expected), nil
+}
+
+// SignatureLength is the length of a TRON transaction signature: r, s and the
+// recovery id.
+const SignatureLength = 65
+
+// MarshalTransaction encodes tx, including any signatures, as deterministic
+// protobuf so the same transaction always produces the same bytes. Use it
+// with UnmarshalTransaction to hand an unsigned transaction to an offline
+// signer and bring the signed one back.
+func MarshalTransaction(tx *core.Transaction) ([]byte, error) {
+	if tx.GetRawData() == nil {
+		return nil, fmt.Errorf("%w: transaction raw data cannot be nil", ErrInvalidTransaction)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to marshal transaction: %v", ErrInvalidTransaction, err)
+	}
+	return data, nil
+}
+
+// UnmarshalTransaction decodes a transaction encoded by MarshalTransaction.
+func UnmarshalTransaction(data []byte) (*core.Transaction, error) {
+	tx := &core.Transaction{}
+	if err := proto.Unmarshal(data, tx); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal transaction: %v", ErrInvalidTransaction, err)
+	}
+	if tx.GetRawData() == nil {
+		return nil, fmt.Errorf("%w: transaction raw data cannot be nil", ErrInvalidTransaction)
+	}
+	return tx, nil
+}
+
+// MarshalTransactionText encodes tx like MarshalTransaction as unpadded
+// URL-safe base64, which survives copy and paste, URLs and QR codes.
+//
+// Example:
+//
+//	// online machine
+//	text, err := types.MarshalTransactionText(txExt.GetTransaction())
+//
+//	// offline machine
+//	tx, err := types.UnmarshalTransactionText(text)
+//	err = signer.SignTx(pkSigner, tx)
+//	signed, err := types.MarshalTransactionText(tx)
+func MarshalTransactionText(tx *core.Transaction) (string, error) {
+	data, err := MarshalTransaction(tx)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// UnmarshalTransactionText decodes a transaction encoded by
+// MarshalTransactionText. Standard base64 and padded input are accepted too,
+// and surrounding whitespace is ignored.
+func UnmarshalTransactionText(text string) (*core.Transaction, error) {
+	s := strings.TrimRight(strings.TrimSpace(text), "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid transaction text: %v", ErrInvalidTransaction, err)
+	}
+	return UnmarshalTransaction(data)
+}
+
+// AttachSignature appends sig, a 65-byte signature over TransactionID(tx),
+// to the transaction's signatures. It is the counterpart of signing offline:
+// the signature must come from a key of the permission the transaction uses,
+// and should be in canonical low-S form (see signer.NormalizeSignature). A
+// signature already present is rejected with ErrAlreadyExists.
+//
+// The function supports both *core.Transaction and *api.TransactionExtention.
+func AttachSignature(tx any, sig []byte) error {
+	if len(sig) != SignatureLength {
+		return fmt.Errorf("%w: signature must be %d bytes, got %d", ErrInvalidParameter, SignatureLength, len(sig))
+	}
+
+	var t *core.Transaction
+	switch v := tx.(type) {
+	case *core.Transaction:
+		t = v
+	case *api.TransactionExtention:
+		t = v.GetTransaction()
+	default:
+		return fmt.Errorf("%w: unsupported transaction type: %T, expected *core.Transaction or *api.TransactionExtention", ErrInvalidParameter, tx)
+	}
+	if t.GetRawData() == nil {
+		return fmt.Errorf("%w: transaction raw data cannot be nil", ErrInvalidTransaction)
+	}
+	for _, existing := range t.Signature {
+		if bytes.Equal(existing, sig) {
+			return fmt.Errorf("%w: signature is already attached", ErrAlreadyExists)
+		}
+	}
+	t.Signature = append(t.Signature, append([]byte(nil), sig...))
+	return nil
+}
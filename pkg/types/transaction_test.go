@@ -0,0 +1,209 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kslamph/tronlib/pb/api"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func newTestTx(t *testing.T, ct core.Transaction_Contract_ContractType, msg proto.Message) *core.Transaction {
+	t.Helper()
+	param, err := anypb.New(msg)
+	require.NoError(t, err)
+	return &core.Transaction{
+		RawData: &core.TransactionRaw{
+			FeeLimit: 100_000_000,
+			Contract: []*core.Transaction_Contract{{Type: ct, Parameter: param, PermissionId: 2}},
+		},
+	}
+}
+
+func TestDecodeTransaction(t *testing.T) {
+	from := MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	to := MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+
+	t.Run("transfer", func(t *testing.T) {
+		tx := newTestTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+			OwnerAddress: from.Bytes(), ToAddress: to.Bytes(), Amount: 1_000_000,
+		})
+		summary, err := DecodeTransaction(tx)
+		require.NoError(t, err)
+		assert.Equal(t, core.Transaction_Contract_TransferContract, summary.Type)
+		assert.True(t, from.Equal(summary.From))
+		assert.True(t, to.Equal(summary.To))
+		assert.Equal(t, int64(1_000_000), summary.Amount)
+		assert.Equal(t, int32(2), summary.PermissionID)
+	})
+
+	t.Run("trigger smart contract", func(t *testing.T) {
+		tx := newTestTx(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+			OwnerAddress:    from.Bytes(),
+			ContractAddress: to.Bytes(),
+			Data:            []byte{0xa9, 0x05, 0x9c, 0xbb, 0x00},
+			CallValue:       5,
+		})
+		summary, err := DecodeTransaction(tx)
+		require.NoError(t, err)
+		assert.True(t, to.Equal(summary.ContractAddress))
+		assert.Nil(t, summary.To)
+		assert.Equal(t, "0xa9059cbb", summary.Selector)
+		assert.Equal(t, int64(5), summary.Amount)
+		assert.Equal(t, int64(100_000_000), summary.FeeLimit)
+	})
+
+	t.Run("other contract type", func(t *testing.T) {
+		tx := newTestTx(t, core.Transaction_Contract_WithdrawBalanceContract, &core.WithdrawBalanceContract{
+			OwnerAddress: from.Bytes(),
+		})
+		summary, err := DecodeTransaction(tx)
+		require.NoError(t, err)
+		assert.True(t, from.Equal(summary.From))
+		assert.IsType(t, &core.WithdrawBalanceContract{}, summary.Contract)
+	})
+
+	t.Run("no contract", func(t *testing.T) {
+		_, err := DecodeTransaction(&core.Transaction{RawData: &core.TransactionRaw{}})
+		assert.ErrorIs(t, err, ErrInvalidTransaction)
+	})
+}
+
+func TestSetMemo(t *testing.T) {
+	tx := &core.Transaction{RawData: &core.TransactionRaw{}}
+	require.NoError(t, SetMemo(tx, []byte("hello")))
+	assert.Equal(t, []byte("hello"), GetMemo(tx))
+
+	assert.ErrorIs(t, SetMemo(&core.Transaction{}, []byte("x")), ErrInvalidTransaction)
+	assert.ErrorIs(t, SetMemo("not a tx", []byte("x")), ErrInvalidParameter)
+	assert.ErrorIs(t, SetMemo(tx, make([]byte, MaxTransactionSize+1)), ErrInvalidParameter)
+	assert.Nil(t, GetMemo(nil))
+}
+
+func TestSetExpiration(t *testing.T) {
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Expiration: time.Now().Add(-time.Second).UnixMilli()}}
+	before := time.Now()
+	require.NoError(t, SetExpiration(tx, time.Hour))
+	assert.False(t, GetExpiration(tx).Before(before.Add(time.Hour).Truncate(time.Millisecond)))
+	assert.False(t, IsExpired(tx))
+
+	ext := &api.TransactionExtention{Transaction: &core.Transaction{RawData: &core.TransactionRaw{}}}
+	require.NoError(t, SetExpiration(ext, time.Minute))
+	raw, err := proto.Marshal(ext.Transaction.RawData)
+	require.NoError(t, err)
+	sum := sha256.Sum256(raw)
+	assert.Equal(t, sum[:], ext.Txid)
+
+	assert.ErrorIs(t, SetExpiration(tx, 0), ErrInvalidParameter)
+	assert.ErrorIs(t, SetExpiration(tx, MaxExpiration+time.Second), ErrInvalidParameter)
+	assert.ErrorIs(t, SetExpiration(&core.Transaction{}, time.Minute), ErrInvalidTransaction)
+	assert.ErrorIs(t, SetExpiration("not a tx", time.Minute), ErrInvalidParameter)
+}
+
+func TestIsExpired(t *testing.T) {
+	future := &core.Transaction{RawData: &core.TransactionRaw{Expiration: time.Now().Add(time.Minute).UnixMilli()}}
+	past := &core.Transaction{RawData: &core.TransactionRaw{Expiration: time.Now().Add(-time.Second).UnixMilli()}}
+
+	assert.False(t, IsExpired(future))
+	assert.False(t, IsExpired(&api.TransactionExtention{Transaction: future}))
+	assert.True(t, IsExpired(past))
+	assert.True(t, IsExpired(&core.Transaction{}))
+	assert.True(t, IsExpired(nil))
+}
+
+func TestGetExpiration(t *testing.T) {
+	exp := time.UnixMilli(1_700_000_060_000)
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Expiration: exp.UnixMilli()}}
+
+	assert.True(t, GetExpiration(tx).Equal(exp))
+	assert.True(t, GetExpiration(&api.TransactionExtention{Transaction: tx}).Equal(exp))
+	assert.True(t, GetExpiration(&core.Transaction{}).IsZero())
+	assert.True(t, GetExpiration(nil).IsZero())
+}
+
+func TestTransactionID(t *testing.T) {
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1, Expiration: 2}}
+	raw, err := proto.Marshal(tx.RawData)
+	require.NoError(t, err)
+	sum := sha256.Sum256(raw)
+	want := hex.EncodeToString(sum[:])
+
+	assert.Equal(t, want, TransactionIDHex(tx))
+
+	ok, err := VerifyTransactionID(tx, want)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = VerifyTransactionID(tx, "0x"+strings.ToUpper(want))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Signatures do not affect the id, raw data changes do.
+	tx.Signature = [][]byte{{1, 2, 3}}
+	assert.Equal(t, want, TransactionIDHex(tx))
+	require.NoError(t, SetMemo(tx, []byte("memo")))
+	ok, err = VerifyTransactionID(tx, want)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = VerifyTransactionID(tx, "zz")
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+	_, err = VerifyTransactionID(&core.Transaction{}, want)
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+	assert.Equal(t, "", TransactionIDHex(nil))
+}
+
+func TestMarshalTransaction(t *testing.T) {
+	tx := newTestTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{Amount: 5})
+	tx.RawData.Expiration = 1_700_000_060_000
+
+	data, err := MarshalTransaction(tx)
+	require.NoError(t, err)
+	again, err := MarshalTransaction(tx)
+	require.NoError(t, err)
+	assert.Equal(t, data, again)
+
+	decoded, err := UnmarshalTransaction(data)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(tx, decoded))
+
+	text, err := MarshalTransactionText(tx)
+	require.NoError(t, err)
+	assert.NotContains(t, text, "=")
+	fromText, err := UnmarshalTransactionText(" " + text + "\n")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(tx, fromText))
+
+	_, err = MarshalTransaction(&core.Transaction{})
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+	_, err = UnmarshalTransaction([]byte{0xff})
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+	_, err = UnmarshalTransactionText("not base64!")
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+}
+
+func TestAttachSignature(t *testing.T) {
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1}}
+	txid := TransactionIDHex(tx)
+	sig := make([]byte, SignatureLength)
+	sig[0] = 1
+
+	require.NoError(t, AttachSignature(tx, sig))
+	sig[0] = 2 // the attached signature is a copy
+	require.NoError(t, AttachSignature(&api.TransactionExtention{Transaction: tx}, sig))
+	assert.Len(t, tx.Signature, 2)
+	assert.Equal(t, byte(1), tx.Signature[0][0])
+	assert.Equal(t, txid, TransactionIDHex(tx))
+
+	assert.ErrorIs(t, AttachSignature(tx, sig), ErrAlreadyExists)
+	assert.ErrorIs(t, AttachSignature(tx, sig[:64]), ErrInvalidParameter)
+	assert.ErrorIs(t, AttachSignature(&core.Transaction{}, sig), ErrInvalidTransaction)
+	assert.ErrorIs(t, AttachSignature("tx", sig), ErrInvalidParameter)
+}
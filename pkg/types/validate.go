@@ -0,0 +1,105 @@
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// Validation failures are preallocated so that rejecting an address does not
+// allocate either.
+var (
+	errBase58Length   = fmt.Errorf("%w: base58 address must be %d characters", ErrInvalidAddress, AddressBase58Length)
+	errBase58Prefix   = fmt.Errorf("%w: base58 address must start with T", ErrInvalidAddress)
+	errBase58Char     = fmt.Errorf("%w: invalid base58 character", ErrInvalidAddress)
+	errBase58Overflow = fmt.Errorf("%w: base58 value does not fit a TRON address", ErrInvalidAddress)
+	errBase58Version  = fmt.Errorf("%w: address prefix must be 0x41", ErrInvalidAddress)
+	errBase58Checksum = fmt.Errorf("%w: invalid checksum", ErrInvalidAddress)
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Index maps an ASCII byte to its base58 digit, or -1 if invalid.
+var base58Index = func() (idx [256]int8) {
+	for i := range idx {
+		idx[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		idx[base58Alphabet[i]] = int8(i)
+	}
+	return idx
+}()
+
+// ValidateBase58Checksum checks that s is a T-prefixed Base58Check TRON
+// address with a valid 0x41 version byte and checksum, without allocating.
+// The returned error wraps ErrInvalidAddress.
+func ValidateBase58Checksum(s string) error {
+	if len(s) != AddressBase58Length {
+		return errBase58Length
+	}
+	if s[0] != 'T' {
+		return errBase58Prefix
+	}
+
+	// 21 address bytes + 4 checksum bytes, decoded big-endian in place
+	var buf [AddressLength + 4]byte
+	for i := 0; i < len(s); i++ {
+		digit := base58Index[s[i]]
+		if digit < 0 {
+			return errBase58Char
+		}
+		carry := uint32(digit)
+		for j := len(buf) - 1; j >= 0; j-- {
+			carry += uint32(buf[j]) * 58
+			buf[j] = byte(carry)
+			carry >>= 8
+		}
+		if carry != 0 {
+			return errBase58Overflow
+		}
+	}
+
+	if buf[0] != AddressPrefixByte {
+		return errBase58Version
+	}
+	h1 := sha256.Sum256(buf[:AddressLength])
+	h2 := sha256.Sum256(h1[:])
+	if h2[0] != buf[21] || h2[1] != buf[22] || h2[2] != buf[23] || h2[3] != buf[24] {
+		return errBase58Checksum
+	}
+	return nil
+}
+
+// IsValidHex reports whether s is a hex TRON address in one of the forms
+// accepted by NewAddressFromHex: 41-prefixed 21 bytes or a 20-byte EVM
+// address, each with an optional 0x prefix.
+func IsValidHex(s string) bool {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	switch len(s) {
+	case 2 * AddressLength:
+		if s[0] != '4' || s[1] != '1' {
+			return false
+		}
+	case 2 * (AddressLength - 1):
+	default:
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidAddress reports whether s would be accepted by NewAddress as a
+// Base58 or hex TRON address. It does not allocate and is suitable for
+// filtering user input on hot paths.
+//
+// Whether an address belongs to a contract cannot be determined from its
+// encoding; that requires an on-chain lookup.
+func IsValidAddress(s string) bool {
+	return ValidateBase58Checksum(s) == nil || IsValidHex(s)
+}
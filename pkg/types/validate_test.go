@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBase58Checksum(t *testing.T) {
+	valid := "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb"
+	assert.NoError(t, ValidateBase58Checksum(valid))
+
+	cases := map[string]string{
+		"too short":    "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jw",
+		"wrong prefix": "AWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb",
+		"bad char":     "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jw0",
+		"bad checksum": "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwc",
+	}
+	for name, s := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateBase58Checksum(s)
+			assert.ErrorIs(t, err, ErrInvalidAddress)
+			_, parseErr := NewAddressFromBase58(s)
+			assert.Error(t, parseErr, "NewAddressFromBase58 should also reject %q", s)
+		})
+	}
+}
+
+func TestIsValidHex(t *testing.T) {
+	assert.True(t, IsValidHex("41e28b3cfd4e0e909077821478e9fcb86b84be786e"))
+	assert.True(t, IsValidHex("0x41E28B3CFD4E0E909077821478E9FCB86B84BE786E"))
+	assert.True(t, IsValidHex("0xe28b3cfd4e0e909077821478e9fcb86b84be786e"))
+	assert.False(t, IsValidHex("42e28b3cfd4e0e909077821478e9fcb86b84be786e"))
+	assert.False(t, IsValidHex("0xe28b3cfd4e0e909077821478e9fcb86b84be786g"))
+	assert.False(t, IsValidHex("0x1234"))
+}
+
+func TestIsValidAddress(t *testing.T) {
+	assert.True(t, IsValidAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb"))
+	assert.True(t, IsValidAddress("0x41e28b3cfd4e0e909077821478e9fcb86b84be786e"))
+	assert.False(t, IsValidAddress(""))
+	assert.False(t, IsValidAddress("not an address"))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		IsValidAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+		IsValidAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwc")
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkIsValidAddress(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IsValidAddress("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	}
+}
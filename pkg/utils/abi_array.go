@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// convertArrayParameter converts an array parameter of any nesting depth,
+// e.g. "uint256[]", "address[2][]" or "bytes32[][]", to the Go type
+// go-ethereum packs for paramType. param may be a JSON array string, a slice
+// or an array; values already of the exact Go type are passed through.
+func (p *ABIProcessor) convertArrayParameter(param interface{}, paramType string) (interface{}, error) {
+	abiType, err := eABI.NewType(paramType, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidData, err)
+	}
+	if jsonStr, ok := param.(string); ok {
+		var jsonArray []interface{}
+		if err := json.Unmarshal([]byte(jsonStr), &jsonArray); err != nil {
+			return nil, fmt.Errorf("failed to parse array JSON: %v", err)
+		}
+		param = jsonArray
+	}
+	return p.convertABIValue(param, abiType)
+}
+
+// convertArrayElements converts the elements of a flat baseType[] array.
+func (p *ABIProcessor) convertArrayElements(elements []interface{}, baseType string) (interface{}, error) {
+	return p.convertArrayParameter(elements, baseType+"[]")
+}
+
+// convertABIValue converts param to the Go type of t, recursing into the
+// elements of slice and array types.
+func (p *ABIProcessor) convertABIValue(param interface{}, t eABI.Type) (interface{}, error) {
+	if t.T != eABI.SliceTy && t.T != eABI.ArrayTy {
+		return p.convertParameter(param, t.String())
+	}
+
+	target := t.GetType()
+	if param != nil && reflect.TypeOf(param) == target {
+		return param, nil
+	}
+	v := reflect.ValueOf(param)
+	if param == nil || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+		return nil, fmt.Errorf("array parameter must be JSON string, slice or array, got %T", param)
+	}
+
+	var out reflect.Value
+	if t.T == eABI.ArrayTy {
+		if v.Len() != t.Size {
+			return nil, fmt.Errorf("array length mismatch for %s: expected %d, got %d", t, t.Size, v.Len())
+		}
+		out = reflect.New(target).Elem()
+	} else {
+		out = reflect.MakeSlice(target, v.Len(), v.Len())
+	}
+
+	elemType := target.Elem()
+	for i := 0; i < v.Len(); i++ {
+		elem, err := p.convertABIValue(v.Index(i).Interface(), *t.Elem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s at index %d: %w", t.Elem, i, err)
+		}
+		ev := reflect.ValueOf(elem)
+		if !ev.Type().AssignableTo(elemType) {
+			if !ev.Type().ConvertibleTo(elemType) {
+				return nil, fmt.Errorf("invalid %s at index %d: cannot use %T", t.Elem, i, elem)
+			}
+			ev = ev.Convert(elemType)
+		}
+		out.Index(i).Set(ev)
+	}
+	return out.Interface(), nil
+}
+
+// arrayElemType returns the element type of an array type string by
+// stripping its outermost dimension, e.g. "address[2][]" -> "address[2]".
+func arrayElemType(paramType string) (string, bool) {
+	if !strings.HasSuffix(paramType, "]") {
+		return "", false
+	}
+	idx := strings.LastIndexByte(paramType, '[')
+	if idx <= 0 {
+		return "", false
+	}
+	return paramType[:idx], true
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestABINestedArrays(t *testing.T) {
+	processor := NewABIProcessor(nil)
+	addr1 := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	addr2 := types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+	h1 := eCommon.HexToHash("0x01")
+	h2 := eCommon.HexToHash("0x02")
+
+	tests := []struct {
+		name      string
+		paramType string
+		param     interface{} // value given to the processor
+		native    interface{} // same value as go-ethereum expects it
+	}{
+		{
+			name:      "uint256[][] with empty inner array",
+			paramType: "uint256[][]",
+			param:     [][]interface{}{{1, "2"}, {}, {big.NewInt(3)}},
+			native:    [][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {}, {big.NewInt(3)}},
+		},
+		{
+			name:      "empty outer array",
+			paramType: "uint256[][]",
+			param:     []interface{}{},
+			native:    [][]*big.Int{},
+		},
+		{
+			name:      "address[2][]",
+			paramType: "address[2][]",
+			param:     [][]interface{}{{addr1, addr2.String()}, {addr2, addr1}},
+			native: [][2]eCommon.Address{
+				{addr1.EVMAddress(), addr2.EVMAddress()},
+				{addr2.EVMAddress(), addr1.EVMAddress()},
+			},
+		},
+		{
+			name:      "bytes32[][] merkle proofs",
+			paramType: "bytes32[][]",
+			param:     [][][]byte{{h1.Bytes(), h2.Bytes()}, {}},
+			native:    [][][32]byte{{h1, h2}, {}},
+		},
+		{
+			name:      "uint8[3][2] static",
+			paramType: "uint8[3][2]",
+			param:     `[[1,2,3],[4,5,6]]`,
+			native:    [2][3]uint8{{1, 2, 3}, {4, 5, 6}},
+		},
+		{
+			name:      "string[][]",
+			paramType: "string[][]",
+			param:     [][]string{{"a", "bc"}, {}, {"def"}},
+			native:    [][]string{{"a", "bc"}, {}, {"def"}},
+		},
+		{
+			name:      "native value passes through",
+			paramType: "uint256[2][]",
+			param:     [][2]*big.Int{{big.NewInt(7), big.NewInt(8)}},
+			native:    [][2]*big.Int{{big.NewInt(7), big.NewInt(8)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := processor.encodeParameters([]string{tt.paramType}, []interface{}{tt.param})
+			require.NoError(t, err)
+
+			abiType, err := eABI.NewType(tt.paramType, "", nil)
+			require.NoError(t, err)
+			want, err := eABI.Arguments{{Type: abiType}}.Pack(tt.native)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("static length mismatch", func(t *testing.T) {
+		_, err := processor.encodeParameters([]string{"uint8[3][]"}, []interface{}{[][]int{{1, 2}}})
+		assert.Error(t, err)
+	})
+
+	t.Run("decode address[2][]", func(t *testing.T) {
+		data, err := processor.encodeParameters([]string{"address[2][]"}, []interface{}{[][]interface{}{{addr1, addr2}}})
+		require.NoError(t, err)
+		decoded, err := processor.DecodeResult(data, []*core.SmartContract_ABI_Entry_Param{{Type: "address[2][]"}})
+		require.NoError(t, err)
+
+		outer, ok := decoded.([]interface{})
+		require.True(t, ok, "got %T", decoded)
+		require.Len(t, outer, 1)
+		inner, ok := outer[0].([]interface{})
+		require.True(t, ok, "got %T", outer[0])
+		require.Len(t, inner, 2)
+		assert.Equal(t, addr1.String(), inner[0].(*types.Address).String())
+		assert.Equal(t, addr2.String(), inner[1].(*types.Address).String())
+	})
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/kslamph/tronlib/pb/core"
+)
+
+// maxCachedABIs bounds the ParseABICached cache. Services use a handful of
+// distinct ABIs; past the bound new ABIs are parsed without being cached.
+const maxCachedABIs = 1024
+
+var (
+	abiCacheMu sync.RWMutex
+	abiCache   = make(map[[sha256.Size]byte]*core.SmartContract_ABI)
+)
+
+// ParseABICached is like ParseABI but reuses the result for identical ABI
+// JSON, keyed by its SHA-256 hash. smartcontract.NewInstance and the token
+// managers use it, so creating many managers for the same ABI parses it once.
+//
+// IMPORTANT: the returned ABI is shared by every caller that passed the same
+// JSON and must not be modified; use NewABIProcessor(nil).ParseABI for a
+// private copy.
+func ParseABICached(abiJSON string) (*core.SmartContract_ABI, error) {
+	key := sha256.Sum256([]byte(abiJSON))
+
+	abiCacheMu.RLock()
+	abi, ok := abiCache[key]
+	abiCacheMu.RUnlock()
+	if ok {
+		return abi, nil
+	}
+
+	abi, err := NewABIProcessor(nil).ParseABI(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	abiCacheMu.Lock()
+	defer abiCacheMu.Unlock()
+	if cached, ok := abiCache[key]; ok {
+		return cached, nil
+	}
+	if len(abiCache) < maxCachedABIs {
+		abiCache[key] = abi
+	}
+	return abi, nil
+}
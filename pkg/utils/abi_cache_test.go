@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cacheTestABI = `[{"type":"function","name":"balanceOf","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}]`
+
+func TestParseABICached(t *testing.T) {
+	first, err := ParseABICached(cacheTestABI)
+	require.NoError(t, err)
+	second, err := ParseABICached(cacheTestABI)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	require.Len(t, first.Entrys, 1)
+	assert.Equal(t, "balanceOf", first.Entrys[0].Name)
+
+	private, err := NewABIProcessor(nil).ParseABI(cacheTestABI)
+	require.NoError(t, err)
+	assert.NotSame(t, first, private)
+
+	_, err = ParseABICached("not json")
+	assert.Error(t, err)
+	_, err = ParseABICached("")
+	assert.Error(t, err)
+}
+
+func BenchmarkParseABICached(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseABICached(cacheTestABI); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
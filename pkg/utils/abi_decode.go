@@ -200,14 +200,14 @@ func (p *ABIProcessor) formatDecodedValue(value interface{}, paramType string) i
 		return value
 
 	default:
-		// Handle array types
-		if strings.HasSuffix(paramType, "[]") {
-			baseType := strings.TrimSuffix(paramType, "[]")
-			if reflect.TypeOf(value).Kind() == reflect.Slice {
+		// Handle array types, formatting nested dimensions recursively
+		if elemType, ok := arrayElemType(paramType); ok && value != nil {
+			kind := reflect.TypeOf(value).Kind()
+			if kind == reflect.Slice || kind == reflect.Array {
 				slice := reflect.ValueOf(value)
 				result := make([]interface{}, slice.Len())
 				for i := 0; i < slice.Len(); i++ {
-					result[i] = p.formatDecodedValue(slice.Index(i).Interface(), baseType)
+					result[i] = p.formatDecodedValue(slice.Index(i).Interface(), elemType)
 				}
 				return result
 			}
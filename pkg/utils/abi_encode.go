@@ -2,7 +2,6 @@ package utils
 
 import (
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -77,12 +76,74 @@ func (p *ABIProcessor) EncodeMethod(method string, paramTypes []string, params [
 	// Encode parameters
 	encoded, err := p.encodeParameters(paramTypes, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encode parameters: %v", err)
+		return nil, fmt.Errorf("failed to encode parameters: %w", err)
 	}
 
 	return append(methodID, encoded...), nil
 }
 
+// EncodeMethodNamed encodes a call to method from the processor's ABI, taking
+// arguments by their declared input names instead of by position. When the
+// method is overloaded, the overload whose input names match args exactly is
+// used.
+func (p *ABIProcessor) EncodeMethodNamed(method string, args map[string]interface{}) ([]byte, error) {
+	if p.abi == nil {
+		return nil, fmt.Errorf("no ABI loaded")
+	}
+
+	var lastErr error
+	for _, entry := range p.abi.Entrys {
+		if entry.Name != method || entry.Type != core.SmartContract_ABI_Entry_Function {
+			continue
+		}
+		paramTypes, params, err := orderNamedArgs(entry.Inputs, args)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return p.EncodeMethod(method, paramTypes, params)
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("method %s: %w", method, lastErr)
+	}
+	return nil, fmt.Errorf("method %s not found", method)
+}
+
+// orderNamedArgs arranges args in the order of inputs, requiring every input
+// to be supplied and rejecting names the inputs don't declare.
+func orderNamedArgs(inputs []*core.SmartContract_ABI_Entry_Param, args map[string]interface{}) ([]string, []interface{}, error) {
+	paramTypes := make([]string, len(inputs))
+	params := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		if input.Name == "" {
+			return nil, nil, fmt.Errorf("input %d has no name", i)
+		}
+		v, ok := args[input.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("missing argument %q", input.Name)
+		}
+		paramTypes[i] = input.Type
+		params[i] = v
+	}
+	if len(args) != len(inputs) {
+		for name := range args {
+			if !hasInputNamed(inputs, name) {
+				return nil, nil, fmt.Errorf("unknown argument %q", name)
+			}
+		}
+	}
+	return paramTypes, params, nil
+}
+
+func hasInputNamed(inputs []*core.SmartContract_ABI_Entry_Param, name string) bool {
+	for _, input := range inputs {
+		if input.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // encodeParameters encodes function parameters (internal method)
 func (p *ABIProcessor) encodeParameters(paramTypes []string, params []interface{}) ([]byte, error) {
 	if len(paramTypes) != len(params) {
@@ -103,7 +164,7 @@ func (p *ABIProcessor) encodeParameters(paramTypes []string, params []interface{
 		// Convert parameter to appropriate type
 		convertedValue, err := p.convertParameter(params[i], paramType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert parameter %d: %v", i, err)
+			return nil, fmt.Errorf("failed to convert parameter %d: %w", i, err)
 		}
 		values[i] = convertedValue
 	}
@@ -123,17 +184,14 @@ func (p *ABIProcessor) convertParameter(param interface{}, paramType string) (in
 		return nil, fmt.Errorf("nil parameter not allowed")
 	}
 
-	// Handle array types
-	if strings.HasSuffix(paramType, "[]") {
-		baseType := strings.TrimSuffix(paramType, "[]")
-		// TODO: Implement full tuple support for array types
-		// For now, we handle arrays of basic types
-		return p.convertArrayParameter(param, baseType)
+	// Handle array types, including nested and fixed-size arrays
+	// TODO: Implement full tuple support for array types
+	if strings.HasSuffix(paramType, "]") {
+		return p.convertArrayParameter(param, paramType)
 	}
 
 	// Handle scalar types
-	// Leverage go-ethereum/accounts/abi's native ability to handle various Go integer types
-	// Directly pass the provided interface{} parameter for number types to eABI.Arguments.Pack
+	// Integers are range-checked and converted to the Go type go-ethereum expects
 	switch paramType {
 	case "address":
 		return p.convertAddress(param)
@@ -150,7 +208,10 @@ func (p *ABIProcessor) convertParameter(param interface{}, paramType string) (in
 	case "bytes8":
 		return p.convertBytes(param, 8) // 8 indicates fixed-size 8 bytes
 	default:
-		// For integer types and other types, pass the parameter directly
+		if isIntType(paramType) {
+			return p.convertInteger(param, paramType)
+		}
+		// For other types, pass the parameter directly
 		// go-ethereum/accounts/abi will handle the conversion and validation
 		return param, nil
 	}
@@ -290,87 +351,3 @@ func (p *ABIProcessor) convertBytes(param interface{}, fixedSize int) (interface
 		return nil, fmt.Errorf("bytes parameter must be string, []byte, or [N]byte")
 	}
 }
-
-// convertArrayParameter converts array parameter
-func (p *ABIProcessor) convertArrayParameter(param interface{}, baseType string) (interface{}, error) {
-	// Handle JSON string arrays
-	if jsonStr, ok := param.(string); ok {
-		var jsonArray []interface{}
-		if err := json.Unmarshal([]byte(jsonStr), &jsonArray); err != nil {
-			return nil, fmt.Errorf("failed to parse array JSON: %v", err)
-		}
-		return p.convertArrayElements(jsonArray, baseType)
-	}
-
-	// Handle slice directly
-	if reflect.TypeOf(param).Kind() == reflect.Slice {
-		// For integer types, return the slice directly
-		// The go-ethereum ABI package expects the actual slice type
-		switch baseType {
-		case "uint256", "uint128", "uint64", "uint32", "uint16", "uint8",
-			"int256", "int128", "int64", "int32", "int16", "int8":
-			return param, nil
-		default:
-			// For other types, convert elements individually
-			slice := reflect.ValueOf(param)
-			elements := make([]interface{}, slice.Len())
-			for i := 0; i < slice.Len(); i++ {
-				elements[i] = slice.Index(i).Interface()
-			}
-			return p.convertArrayElements(elements, baseType)
-		}
-	}
-
-	return nil, fmt.Errorf("array parameter must be JSON string or slice")
-}
-
-// convertArrayElements converts array elements to appropriate types
-func (p *ABIProcessor) convertArrayElements(elements []interface{}, baseType string) (interface{}, error) {
-	switch baseType {
-	case "address":
-		addresses := make([]eCommon.Address, len(elements))
-		for i, elem := range elements {
-			addr, err := p.convertAddress(elem)
-			if err != nil {
-				return nil, fmt.Errorf("invalid address at index %d: %v", i, err)
-			}
-			addresses[i] = addr
-		}
-		return addresses, nil
-
-	case "uint256", "uint128", "uint64", "uint32", "uint16", "uint8":
-		// For integer types, return the elements directly
-		// go-ethereum/accounts/abi expects the actual slice of values
-		return elements, nil
-
-	case "int256", "int128", "int64", "int32", "int16", "int8":
-		// For integer types, return the elements directly
-		// go-ethereum/accounts/abi expects the actual slice of values
-		return elements, nil
-
-	case "bool":
-		bools := make([]bool, len(elements))
-		for i, elem := range elements {
-			b, err := p.convertBool(elem)
-			if err != nil {
-				return nil, fmt.Errorf("invalid bool at index %d: %v", i, err)
-			}
-			bools[i] = b
-		}
-		return bools, nil
-
-	case "string":
-		strings := make([]string, len(elements))
-		for i, elem := range elements {
-			s, err := p.convertString(elem)
-			if err != nil {
-				return nil, fmt.Errorf("invalid string at index %d: %v", i, err)
-			}
-			strings[i] = s
-		}
-		return strings, nil
-
-	default:
-		return nil, fmt.Errorf("unsupported array element type: %s", baseType)
-	}
-}
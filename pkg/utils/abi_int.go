@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// parseIntType parses an ABI integer type name such as "uint24" or "int128".
+func parseIntType(paramType string) (bits int, signed bool, ok bool) {
+	rest := paramType
+	switch {
+	case strings.HasPrefix(rest, "uint"):
+		rest = rest[len("uint"):]
+	case strings.HasPrefix(rest, "int"):
+		rest = rest[len("int"):]
+		signed = true
+	default:
+		return 0, false, false
+	}
+	n, err := strconv.Atoi(rest)
+	if err != nil || n < 8 || n > 256 || n%8 != 0 {
+		return 0, false, false
+	}
+	return n, signed, true
+}
+
+// isIntType reports whether paramType is an ABI intN or uintN type.
+func isIntType(paramType string) bool {
+	_, _, ok := parseIntType(paramType)
+	return ok
+}
+
+// convertInteger converts param to the Go type go-ethereum expects for
+// paramType (uint8..uint64/int8..int64 for those exact widths, *big.Int
+// otherwise), rejecting values outside the type's range with ErrInvalidData.
+//
+// Accepted inputs are Go integer types, *big.Int, big.Int, decimal or
+// 0x-prefixed hex strings, and integral float64 values as produced by JSON.
+func (p *ABIProcessor) convertInteger(param interface{}, paramType string) (interface{}, error) {
+	bits, signed, ok := parseIntType(paramType)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported integer type %s", ErrInvalidData, paramType)
+	}
+	v, err := abiIntValue(param)
+	if err != nil {
+		return nil, err
+	}
+
+	var min, max *big.Int
+	if signed {
+		max = new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		min = new(big.Int).Neg(max)
+	} else {
+		max = new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		min = new(big.Int)
+	}
+	if v.Cmp(min) < 0 || v.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("%w: value %s out of range for %s", ErrInvalidData, v, paramType)
+	}
+
+	abiType, err := eABI.NewType(paramType, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidData, err)
+	}
+	goType := abiType.GetType()
+	if goType.Kind() == reflect.Ptr {
+		return v, nil
+	}
+	out := reflect.New(goType).Elem()
+	if signed {
+		out.SetInt(v.Int64())
+	} else {
+		out.SetUint(v.Uint64())
+	}
+	return out.Interface(), nil
+}
+
+// abiIntValue converts a numeric ABI parameter to *big.Int.
+func abiIntValue(param interface{}) (*big.Int, error) {
+	switch v := param.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("%w: nil *big.Int", ErrInvalidData)
+		}
+		return new(big.Int).Set(v), nil
+	case big.Int:
+		return new(big.Int).Set(&v), nil
+	case string:
+		s := strings.TrimSpace(v)
+		base := 10
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			s, base = s[2:], 16
+		}
+		n, ok := new(big.Int).SetString(s, base)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid integer string %q", ErrInvalidData, v)
+		}
+		return n, nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return nil, fmt.Errorf("%w: %v is not an exactly representable integer", ErrInvalidData, v)
+		}
+		return big.NewInt(int64(v)), nil
+	}
+
+	rv := reflect.ValueOf(param)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("%w: cannot use %T as an integer", ErrInvalidData, param)
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kslamph/tronlib/pb/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestABIIntegerBoundaries(t *testing.T) {
+	processor := NewABIProcessor(nil)
+
+	pow2 := func(n uint) *big.Int { return new(big.Int).Lsh(big.NewInt(1), n) }
+	sub := func(a *big.Int, b int64) *big.Int { return new(big.Int).Sub(a, big.NewInt(b)) }
+	neg := func(a *big.Int) *big.Int { return new(big.Int).Neg(a) }
+
+	tests := []struct {
+		name      string
+		paramType string
+		value     interface{}
+		wantErr   bool
+	}{
+		{"uint8 zero", "uint8", 0, false},
+		{"uint8 max", "uint8", 255, false},
+		{"uint8 overflow", "uint8", 256, true},
+		{"uint8 negative", "uint8", -1, true},
+		{"int8 min", "int8", -128, false},
+		{"int8 max", "int8", int8(127), false},
+		{"int8 overflow", "int8", 128, true},
+		{"int8 underflow", "int8", -129, true},
+		{"uint24 max", "uint24", sub(pow2(24), 1), false},
+		{"uint24 overflow", "uint24", pow2(24), true},
+		{"int24 min", "int24", neg(pow2(23)), false},
+		{"int24 max", "int24", "8388607", false},
+		{"int24 underflow", "int24", sub(neg(pow2(23)), 1), true},
+		{"uint64 max", "uint64", uint64(1<<64 - 1), false},
+		{"int64 min", "int64", int64(-1 << 63), false},
+		{"uint96 max hex", "uint96", "0xffffffffffffffffffffffff", false},
+		{"uint96 overflow hex", "uint96", "0x1000000000000000000000000", true},
+		{"int128 min", "int128", neg(pow2(127)), false},
+		{"int128 overflow", "int128", pow2(127), true},
+		{"uint256 max", "uint256", sub(pow2(256), 1), false},
+		{"uint256 overflow", "uint256", pow2(256), true},
+		{"int256 min", "int256", neg(pow2(255)), false},
+		{"non-integral float", "uint32", 1.5, true},
+		{"invalid string", "uint32", "abc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := processor.encodeParameters([]string{tt.paramType}, []interface{}{tt.value})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidData)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, data, 32)
+
+			decoded, err := processor.DecodeResult(data, []*core.SmartContract_ABI_Entry_Param{{Type: tt.paramType}})
+			require.NoError(t, err)
+			want, err := abiIntValue(tt.value)
+			require.NoError(t, err)
+			got, err := abiIntValue(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, 0, want.Cmp(got), "want %s, got %s", want, got)
+		})
+	}
+}
+
+func TestABIIntegerArrays(t *testing.T) {
+	processor := NewABIProcessor(nil)
+
+	_, err := processor.EncodeMethod("f", []string{"uint24[]"}, []interface{}{[]interface{}{1, "16777215"}})
+	require.NoError(t, err)
+
+	_, err = processor.EncodeMethod("f", []string{"int16[]"}, []interface{}{[]int{-32768, 32767}})
+	require.NoError(t, err)
+
+	_, err = processor.EncodeMethod("f", []string{"uint8[]"}, []interface{}{[]int{1, 256}})
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	_, err = processor.EncodeMethod("f", []string{"uint24"}, []interface{}{1 << 24})
+	assert.ErrorIs(t, err, ErrInvalidData)
+}
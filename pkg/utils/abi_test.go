@@ -548,3 +548,46 @@ func TestABIParser_GetMethodTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestABIProcessor_EncodeMethodNamed(t *testing.T) {
+	abiJSON := `[
+		{"name":"transfer","type":"function","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+		{"name":"transfer","type":"function","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"},{"name":"memo","type":"string"}],"outputs":[]}
+	]`
+	abi, err := NewABIProcessor(nil).ParseABI(abiJSON)
+	require.NoError(t, err)
+	processor := NewABIProcessor(abi)
+
+	to := "TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb"
+
+	t.Run("orders by declared names", func(t *testing.T) {
+		got, err := processor.EncodeMethodNamed("transfer", map[string]interface{}{"amount": 1000, "to": to})
+		require.NoError(t, err)
+		want, err := processor.EncodeMethod("transfer", []string{"address", "uint256"}, []interface{}{to, 1000})
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("selects overload by names", func(t *testing.T) {
+		got, err := processor.EncodeMethodNamed("transfer", map[string]interface{}{"amount": 1, "memo": "hi", "to": to})
+		require.NoError(t, err)
+		want, err := processor.EncodeMethod("transfer", []string{"address", "uint256", "string"}, []interface{}{to, 1, "hi"})
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("missing argument", func(t *testing.T) {
+		_, err := processor.EncodeMethodNamed("transfer", map[string]interface{}{"to": to})
+		assert.ErrorContains(t, err, "missing argument")
+	})
+
+	t.Run("unknown argument", func(t *testing.T) {
+		_, err := processor.EncodeMethodNamed("transfer", map[string]interface{}{"to": to, "amount": 1, "value": 2})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		_, err := processor.EncodeMethodNamed("approve", map[string]interface{}{})
+		assert.ErrorContains(t, err, "not found")
+	})
+}
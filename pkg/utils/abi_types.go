@@ -1,9 +1,15 @@
 package utils
 
 import (
+	"errors"
+
 	"github.com/kslamph/tronlib/pb/core"
 )
 
+// ErrInvalidData is returned when a value cannot be encoded as its ABI type,
+// for example an integer outside the range of its intN/uintN type.
+var ErrInvalidData = errors.New("invalid data")
+
 // ABIProcessor handles all smart contract ABI operations including encoding, decoding, parsing, and event processing
 type ABIProcessor struct {
 	abi *core.SmartContract_ABI
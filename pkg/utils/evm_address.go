@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"bytes"
+
+	eCommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/kslamph/tronlib/pkg/types"
+)
+
+// TronToEVMAddresses converts TRON addresses to their 20-byte EVM form, in
+// order. A nil address converts to the zero address.
+func TronToEVMAddresses(addrs []*types.Address) []eCommon.Address {
+	out := make([]eCommon.Address, len(addrs))
+	for i, addr := range addrs {
+		if addr != nil {
+			out[i] = addr.EVMAddress()
+		}
+	}
+	return out
+}
+
+// EVMToTronAddresses converts 20-byte EVM addresses to TRON addresses, in
+// order.
+func EVMToTronAddresses(addrs []eCommon.Address) []*types.Address {
+	out := make([]*types.Address, len(addrs))
+	for i, addr := range addrs {
+		// A 20-byte EVM address always converts
+		out[i], _ = types.NewAddressFromEVM(addr)
+	}
+	return out
+}
+
+// RewriteCalldataAddresses returns a copy of data in which every 32-byte
+// word holding a TRON-form address (11 zero bytes, the 0x41 prefix, then 20
+// address bytes) is rewritten to the EVM form the ABI expects, by clearing
+// the prefix byte. A leading 4-byte method selector is skipped when the data
+// length indicates one.
+//
+// This is a heuristic: any word that happens to match the pattern, such as a
+// uint256 in that range, is rewritten too. Prefer encoding addresses through
+// ABIProcessor when the types are known.
+func RewriteCalldataAddresses(data []byte) []byte {
+	out := append([]byte(nil), data...)
+	start := 0
+	if len(out)%32 == 4 {
+		start = 4
+	}
+	var zeroPad [11]byte
+	for off := start; off+32 <= len(out); off += 32 {
+		word := out[off : off+32]
+		if word[11] == types.AddressPrefixByte && bytes.Equal(word[:11], zeroPad[:]) {
+			word[11] = 0
+		}
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/kslamph/tronlib/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEVMAddressConversions(t *testing.T) {
+	addr1 := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+	addr2 := types.MustNewAddressFromBase58("TXNYeYdao7JL7wBtmzbk7mAie7UZsdgVjx")
+
+	evm := TronToEVMAddresses([]*types.Address{addr1, nil, addr2})
+	require.Len(t, evm, 3)
+	assert.Equal(t, addr1.EVMAddress(), evm[0])
+	assert.Equal(t, eCommon.Address{}, evm[1])
+	assert.Equal(t, addr2.EVMAddress(), evm[2])
+
+	tron := EVMToTronAddresses([]eCommon.Address{evm[0], evm[2]})
+	require.Len(t, tron, 2)
+	assert.Equal(t, addr1.String(), tron[0].String())
+	assert.Equal(t, addr2.String(), tron[1].String())
+}
+
+func TestRewriteCalldataAddresses(t *testing.T) {
+	processor := NewABIProcessor(nil)
+	addr := types.MustNewAddressFromBase58("TWd4WrZ9wn84f5x1hZhL4DHvk738ns5jwb")
+
+	want, err := processor.EncodeMethod("transfer", []string{"address", "uint256"}, []interface{}{addr, int64(5)})
+	require.NoError(t, err)
+
+	// Hand-built calldata carrying the 21-byte TRON form in the address word.
+	tronForm := append([]byte(nil), want...)
+	copy(tronForm[4+11:4+32], addr.Bytes())
+	require.NotEqual(t, want, tronForm)
+
+	got := RewriteCalldataAddresses(tronForm)
+	assert.Equal(t, want, got)
+	assert.NotEqual(t, want, tronForm, "input must not be modified")
+	assert.Equal(t, want, RewriteCalldataAddresses(want), "EVM-form calldata is unchanged")
+}
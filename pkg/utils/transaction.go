@@ -12,22 +12,15 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-// GetTransactionID calculates the transaction ID from a transaction
+// GetTransactionID calculates the transaction ID, the sha256 hash of the
+// protobuf-encoded RawData. It returns nil when the transaction has no raw
+// data. See types.TransactionID.
 func GetTransactionID(tx *core.Transaction) []byte {
-	if tx == nil || tx.RawData == nil {
-		return nil
-	}
-
-	// Marshal raw data for hashing
-	rawData, err := proto.Marshal(tx.RawData)
+	txid, err := types.TransactionID(tx)
 	if err != nil {
 		return nil
 	}
-
-	// Calculate SHA256 hash
-	hasher := sha256.New()
-	hasher.Write(rawData)
-	return hasher.Sum(nil)
+	return txid
 }
 
 func ExtractSigners(tx *core.Transaction) ([]*types.Address, error) {
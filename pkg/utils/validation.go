@@ -36,6 +36,23 @@ func ValidateAmount(amount *big.Int) error {
 	return nil
 }
 
+// ValidateUint256 checks that v is set, non-negative and fits in 256 bits,
+// the range of the ABI uint256 type. what names the value in the error
+// message (e.g. "token id"), and the error wraps sentinel, such as
+// types.ErrInvalidParameter or types.ErrInvalidAmount.
+func ValidateUint256(sentinel error, what string, v *big.Int) error {
+	if v == nil {
+		return fmt.Errorf("%w: %s cannot be nil", sentinel, what)
+	}
+	if v.Sign() < 0 {
+		return fmt.Errorf("%w: %s cannot be negative, got %s", sentinel, what, v)
+	}
+	if v.BitLen() > 256 {
+		return fmt.Errorf("%w: %s exceeds uint256", sentinel, what)
+	}
+	return nil
+}
+
 // VerifyMessageV2 verifies a message signature using TIP-191 format (v2)
 func VerifyMessageV2(message string, signature string, expectedAddress string) (bool, error) {
 	// Validate the expected address
@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/kslamph/tronlib/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -121,6 +122,30 @@ func TestAmountValidation(t *testing.T) {
 
 }
 
+func TestUint256Validation(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	for _, v := range []*big.Int{big.NewInt(0), big.NewInt(1), maxUint256} {
+		assert.NoError(t, ValidateUint256(types.ErrInvalidParameter, "token id", v))
+	}
+
+	invalidCases := []struct {
+		name  string
+		value *big.Int
+	}{
+		{name: "Nil", value: nil},
+		{name: "Negative", value: big.NewInt(-1)},
+		{name: "Overflow", value: new(big.Int).Add(maxUint256, big.NewInt(1))},
+	}
+	for _, tc := range invalidCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateUint256(types.ErrInvalidAmount, "amount", tc.value)
+			assert.ErrorIs(t, err, types.ErrInvalidAmount)
+			assert.Contains(t, err.Error(), "amount")
+		})
+	}
+}
+
 func TestContractValidation(t *testing.T) {
 
 	t.Run("Contract data validation", func(t *testing.T) {
@@ -0,0 +1,58 @@
+package voting
+
+import (
+	"context"
+	"time"
+
+	"github.com/kslamph/tronlib/pkg/network"
+)
+
+// maintenancePollInterval is how often WaitForNextMaintenance re-checks the
+// node once the boundary has passed locally; one block interval.
+const maintenancePollInterval = 3 * time.Second
+
+// WaitForNextMaintenance blocks until the next maintenance period has
+// started, i.e. until votes cast before the call have taken effect. It
+// returns ctx.Err() if ctx ends first.
+//
+// The boundary is read from the node. Because maintenance runs in the first
+// block after the boundary and local clocks can drift, the wait ends only
+// once the node reports a later next maintenance time.
+//
+// Example:
+//
+//	if err := vm.WaitForNextMaintenance(ctx); err != nil {
+//	    // handle error
+//	}
+//	txExt, err := vm.WithdrawBalance2(ctx, owner) // claim rewards of the new cycle
+func (m *Manager) WaitForNextMaintenance(ctx context.Context) error {
+	target, err := m.nextMaintenanceTime(ctx)
+	if err != nil {
+		return err
+	}
+	wait := time.Until(target)
+	for {
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		next, err := m.nextMaintenanceTime(ctx)
+		if err != nil {
+			return err
+		}
+		if next.After(target) {
+			return nil
+		}
+		wait = maintenancePollInterval
+	}
+}
+
+// nextMaintenanceTime fetches the start of the next maintenance period.
+func (m *Manager) nextMaintenanceTime(ctx context.Context) (time.Time, error) {
+	return network.NewManager(m.conn).GetNextMaintenanceTime(ctx)
+}
@@ -2,7 +2,9 @@ package voting_test
 
 import (
 	"context"
+	"errors"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -138,3 +140,53 @@ func TestVotingManager_InputValidationErrors(t *testing.T) {
 		t.Fatalf("expected error for >100")
 	}
 }
+
+// maintenanceServer reports the boundaries in times, one per call, repeating
+// the last.
+type maintenanceServer struct {
+	api.UnimplementedWalletServer
+	times []time.Time
+	calls int32
+}
+
+func (s *maintenanceServer) GetNextMaintenanceTime(ctx context.Context, in *api.EmptyMessage) (*api.NumberMessage, error) {
+	i := int(atomic.AddInt32(&s.calls, 1)) - 1
+	if i >= len(s.times) {
+		i = len(s.times) - 1
+	}
+	return &api.NumberMessage{Num: s.times[i].UnixMilli()}, nil
+}
+
+func TestVotingManager_WaitForNextMaintenance(t *testing.T) {
+	// The node reports milliseconds, so keep the boundary on one.
+	boundary := time.Now().Add(50 * time.Millisecond).Truncate(time.Millisecond)
+	srv := &maintenanceServer{times: []time.Time{boundary, boundary.Add(6 * time.Hour)}}
+	lis, _, cleanup := newVoteBufServer(t, srv)
+	t.Cleanup(cleanup)
+
+	c, err := client.NewClientWithDialer("passthrough:///bufnet", func(ctx context.Context, s string) (net.Conn, error) { return lis.DialContext(ctx) }, client.WithTimeout(500*time.Millisecond), client.WithPool(1, 1))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	defer c.Close()
+	m := voting.NewManager(c)
+
+	if err := m.WaitForNextMaintenance(context.Background()); err != nil {
+		t.Fatalf("WaitForNextMaintenance: %v", err)
+	}
+	if time.Now().Before(boundary) {
+		t.Fatalf("returned before the maintenance boundary")
+	}
+	if got := atomic.LoadInt32(&srv.calls); got != 2 {
+		t.Fatalf("expected 2 node queries, got %d", got)
+	}
+
+	// A boundary far in the future is cut short by the context.
+	srv.times = []time.Time{time.Now().Add(time.Hour)}
+	atomic.StoreInt32(&srv.calls, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := m.WaitForNextMaintenance(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline, got %v", err)
+	}
+}